@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -17,8 +18,19 @@ var (
 )
 
 func main() {
-	if len(os.Args) > 1 {
-		arg := os.Args[1]
+	args, noColor := extractFlag(os.Args[1:], "--no-color")
+	args, metricsAddr := extractValueFlag(args, "--metrics")
+	if noColor {
+		// Children inherit it too, see devdash.LaunchEnv
+		_ = os.Setenv("NO_COLOR", "1")
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		tui.SetMonochrome()
+	}
+
+	profile, session := "", ""
+	if len(args) > 0 {
+		arg := args[0]
 		if arg == "--help" || arg == "-h" {
 			printUsage()
 			os.Exit(0)
@@ -27,6 +39,23 @@ func main() {
 			fmt.Printf("devdash %s (%s)\n", version, commit)
 			os.Exit(0)
 		}
+		if arg == "scan" {
+			os.Exit(runScan(args[1:], os.Stdout, os.Stderr))
+		}
+		if arg == "up" {
+			if len(args) != 2 {
+				fmt.Fprintln(os.Stderr, "Usage: devdash up <profile>")
+				os.Exit(2)
+			}
+			profile = args[1]
+		}
+		if arg == "logs" {
+			if len(args) != 2 {
+				fmt.Fprintln(os.Stderr, "Usage: devdash logs <session>")
+				os.Exit(2)
+			}
+			session = args[1]
+		}
 	}
 
 	// Load persistent config
@@ -44,6 +73,11 @@ func main() {
 
 	// Initialize process manager
 	pm := devdash.NewProcessManager(sessionsDir, logsDir)
+	if cfg.Webhook != nil && cfg.Webhook.URL != "" {
+		pm.SetWebhook(devdash.NewWebhook(cfg.Webhook.URL, cfg.Webhook.Events))
+	}
+	pm.SetAutoRemove(cfg.AutoRemoveAfter())
+	pm.SetPreload(int64(cfg.ReconnectPreload) << 10)
 
 	// Reconnect to existing sessions
 	reconnected := pm.Reconnect()
@@ -51,13 +85,92 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Reconnected to %d existing process(es)\n", len(reconnected))
 	}
 
+	// Serve Prometheus metrics when asked; the flag wins over config
+	if metricsAddr == "" {
+		metricsAddr = cfg.MetricsAddr
+	}
+	if metricsAddr != "" {
+		srv, err := pm.ServeMetrics(metricsAddr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer srv.Close()
+	}
+
 	// Create and run TUI
 	app := tui.NewApp(cfg, pm)
-	p := tea.NewProgram(app, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+
+	// Open straight into one session's log, skipping the dashboard
+	if session != "" {
+		var err error
+		if app, err = app.OpenSession(session); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Queue a startup profile's services; they launch once the TUI is up
+	var warnings []string
+	if profile != "" {
+		var err error
+		app, warnings, err = app.StartProfile(profile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Focus reports let the TUI stop rendering log output while unfocused
+	p := tea.NewProgram(app, tea.WithAltScreen(), tea.WithReportFocus())
+	final, err := p.Run()
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	if app, ok := final.(tui.App); ok {
+		app.Close()
+	}
+
+	// Shown after the alt screen is gone so they aren't wiped
+	for _, w := range warnings {
+		fmt.Fprintf(os.Stderr, "profile %s: skipped %s\n", profile, w)
+	}
+}
+
+// extractFlag removes every occurrence of a boolean flag from args and
+// reports whether it was present, so it can appear before or after a subcommand
+func extractFlag(args []string, flag string) ([]string, bool) {
+	out := make([]string, 0, len(args))
+	found := false
+	for _, a := range args {
+		if a == flag {
+			found = true
+			continue
+		}
+		out = append(out, a)
+	}
+	return out, found
+}
+
+// extractValueFlag removes a `--flag value` or `--flag=value` pair from args
+// and returns its value ("" when absent). The last occurrence wins.
+func extractValueFlag(args []string, flag string) ([]string, string) {
+	out := make([]string, 0, len(args))
+	value := ""
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		switch {
+		case a == flag && i+1 < len(args):
+			value = args[i+1]
+			i++
+		case strings.HasPrefix(a, flag+"="):
+			value = strings.TrimPrefix(a, flag+"=")
+		default:
+			out = append(out, a)
+		}
+	}
+	return out, value
 }
 
 // printUsage displays help information
@@ -67,20 +180,53 @@ func printUsage() {
 Usage:
   devdash              Start the TUI dashboard
   devdash --help       Show this help message
+  devdash --no-color   Disable colors (also honors the NO_COLOR env var)
+  devdash --metrics <addr>
+                       Serve Prometheus metrics at http://<addr>/metrics
+                       (a bare port binds to localhost)
+  devdash scan [--json]
+                       List discovered repos and projects (no TUI)
+  devdash up <profile> Start the TUI and launch every service in a profile
+  devdash logs <session>
+                       Open one running session's log fullscreen
+                       (q quits, esc shows the dashboard)
 
 Keyboard shortcuts:
   n          Launch new process
   k          Kill selected process
   r          Restart selected process
+  d          Duplicate selected process (new instance, next free port)
+  X          Restart all errored processes
+  K          Send a signal (SIGHUP, SIGUSR1, ...) to selected process
+  a          Attach to an external process by PID
   t          Toggle Cloudflare tunnel (requires cloudflared)
   u          Copy tunnel URL
+  U          Pin/unpin the tunnel URL banner
+  l          Copy local URL (http://localhost:<port>)
+  L          Copy a curl command for the local or tunnel URL
+  R          Reveal session directory in the file manager
+  b          Start/stop the build timer of selected process
   s          Settings (manage scan directories)
+  F          Search logs across all sessions
+  P          Flush partial log lines of all sessions
+  W          Save session port/env to the project's .devdash.json
   Enter      Fullscreen log view
-  Tab        Switch focus (list / logs)
+  e / E      Jump to next / previous error or warning in the log
+  Tab        Next panel (list / logs); Shift+Tab previous
+  < / >      Narrow / widen the session list
+  D          Toggle dense session list
+  w          Toggle indented continuation rows of wrapped log lines
+  O          Toggle the all-sessions overview table
+  V          Split view: two sessions' logs side by side
+  T          Edit tags of selected session
+  f          Cycle tag filter
+  h          Cycle status filter (all / running / not running)
   Up/Down    Navigate session list
   j/k        Navigate (vim-style)
   G          Jump to bottom of logs
   g          Jump to top of logs
+  |          Toggle the scrollbar (fullscreen log view)
+  J          Toggle compact JSON log lines (fullscreen log view)
   q          Quit (processes keep running)
   Esc        Close popup / back to dashboard
 
@@ -89,6 +235,9 @@ Configuration:
   Sessions dir: ~/.config/local-dev/sessions/
   Logs dir:     ~/.config/local-dev/logs/
 
+  Override the directory with DEVDASH_CONFIG_DIR, or set XDG_CONFIG_HOME
+  to use $XDG_CONFIG_HOME/local-dev.
+
 On first run, the settings overlay opens automatically.
 Add scan directories pointing to your worktree parent directories.
 
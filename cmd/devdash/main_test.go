@@ -0,0 +1,48 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractFlag(t *testing.T) {
+	tests := []struct {
+		args  []string
+		want  []string
+		found bool
+	}{
+		{nil, []string{}, false},
+		{[]string{"up", "web"}, []string{"up", "web"}, false},
+		{[]string{"--no-color"}, []string{}, true},
+		{[]string{"up", "--no-color", "web", "--no-color"}, []string{"up", "web"}, true},
+		{[]string{"--no-color=1"}, []string{"--no-color=1"}, false},
+	}
+	for _, tt := range tests {
+		got, found := extractFlag(tt.args, "--no-color")
+		if !reflect.DeepEqual(got, tt.want) || found != tt.found {
+			t.Errorf("extractFlag(%q) = %q, %v; want %q, %v", tt.args, got, found, tt.want, tt.found)
+		}
+	}
+}
+
+func TestExtractValueFlag(t *testing.T) {
+	tests := []struct {
+		args  []string
+		want  []string
+		value string
+	}{
+		{nil, []string{}, ""},
+		{[]string{"scan", "--json"}, []string{"scan", "--json"}, ""},
+		{[]string{"--metrics", "9100"}, []string{}, "9100"},
+		{[]string{"--metrics=:9100", "up", "web"}, []string{"up", "web"}, ":9100"},
+		{[]string{"--metrics", "9100", "--metrics=9200"}, []string{}, "9200"},
+		{[]string{"up", "--metrics"}, []string{"up", "--metrics"}, ""}, // no value to take
+		{[]string{"--metricsx"}, []string{"--metricsx"}, ""},
+	}
+	for _, tt := range tests {
+		got, value := extractValueFlag(tt.args, "--metrics")
+		if !reflect.DeepEqual(got, tt.want) || value != tt.value {
+			t.Errorf("extractValueFlag(%q) = %q, %q; want %q, %q", tt.args, got, value, tt.want, tt.value)
+		}
+	}
+}
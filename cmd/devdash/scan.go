@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/kimaguri/simplx-toolkit/internal/config"
+	"github.com/kimaguri/simplx-toolkit/internal/discovery"
+)
+
+// scanWorktree is the JSON shape of a discovered worktree
+type scanWorktree struct {
+	Name        string        `json:"name"`
+	Path        string        `json:"path"`
+	Branch      string        `json:"branch"`
+	IsWorktree  bool          `json:"is_worktree"`
+	MainProject string        `json:"main_project,omitempty"`
+	Projects    []scanProject `json:"projects"`
+}
+
+// scanProject is the JSON shape of a detected project
+type scanProject struct {
+	Name           string   `json:"name"`
+	Path           string   `json:"path"`
+	PkgName        string   `json:"pkg_name,omitempty"`
+	IsEncore       bool     `json:"is_encore"`
+	Framework      string   `json:"framework,omitempty"`
+	PackageManager string   `json:"package_manager"`
+	Port           int      `json:"port"`
+	PortFixed      bool     `json:"port_fixed"`
+	Scripts        []string `json:"scripts"`
+	WorkspaceRoot  string   `json:"workspace_root,omitempty"`
+	NodeVersion    string   `json:"node_version,omitempty"` // from .nvmrc/.node-version
+	Filtered       bool     `json:"filtered"`               // hidden from the launcher by ignore/include patterns
+}
+
+// runScan implements `devdash scan [--json]`: runs discovery over the configured
+// scan dirs and prints the result. Returns the process exit code.
+func runScan(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("scan", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	asJSON := fs.Bool("json", false, "print results as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	cfg := config.LoadConfig()
+	if len(cfg.ScanDirs) == 0 {
+		_, _ = fmt.Fprintln(stderr, "No scan directories configured. Run devdash and add one in Settings.")
+		return 1
+	}
+
+	results := scanAll(cfg)
+
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			_, _ = fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	for _, wt := range results {
+		_, _ = fmt.Fprintf(stdout, "%s (%s) %s\n", wt.Name, wt.Branch, wt.Path)
+		for _, p := range wt.Projects {
+			note := ""
+			if p.Filtered {
+				note = "  [filtered]"
+			}
+			_, _ = fmt.Fprintf(stdout, "  %-24s %-5s port=%-5d %s%s\n", p.Name, p.PackageManager, p.Port, p.Path, note)
+		}
+	}
+	return 0
+}
+
+// scanAll discovers worktrees and their projects, marking projects hidden by the
+// configured ignore/include patterns instead of dropping them
+func scanAll(cfg *config.LocalConfig) []scanWorktree {
+	filter := discovery.Filter{
+		Ignore:          cfg.IgnorePatterns,
+		Include:         cfg.IncludePatterns,
+		PriorityScripts: cfg.PriorityScripts,
+		Orchestrators:   cfg.Orchestrators,
+	}
+	unfiltered := filter // same script rules, nothing hidden
+	unfiltered.Ignore, unfiltered.Include = nil, nil
+	results := []scanWorktree{}
+
+	for _, wt := range discovery.ScanWorktrees(cfg.ScanDirs) {
+		visible := make(map[string]bool)
+		for _, p := range discovery.DetectProjectsFiltered(wt, filter) {
+			visible[p.Path] = true
+		}
+
+		out := scanWorktree{
+			Name:        wt.Name,
+			Path:        wt.Path,
+			Branch:      wt.Branch,
+			IsWorktree:  wt.IsWorktree,
+			MainProject: wt.MainProject,
+			Projects:    []scanProject{},
+		}
+		for _, p := range discovery.DetectProjectsFiltered(wt, unfiltered) {
+			port := p.DetectedPort
+			if p.Port > 0 {
+				port = p.Port
+			}
+			if saved := cfg.GetPort(config.PortKey(wt.Name, p.Name)); saved > 0 {
+				port = saved
+			}
+			scripts := p.Scripts
+			if scripts == nil {
+				scripts = []string{}
+			}
+			out.Projects = append(out.Projects, scanProject{
+				Name:           p.Name,
+				Path:           p.Path,
+				PkgName:        p.PkgName,
+				IsEncore:       p.IsEncore,
+				Framework:      p.Framework,
+				PackageManager: p.PackageManager,
+				Port:           port,
+				PortFixed:      p.PortFixed,
+				Scripts:        scripts,
+				WorkspaceRoot:  p.WorkspaceRoot,
+				NodeVersion:    p.NodeVersion,
+				Filtered:       !visible[p.Path],
+			})
+		}
+		results = append(results, out)
+	}
+	return results
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kimaguri/simplx-toolkit/internal/config"
+)
+
+// writeFile creates path and its parent directories with content
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// scanFixture creates a scan dir holding one repo, shop, on branch main
+// with a web and an admin package
+func scanFixture(t *testing.T) (scan, repo string) {
+	scan = t.TempDir()
+	repo = filepath.Join(scan, "shop")
+	writeFile(t, filepath.Join(repo, ".git", "HEAD"), "ref: refs/heads/main\n")
+	writeFile(t, filepath.Join(repo, "web", "package.json"), `{"name":"web","scripts":{"dev":"vite","build":"vite build"}}`)
+	writeFile(t, filepath.Join(repo, "admin", "package.json"), `{"name":"admin","scripts":{"dev":"next dev"}}`)
+	return scan, repo
+}
+
+func TestScanAll(t *testing.T) {
+	scan, repo := scanFixture(t)
+	cfg := &config.LocalConfig{
+		ScanDirs:       []string{scan},
+		IgnorePatterns: []string{"admin"},
+		PortOverrides:  map[string]int{config.PortKey("shop", "web"): 4100},
+	}
+
+	got := scanAll(cfg)
+	want := []scanWorktree{{
+		Name:   "shop",
+		Path:   repo,
+		Branch: "main",
+		Projects: []scanProject{
+			{Name: "admin", Path: filepath.Join(repo, "admin"), PkgName: "admin", Framework: "next", PackageManager: "npm", Scripts: []string{"dev"}, Filtered: true},
+			{Name: "web", Path: filepath.Join(repo, "web"), PkgName: "web", Framework: "vite", PackageManager: "npm", Port: 4100, Scripts: []string{"dev", "build"}},
+		},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("scanAll =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if got := scanAll(&config.LocalConfig{ScanDirs: []string{t.TempDir()}}); got == nil || len(got) != 0 {
+		t.Errorf("scanAll of an empty dir = %#v, want an empty list (not null in JSON)", got)
+	}
+}
+
+func TestRunScan(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(config.ConfigDirEnv, dir)
+	var stdout, stderr bytes.Buffer
+
+	// No config yet: nothing to scan
+	if code := runScan(nil, &stdout, &stderr); code != 1 || !strings.Contains(stderr.String(), "No scan directories") {
+		t.Errorf("runScan without scan dirs = %d, stderr %q", code, stderr.String())
+	}
+
+	scan, repo := scanFixture(t)
+	writeFile(t, filepath.Join(dir, "config.json"),
+		fmt.Sprintf(`{"version":%d,"scan_dirs":[%q],"ignore_patterns":["admin"]}`, config.CurrentConfigVersion, scan))
+
+	stdout.Reset()
+	if code := runScan(nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("runScan = %d, stderr %q", code, stderr.String())
+	}
+	lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+	if len(lines) != 3 || lines[0] != "shop (main) "+repo {
+		t.Fatalf("text output:\n%s", stdout.String())
+	}
+	if !strings.HasPrefix(strings.TrimSpace(lines[1]), "admin") || !strings.HasSuffix(lines[1], "[filtered]") {
+		t.Errorf("admin line %q, want it marked filtered", lines[1])
+	}
+	if !strings.HasPrefix(strings.TrimSpace(lines[2]), "web") || strings.Contains(lines[2], "[filtered]") {
+		t.Errorf("web line %q", lines[2])
+	}
+
+	stdout.Reset()
+	if code := runScan([]string{"--json"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("runScan --json = %d, stderr %q", code, stderr.String())
+	}
+	var results []scanWorktree
+	if err := json.Unmarshal(stdout.Bytes(), &results); err != nil {
+		t.Fatalf("--json output doesn't parse: %v\n%s", err, stdout.String())
+	}
+	if len(results) != 1 || len(results[0].Projects) != 2 || !results[0].Projects[0].Filtered || results[0].Projects[1].Name != "web" {
+		t.Errorf("--json results = %+v", results)
+	}
+
+	if code := runScan([]string{"--bogus"}, &stdout, &stderr); code != 2 {
+		t.Errorf("runScan --bogus = %d, want 2", code)
+	}
+}
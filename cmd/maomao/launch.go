@@ -32,6 +32,9 @@ func launchWorkspace(configDir string, initialTaskID string) error {
 	os.MkdirAll(sessionsDir, 0o755)
 	os.MkdirAll(logsDir, 0o755)
 	pm := process.NewProcessManager(sessionsDir, logsDir)
+	if cfg, err := maomaoconfig.LoadGlobalConfig(configDir); err == nil && cfg != nil {
+		pm.CleanLogs = cfg.CleanLogs
+	}
 
 	// Wire agent lifecycle events via OnExit callback
 	pm.OnExit = func(key string, exitCode int) {
@@ -124,6 +127,7 @@ func launchWorkspace(configDir string, initialTaskID string) error {
 				Command: "sh",
 				Args:    []string{"-c", agentCmd},
 				WorkDir: workDir,
+				NoPTY:   agentConf.NoPTY,
 			}
 
 			rp, err := pm.Start(info)
@@ -356,6 +360,7 @@ func launchWorkspace(configDir string, initialTaskID string) error {
 		processKey := taskID + ":" + repoName
 		info := process.SessionInfo{
 			Name: processKey, Command: "sh", Args: []string{"-c", agentCmd}, WorkDir: wtDir,
+			NoPTY: agentConf.NoPTY,
 		}
 		rp, err := pm.Start(info)
 		if err != nil {
@@ -1,16 +1,22 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
 
 // DevCommand returns the command, args, and extra env to run a project's dev server.
-// For Encore projects (encore.app detected), uses `encore run --port`.
-// For workspace packages (pkgName non-empty), uses `{pm} --filter <name> run {script}`.
+// For Encore projects (encore.app detected), uses `encore run --port` plus encoreArgs.
+// For workspace packages (pkgName non-empty), uses the package manager's workspace
+// syntax (see workspaceRunArgs).
 // For standalone projects, uses `{pm} run {script}` with PORT env variable.
-func DevCommand(isEncore bool, port int, pmBinary string, pkgName string, script string) (cmd string, args []string, env []string) {
+func DevCommand(isEncore bool, port int, pmBinary string, pkgName string, script string, encoreArgs []string) (cmd string, args []string, env []string) {
 	portStr := fmt.Sprintf("%d", port)
 
 	if isEncore && script == "" {
-		return "encore", []string{"run", "--port", portStr}, nil
+		return "encore", append([]string{"run", "--port", portStr}, stripPortFlag(encoreArgs)...), nil
 	}
 
 	if script == "" {
@@ -18,12 +24,123 @@ func DevCommand(isEncore bool, port int, pmBinary string, pkgName string, script
 	}
 
 	if pkgName != "" {
-		return pmBinary, []string{"--filter", pkgName, "run", script}, []string{fmt.Sprintf("PORT=%s", portStr)}
+		return pmBinary, workspaceRunArgs(pmBinary, pkgName, script), []string{fmt.Sprintf("PORT=%s", portStr)}
 	}
 
 	return pmBinary, []string{"run", script}, []string{fmt.Sprintf("PORT=%s", portStr)}
 }
 
+// Supported Node version managers for LocalConfig.VersionManager
+const (
+	VersionManagerFnm   = "fnm"
+	VersionManagerNvm   = "nvm"
+	VersionManagerVolta = "volta"
+	VersionManagerAsdf  = "asdf"
+)
+
+// nvmExecScript loads nvm (a shell function, not a binary) and runs the
+// command under the requested version; $0 is the version, $@ the command
+const nvmExecScript = `. "${NVM_DIR:-$HOME/.nvm}/nvm.sh" && nvm exec "$0" "$@"`
+
+// WithVersionManager wraps cmd so it runs under the given Node version:
+//   - fnm:   `fnm exec --using=<version> -- cmd args...`
+//   - nvm:   `bash -c '<load nvm> && nvm exec <version> cmd args...'`
+//   - volta: `volta run --node <version> cmd args...`
+//   - asdf:  `asdf exec <cmd name> args...`, cmd being an asdf shim; asdf
+//     reads the version from .tool-versions itself
+//
+// Returns cmd and args unchanged if manager or version is empty or the
+// manager is unknown.
+func WithVersionManager(manager, version, cmd string, args []string) (string, []string) {
+	if version == "" {
+		return cmd, args
+	}
+	switch manager {
+	case VersionManagerFnm:
+		return "fnm", append([]string{"exec", "--using=" + version, "--", cmd}, args...)
+	case VersionManagerNvm:
+		return "bash", append([]string{"-c", nvmExecScript, version, cmd}, args...)
+	case VersionManagerVolta:
+		return "volta", append([]string{"run", "--node", version, cmd}, args...)
+	case VersionManagerAsdf:
+		return "asdf", append([]string{"exec", filepath.Base(cmd)}, args...)
+	}
+	return cmd, args
+}
+
+// lockFiles are the lockfiles each package manager installs from frozen
+var lockFiles = map[string][]string{
+	"pnpm": {"pnpm-lock.yaml"},
+	"npm":  {"package-lock.json", "npm-shrinkwrap.json"},
+	"yarn": {"yarn.lock"},
+	"bun":  {"bun.lockb", "bun.lock"},
+}
+
+// InstallArgs returns the args to install dependencies in dir. With frozen
+// set and the package manager's lockfile in dir, the install must match the
+// lockfile instead of updating it:
+//   - pnpm, yarn, bun: `install --frozen-lockfile`
+//   - npm:             `ci`
+//
+// Otherwise it is a plain `install`. pmBinary may be a bare name or a path.
+func InstallArgs(pmBinary, dir string, frozen bool) []string {
+	pm := filepath.Base(pmBinary)
+	if !frozen || !hasLockFile(dir, pm) {
+		return []string{"install"}
+	}
+	if pm == "npm" {
+		return []string{"ci"}
+	}
+	return []string{"install", "--frozen-lockfile"}
+}
+
+// hasLockFile reports whether dir has one of pm's lockfiles
+func hasLockFile(dir, pm string) bool {
+	for _, name := range lockFiles[pm] {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// workspaceRunArgs returns the args to run script in workspace package pkgName.
+// pmBinary may be a bare name or a resolved path; the manager is identified by base name:
+//   - pnpm: `pnpm --filter <name> run <script>`
+//   - yarn: `yarn workspace <name> run <script>`
+//   - npm:  `npm run -w <name> <script>`
+//   - bun:  `bun run --filter <name> <script>`
+func workspaceRunArgs(pmBinary, pkgName, script string) []string {
+	switch filepath.Base(pmBinary) {
+	case "yarn":
+		return []string{"workspace", pkgName, "run", script}
+	case "npm":
+		return []string{"run", "-w", pkgName, script}
+	case "bun":
+		return []string{"run", "--filter", pkgName, script}
+	default:
+		return []string{"--filter", pkgName, "run", script}
+	}
+}
+
+// stripPortFlag drops --port/--port=N (and the value following a bare --port)
+// from user-supplied args so they can't override the port devdash injects.
+func stripPortFlag(args []string) []string {
+	var out []string
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if a == "--port" {
+			i++ // skip value
+			continue
+		}
+		if strings.HasPrefix(a, "--port=") {
+			continue
+		}
+		out = append(out, a)
+	}
+	return out
+}
+
 // SessionName generates a session name from worktree and project names
 func SessionName(wtName, projectName string) string {
 	return "dev-" + sanitize(wtName) + "-" + sanitize(projectName)
@@ -0,0 +1,485 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDevCommand_EncoreExtraArgs(t *testing.T) {
+	cmd, args, env := DevCommand(true, 4000, "pnpm", "", "", []string{"--browser=never", "--port", "9999", "--debug", "--port=1"})
+	if cmd != "encore" {
+		t.Fatalf("expected encore, got %q", cmd)
+	}
+	want := []string{"run", "--port", "4000", "--browser=never", "--debug"}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("args = %v, want %v", args, want)
+	}
+	if env != nil {
+		t.Errorf("expected no env for encore, got %v", env)
+	}
+}
+
+func TestDevCommand_WorkspacePackage(t *testing.T) {
+	tests := []struct {
+		pm   string
+		want []string
+	}{
+		{"/usr/local/bin/pnpm", []string{"--filter", "@acme/web", "run", "dev"}},
+		{"pnpm", []string{"--filter", "@acme/web", "run", "dev"}},
+		{"/usr/local/bin/yarn", []string{"workspace", "@acme/web", "run", "dev"}},
+		{"/usr/local/bin/npm", []string{"run", "-w", "@acme/web", "dev"}},
+		{"/home/me/.bun/bin/bun", []string{"run", "--filter", "@acme/web", "dev"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pm, func(t *testing.T) {
+			cmd, args, env := DevCommand(false, 3000, tt.pm, "@acme/web", "dev", nil)
+			if cmd != tt.pm {
+				t.Errorf("cmd = %q, want %q", cmd, tt.pm)
+			}
+			if !reflect.DeepEqual(args, tt.want) {
+				t.Errorf("args = %v, want %v", args, tt.want)
+			}
+			if !reflect.DeepEqual(env, []string{"PORT=3000"}) {
+				t.Errorf("env = %v, want [PORT=3000]", env)
+			}
+		})
+	}
+}
+
+func TestDevCommand_StandaloneDefaultsToDev(t *testing.T) {
+	_, args, _ := DevCommand(false, 3000, "npm", "", "", nil)
+	if !reflect.DeepEqual(args, []string{"run", "dev"}) {
+		t.Errorf("args = %v, want [run dev]", args)
+	}
+}
+
+func TestConfigDir_Resolution(t *testing.T) {
+	t.Setenv(ConfigDirEnv, "/tmp/devdash-test")
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg")
+	if got := ConfigDir(); got != "/tmp/devdash-test" {
+		t.Errorf("ConfigDir() = %q, want env override", got)
+	}
+	if got := LogsDir(); got != "/tmp/devdash-test/logs" {
+		t.Errorf("LogsDir() = %q, want under env override", got)
+	}
+
+	t.Setenv(ConfigDirEnv, "")
+	if got := ConfigDir(); got != "/tmp/xdg/local-dev" {
+		t.Errorf("ConfigDir() = %q, want XDG path", got)
+	}
+}
+
+func TestLoadConfig_CorruptFileIsBackedUp(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(ConfigDirEnv, dir)
+
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"scan_dirs": [`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := LoadConfig()
+	if len(cfg.ScanDirs) != 0 {
+		t.Errorf("expected empty config, got %v", cfg.ScanDirs)
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(dir, "config.json.corrupt-*"))
+	if len(matches) != 1 {
+		t.Fatalf("expected 1 backup file, got %v", matches)
+	}
+}
+
+func TestSaveConfig_RoundTrip(t *testing.T) {
+	t.Setenv(ConfigDirEnv, t.TempDir())
+
+	cfg := LoadConfig()
+	cfg.ScanDirs = []string{"/projects"}
+	cfg.SetPort("wt:web", 5173)
+	if err := SaveConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := LoadConfig()
+	if !reflect.DeepEqual(loaded.ScanDirs, cfg.ScanDirs) || loaded.GetPort("wt:web") != 5173 {
+		t.Errorf("round trip mismatch: %+v", loaded)
+	}
+}
+
+func TestLoadConfig_MigratesUnversioned(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(ConfigDirEnv, dir)
+
+	path := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(path, []byte(`{"scan_dirs": ["/projects"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := LoadConfig()
+	if cfg.Version != CurrentConfigVersion {
+		t.Errorf("Version = %d, want %d", cfg.Version, CurrentConfigVersion)
+	}
+	if !reflect.DeepEqual(cfg.ScanDirs, []string{"/projects"}) {
+		t.Errorf("ScanDirs = %v", cfg.ScanDirs)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"version": 1`) {
+		t.Errorf("migrated config not rewritten: %s", data)
+	}
+}
+
+func TestLoadConfig_FutureVersionLoadsBestEffort(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(ConfigDirEnv, dir)
+
+	path := filepath.Join(dir, "config.json")
+	original := `{"version": 99, "scan_dirs": ["/projects"], "theme": "dark"}`
+	if err := os.WriteFile(path, []byte(original), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := LoadConfig()
+	if cfg.Version != 99 || !reflect.DeepEqual(cfg.ScanDirs, []string{"/projects"}) {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+
+	data, _ := os.ReadFile(path)
+	if string(data) != original {
+		t.Errorf("future-version config was rewritten: %s", data)
+	}
+}
+
+func TestSaveConfig_KeepsUnknownFields(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(ConfigDirEnv, dir)
+
+	path := filepath.Join(dir, "config.json")
+	original := `{"version": 99, "scan_dirs": ["/projects"], "theme": "dark", "panels": {"left": 0.4}}`
+	if err := os.WriteFile(path, []byte(original), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := LoadConfig()
+	cfg.AddScanDir("/work")
+	if err := SaveConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	var saved map[string]any
+	data, _ := os.ReadFile(path)
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"version":   float64(99),
+		"scan_dirs": []any{"/projects", "/work"},
+		"theme":     "dark",
+		"panels":    map[string]any{"left": 0.4},
+	}
+	if !reflect.DeepEqual(saved, want) {
+		t.Errorf("saved %v, want %v", saved, want)
+	}
+
+	// A cleared known field stays cleared rather than coming back as unknown
+	cfg = LoadConfig()
+	cfg.ScanDirs = nil
+	if err := SaveConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if again := LoadConfig(); len(again.ScanDirs) != 0 || again.unknown["theme"] == nil {
+		t.Errorf("second round trip: scan_dirs %v, unknown %v", again.ScanDirs, again.unknown)
+	}
+}
+
+func TestIdleAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", DefaultIdleTimeout},
+		{"0", 0},
+		{"90s", 90 * time.Second},
+		{"10m", 10 * time.Minute},
+		{"soon", DefaultIdleTimeout},
+		{"-1m", DefaultIdleTimeout},
+	}
+	for _, tt := range tests {
+		cfg := &LocalConfig{IdleTimeout: tt.value}
+		if got := cfg.IdleAfter(); got != tt.want {
+			t.Errorf("IdleAfter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestAutoRemoveAfter(t *testing.T) {
+	for value, want := range map[string]time.Duration{"": 0, "0": 0, "10m": 10 * time.Minute, "later": 0} {
+		cfg := &LocalConfig{AutoRemove: value}
+		if got := cfg.AutoRemoveAfter(); got != want {
+			t.Errorf("AutoRemoveAfter(%q) = %v, want %v", value, got, want)
+		}
+	}
+	if got := (*LocalConfig)(nil).AutoRemoveAfter(); got != 0 {
+		t.Errorf("nil config: %v", got)
+	}
+}
+
+func TestStopTimeoutFor(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &LocalConfig{}
+	if got := cfg.StopTimeoutFor(dir); got != 0 {
+		t.Errorf("unset: got %v, want 0", got)
+	}
+
+	cfg.StopTimeout = "2s"
+	if got := cfg.StopTimeoutFor(dir); got != 2*time.Second {
+		t.Errorf("global: got %v, want 2s", got)
+	}
+
+	os.WriteFile(filepath.Join(dir, ProjectConfigFile), []byte(`{"stop_timeout":"15s"}`), 0644)
+	if got := cfg.StopTimeoutFor(dir); got != 15*time.Second {
+		t.Errorf("project override: got %v, want 15s", got)
+	}
+}
+
+func TestOpenFilesFor(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &LocalConfig{}
+	if got := cfg.OpenFilesFor(dir); got != 0 {
+		t.Errorf("unset: got %d, want 0", got)
+	}
+
+	cfg.OpenFiles = 4096
+	if got := cfg.OpenFilesFor(dir); got != 4096 {
+		t.Errorf("global: got %d, want 4096", got)
+	}
+
+	os.WriteFile(filepath.Join(dir, ProjectConfigFile), []byte(`{"open_files":65536}`), 0644)
+	if got := cfg.OpenFilesFor(dir); got != 65536 {
+		t.Errorf("project override: got %d, want 65536", got)
+	}
+}
+
+func TestCleanEnvFor(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &LocalConfig{}
+	if cfg.CleanEnvFor(dir) {
+		t.Error("unset: got clean, want inherited")
+	}
+
+	os.WriteFile(filepath.Join(dir, ProjectConfigFile), []byte(`{"clean_env":true}`), 0644)
+	if !cfg.CleanEnvFor(dir) {
+		t.Error("project on: got inherited, want clean")
+	}
+
+	cfg.CleanEnv = true
+	os.WriteFile(filepath.Join(dir, ProjectConfigFile), []byte(`{"clean_env":false}`), 0644)
+	if cfg.CleanEnvFor(dir) {
+		t.Error("project opt-out: got clean, want inherited")
+	}
+	os.WriteFile(filepath.Join(dir, ProjectConfigFile), []byte(`{}`), 0644)
+	if !cfg.CleanEnvFor(dir) {
+		t.Error("global on: got inherited, want clean")
+	}
+}
+
+func TestWithVersionManager(t *testing.T) {
+	args := []string{"run", "dev"}
+	tests := []struct {
+		manager, version string
+		wantCmd          string
+		wantArgs         []string
+	}{
+		{"", "20", "pnpm", args},
+		{"fnm", "", "pnpm", args},
+		{"fnm", "20", "fnm", []string{"exec", "--using=20", "--", "pnpm", "run", "dev"}},
+		{"volta", "20", "volta", []string{"run", "--node", "20", "pnpm", "run", "dev"}},
+		{"nvm", "20", "bash", []string{"-c", nvmExecScript, "20", "pnpm", "run", "dev"}},
+		{"asdf", "20", "asdf", []string{"exec", "pnpm", "run", "dev"}},
+		{"asdf", "", "pnpm", args},
+		{"rtx", "20", "pnpm", args},
+	}
+	for _, tt := range tests {
+		cmd, got := WithVersionManager(tt.manager, tt.version, "pnpm", args)
+		if cmd != tt.wantCmd || !reflect.DeepEqual(got, tt.wantArgs) {
+			t.Errorf("WithVersionManager(%q, %q) = %s %v, want %s %v", tt.manager, tt.version, cmd, got, tt.wantCmd, tt.wantArgs)
+		}
+	}
+}
+
+func TestSaveProjectLaunch(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ProjectConfigFile)
+	os.WriteFile(path, []byte(`{"stop_signal":"SIGINT","env":["OLD=1"],"custom_key":{"a":1}}`), 0644)
+
+	if err := SaveProjectLaunch(dir, 4100, []string{"DEBUG=1"}); err != nil {
+		t.Fatal(err)
+	}
+	pc := LoadProjectConfig(dir)
+	if pc.Port != 4100 || !reflect.DeepEqual(pc.Env, []string{"DEBUG=1"}) || pc.StopSignal != "SIGINT" {
+		t.Errorf("saved %+v", pc)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), `"custom_key"`) {
+		t.Errorf("unknown key dropped:\n%s", data)
+	}
+
+	// Empty env removes the key
+	if err := SaveProjectLaunch(dir, 4100, nil); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); strings.Contains(string(data), `"env"`) {
+		t.Errorf("env kept:\n%s", data)
+	}
+
+	// A file that isn't JSON is left alone
+	os.WriteFile(path, []byte("{broken"), 0644)
+	if err := SaveProjectLaunch(dir, 1, nil); err == nil {
+		t.Error("want an error for an unparsable file")
+	}
+}
+
+func TestProjectLaunchCommands(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, ProjectConfigFile), []byte(`{"commands":[
+		{"name":"make dev","command":"make","args":["dev"],"cwd":"server"},
+		{"name":"broken"},
+		{"name":"make dev","command":"ignored"},
+		{"name":"api","command":"./bin/api","env":["LOG=debug"]}
+	]}`), 0644)
+
+	cmds := LoadProjectConfig(dir).LaunchCommands()
+	if len(cmds) != 2 || cmds[0].Name != "make dev" || cmds[1].Name != "api" {
+		t.Fatalf("LaunchCommands = %+v", cmds)
+	}
+	if got := cmds[0].Dir(dir); got != filepath.Join(dir, "server") {
+		t.Errorf("relative cwd: got %q", got)
+	}
+	if got := cmds[1].Dir(dir); got != dir {
+		t.Errorf("default cwd: got %q, want the project dir", got)
+	}
+	if c, ok := LoadProjectConfig(dir).LaunchCommand("api"); !ok || c.Command != "./bin/api" {
+		t.Errorf("LaunchCommand(api) = %+v, %v", c, ok)
+	}
+}
+
+func TestCustomCommandExpand(t *testing.T) {
+	c := CustomCommand{
+		Name:    "api",
+		Command: "${HOME}/bin/api",
+		Args:    []string{"--port", "${PORT}", "--db", "${DATABASE_URL}", "$${literal}"},
+		Env:     []string{"DB_PORT=5433", "DATABASE_URL=postgres://localhost:${DB_PORT}/app"},
+	}
+	got, err := c.Expand([]string{"HOME=/home/me", "PORT=4000"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Command != "/home/me/bin/api" {
+		t.Errorf("command = %q", got.Command)
+	}
+	wantArgs := []string{"--port", "4000", "--db", "postgres://localhost:5433/app", "${literal}"}
+	if !reflect.DeepEqual(got.Args, wantArgs) {
+		t.Errorf("args = %q, want %q", got.Args, wantArgs)
+	}
+	if got.Env[1] != "DATABASE_URL=postgres://localhost:5433/app" {
+		t.Errorf("env = %q", got.Env)
+	}
+
+	// Shell parameters and bare $VAR are the shell's, even under strict_env
+	script := "echo $1 $HOME && test $? -eq 0 && echo $$ $@"
+	c = CustomCommand{Name: "sh", Command: "sh", Args: []string{"-c", script}, strict: true}
+	if got, err := c.Expand([]string{"HOME=/home/me"}); err != nil || got.Args[1] != script {
+		t.Errorf("shell parameters: got %q, %v; want the script untouched", got.Args, err)
+	}
+
+	// Undefined: empty by default, an error with strict_env
+	c = CustomCommand{Name: "x", Command: "run", Args: []string{"${NOPE}"}}
+	if got, err := c.Expand(nil); err != nil || got.Args[0] != "" {
+		t.Errorf("lenient: got %q, %v", got.Args, err)
+	}
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, ProjectConfigFile), []byte(`{"strict_env":true,"commands":[{"name":"x","command":"run","args":["${NOPE}"]}]}`), 0644)
+	strict, _ := LoadProjectConfig(dir).LaunchCommand("x")
+	if _, err := strict.Expand(nil); err == nil || !strings.Contains(err.Error(), "NOPE") {
+		t.Errorf("strict: expected an error naming NOPE, got %v", err)
+	}
+}
+
+func TestProjectConfigExpandEnv(t *testing.T) {
+	pc := &ProjectConfig{Env: []string{"DB_PORT=5433", "DATABASE_URL=postgres://localhost:${DB_PORT}/app", "API=http://localhost:${PORT}"}}
+	got, err := pc.ExpandEnv([]string{"PORT=4000"})
+	want := []string{"DB_PORT=5433", "DATABASE_URL=postgres://localhost:5433/app", "API=http://localhost:4000"}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("ExpandEnv = %q, %v; want %q", got, err, want)
+	}
+
+	pc = &ProjectConfig{Env: []string{"URL=${NOPE}"}, StrictEnv: true}
+	if _, err := pc.ExpandEnv(nil); err == nil || !strings.Contains(err.Error(), "NOPE") {
+		t.Errorf("strict: expected an error naming NOPE, got %v", err)
+	}
+}
+
+func TestSessionTags(t *testing.T) {
+	if got := ParseTags(" Frontend, api  #infra,api,, "); !reflect.DeepEqual(got, []string{"frontend", "api", "infra"}) {
+		t.Errorf("ParseTags = %q", got)
+	}
+
+	cfg := &LocalConfig{}
+	cfg.SetTags("web", []string{"frontend"})
+	cfg.SetTags("api", []string{"backend", "frontend"})
+	if got := cfg.AllTags(); !reflect.DeepEqual(got, []string{"backend", "frontend"}) {
+		t.Errorf("AllTags = %q", got)
+	}
+	cfg.SetTags("web", nil)
+	if _, ok := cfg.SessionTags["web"]; ok || cfg.Tags("web") != nil {
+		t.Error("empty tags should remove the entry")
+	}
+}
+
+func TestCheckLogPatterns(t *testing.T) {
+	if err := (&ProjectConfig{BuildStart: `rebuilding`, BuildDone: `done in \d+ms`}).CheckLogPatterns(); err != nil {
+		t.Errorf("valid patterns rejected: %v", err)
+	}
+	if err := (&ProjectConfig{}).CheckLogPatterns(); err != nil {
+		t.Errorf("empty patterns should use the defaults: %v", err)
+	}
+	err := (&ProjectConfig{BuildDone: `ready (`}).CheckLogPatterns()
+	if err == nil || !strings.Contains(err.Error(), "build_done") {
+		t.Errorf("invalid build_done: got %v", err)
+	}
+	err = (&ProjectConfig{ReadyPattern: `listening [`}).CheckLogPatterns()
+	if err == nil || !strings.Contains(err.Error(), "ready_pattern") {
+		t.Errorf("invalid ready_pattern: got %v", err)
+	}
+}
+
+func TestInstallArgs(t *testing.T) {
+	dir := t.TempDir()
+	if got := InstallArgs("pnpm", dir, true); !reflect.DeepEqual(got, []string{"install"}) {
+		t.Errorf("no lockfile: got %v, want a plain install", got)
+	}
+
+	os.WriteFile(filepath.Join(dir, "pnpm-lock.yaml"), nil, 0644)
+	os.WriteFile(filepath.Join(dir, "package-lock.json"), nil, 0644)
+	tests := []struct {
+		pm     string
+		frozen bool
+		want   []string
+	}{
+		{"pnpm", false, []string{"install"}},
+		{"/usr/local/bin/pnpm", true, []string{"install", "--frozen-lockfile"}},
+		{"npm", true, []string{"ci"}},
+		{"yarn", true, []string{"install"}}, // no yarn.lock
+	}
+	for _, tt := range tests {
+		if got := InstallArgs(tt.pm, dir, tt.frozen); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("InstallArgs(%q, frozen=%v) = %v, want %v", tt.pm, tt.frozen, got, tt.want)
+		}
+	}
+}
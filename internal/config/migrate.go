@@ -0,0 +1,111 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// CurrentConfigVersion is the config.json schema version written by this build
+const CurrentConfigVersion = 1
+
+// migration upgrades a raw config document from version N to N+1.
+// Operating on the raw map lets migrations rename or reshape fields
+// that no longer exist on LocalConfig.
+type migration func(raw map[string]any) error
+
+// migrations[i] upgrades version i to version i+1
+var migrations = []migration{
+	// 0 -> 1: introduce the version field; no shape changes
+	func(raw map[string]any) error { return nil },
+}
+
+// migrateConfig upgrades raw config data to CurrentConfigVersion. It returns the
+// (possibly rewritten) data and whether a migration was applied. Configs from a
+// newer version are returned unchanged so they load best-effort; the fields
+// this build doesn't know survive saves, see unknownFields.
+func migrateConfig(data []byte) ([]byte, bool, error) {
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, false, err
+	}
+	if raw == nil {
+		raw = make(map[string]any)
+	}
+
+	version := 0
+	if v, ok := raw["version"].(float64); ok {
+		version = int(v)
+	}
+	if version >= CurrentConfigVersion {
+		if version > CurrentConfigVersion {
+			_, _ = fmt.Fprintf(os.Stderr, "warning: config version %d is newer than supported (%d); unknown fields are kept but not used\n", version, CurrentConfigVersion)
+		}
+		return data, false, nil
+	}
+
+	for v := version; v < CurrentConfigVersion; v++ {
+		if err := migrations[v](raw); err != nil {
+			return nil, false, fmt.Errorf("migrate config v%d -> v%d: %w", v, v+1, err)
+		}
+	}
+	raw["version"] = CurrentConfigVersion
+
+	out, err := json.Marshal(raw)
+	if err != nil {
+		return nil, false, err
+	}
+	return out, true, nil
+}
+
+// unknownFields returns the top-level fields of a config document that
+// LocalConfig has no field for
+func unknownFields(data []byte) (map[string]json.RawMessage, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	known := knownConfigKeys()
+	for key := range raw {
+		if known[key] {
+			delete(raw, key)
+		}
+	}
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	return raw, nil
+}
+
+// knownConfigKeys returns the JSON names of LocalConfig's fields
+func knownConfigKeys() map[string]bool {
+	t := reflect.TypeOf(LocalConfig{})
+	keys := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			keys[name] = true
+		}
+	}
+	return keys
+}
+
+// marshalConfig encodes cfg for config.json, with the unknown fields it was
+// loaded with merged back in
+func marshalConfig(cfg *LocalConfig) ([]byte, error) {
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil || len(cfg.unknown) == 0 {
+		return data, err
+	}
+
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	for key, value := range cfg.unknown {
+		doc[key] = value
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
@@ -2,18 +2,93 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/google/renameio/v2"
 )
 
 // LocalConfig holds persistent user configuration
 type LocalConfig struct {
-	ScanDirs      []string       `json:"scan_dirs"`
-	PortOverrides map[string]int `json:"port_overrides,omitempty"`
+	Version          int            `json:"version"` // schema version, see CurrentConfigVersion
+	ScanDirs         []string       `json:"scan_dirs"`
+	PortOverrides    map[string]int `json:"port_overrides,omitempty"`
+	IgnorePatterns   []string       `json:"ignore_patterns,omitempty"`       // glob patterns for projects to hide
+	IncludePatterns  []string       `json:"include_patterns,omitempty"`      // if set, only matching projects are shown
+	PriorityScripts  []string       `json:"priority_scripts,omitempty"`      // dev scripts listed first (default dev, start, serve, watch)
+	Orchestrators    []string       `json:"orchestrator_commands,omitempty"` // monorepo orchestrators whose roots are skipped (default turbo, lerna, nx)
+	EncoreArgs       []string       `json:"encore_args,omitempty"`           // default extra args for `encore run`
+	ListRatio        float64        `json:"list_ratio,omitempty"`            // session list share of the dashboard width (0 = 1/3)
+	DenseList        bool           `json:"dense_list,omitempty"`            // one row per session in the dashboard list
+	WrapSessionNames bool           `json:"wrap_session_names,omitempty"`    // wrap long session names instead of eliding the middle
+	WrapIndent       bool           `json:"wrap_indent,omitempty"`           // indent the continuation rows of wrapped log lines
+	IdleTimeout      string         `json:"idle_timeout,omitempty"`          // dim sessions silent for this long, e.g. "5m" ("0" disables)
+	TimeFormat       string         `json:"time_format,omitempty"`           // session times: "short" (3h12m, default), "long" (3 hours ago) or "clock" (14:02:33)
+	StopTimeout      string         `json:"stop_timeout,omitempty"`          // SIGTERM→SIGKILL window, e.g. "15s" (default 5s)
+	AutoRemove       string         `json:"auto_remove,omitempty"`           // drop sessions that exited cleanly this long ago, e.g. "10m" ("" or "0" = never)
+	ReconnectPreload int            `json:"reconnect_preload_kb,omitempty"`  // KiB from the end of each log loaded on reconnect (0 = 1024)
+	OpenFiles        int            `json:"open_files,omitempty"`            // raise launched processes' soft open-file limit to this (0 = inherit)
+	StopSignal       string         `json:"stop_signal,omitempty"`           // graceful stop signal: "SIGTERM" (default) or "SIGINT"
+	VersionManager   string         `json:"version_manager,omitempty"`       // "fnm", "nvm", "volta" or "asdf": run Node projects under their pinned version
+	FrozenInstall    bool           `json:"frozen_install,omitempty"`        // install dependencies from the lockfile without updating it (npm ci, --frozen-lockfile)
+	ReadyBell        bool           `json:"ready_bell,omitempty"`            // ring the terminal bell when a session's server comes up
+	TunnelAutoCopy   bool           `json:"tunnel_auto_copy,omitempty"`      // copy a tunnel's URL to the clipboard as soon as it is up
+	StickySearch     bool           `json:"sticky_search,omitempty"`         // keep the dashboard search query when switching sessions
+	NoWatch          bool           `json:"no_watch,omitempty"`              // don't watch scan dirs for new or removed worktrees (e.g. on network filesystems)
+	CleanEnv         bool           `json:"clean_env,omitempty"`             // launch with a minimal environment instead of devdash's own
+	MetricsAddr      string         `json:"metrics_addr,omitempty"`          // serve Prometheus /metrics here, e.g. "9273" (localhost) or "0.0.0.0:9273"
+
+	Profiles    map[string][]ProfileEntry `json:"profiles,omitempty"`     // named sets of services for `devdash up <profile>`
+	Webhook     *WebhookConfig            `json:"webhook,omitempty"`      // POST process lifecycle events to a URL
+	SessionTags map[string][]string       `json:"session_tags,omitempty"` // free-form tags per session name, e.g. "frontend"
+
+	// LastProjects maps a worktree name to the project last launched from
+	// it, preselected in the launcher
+	LastProjects map[string]string `json:"last_projects,omitempty"`
+
+	// unknown holds the top-level fields this build has no field for, e.g.
+	// from a newer version, so SaveConfig writes them back unchanged
+	unknown map[string]json.RawMessage
+}
+
+// WebhookConfig selects where lifecycle events are POSTed and which ones
+type WebhookConfig struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events,omitempty"` // "start", "stop", "error", "tunnel"; empty = all
+}
+
+// ProfileEntry is one service in a startup profile. Script and Port are
+// optional; they default to what the launcher would pick.
+type ProfileEntry struct {
+	Worktree string `json:"worktree"`         // worktree display name, e.g. "platform"
+	Project  string `json:"project"`          // project name within the worktree
+	Script   string `json:"script,omitempty"` // package.json script (default: first of dev/start/...)
+	Port     int    `json:"port,omitempty"`   // port (default: saved override, then detected)
 }
 
-// configDir returns the config directory path: ~/.config/local-dev/
+// DefaultIdleTimeout is used when IdleTimeout is unset or invalid
+const DefaultIdleTimeout = 5 * time.Minute
+
+// ConfigDirEnv overrides the config directory location when set
+const ConfigDirEnv = "DEVDASH_CONFIG_DIR"
+
+// configDir returns the config directory path. Resolution order:
+//  1. $DEVDASH_CONFIG_DIR
+//  2. $XDG_CONFIG_HOME/local-dev
+//  3. ~/.config/local-dev
 func configDir() string {
+	if dir := os.Getenv(ConfigDirEnv); dir != "" {
+		return dir
+	}
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "local-dev")
+	}
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return ".local-dev"
@@ -26,12 +101,12 @@ func ConfigDir() string {
 	return configDir()
 }
 
-// SessionsDir returns the sessions directory path: ~/.config/local-dev/sessions/
+// SessionsDir returns the sessions directory path: <config dir>/sessions/
 func SessionsDir() string {
 	return filepath.Join(configDir(), "sessions")
 }
 
-// LogsDir returns the logs directory path: ~/.config/local-dev/logs/
+// LogsDir returns the logs directory path: <config dir>/logs/
 func LogsDir() string {
 	return filepath.Join(configDir(), "logs")
 }
@@ -42,8 +117,10 @@ func configPath() string {
 }
 
 // LoadConfig loads configuration from disk. Returns empty config if file doesn't exist.
+// Older config versions are migrated and written back.
 func LoadConfig() *LocalConfig {
 	cfg := &LocalConfig{
+		Version:       CurrentConfigVersion,
 		PortOverrides: make(map[string]int),
 	}
 
@@ -52,30 +129,54 @@ func LoadConfig() *LocalConfig {
 		return cfg
 	}
 
-	if err := json.Unmarshal(data, cfg); err != nil {
-		return cfg
+	data, migrated, err := migrateConfig(data)
+	if err == nil {
+		err = json.Unmarshal(data, cfg)
+	}
+	if err == nil {
+		cfg.unknown, err = unknownFields(data)
+	}
+	if err != nil {
+		// Keep the unreadable file so settings can be recovered by hand;
+		// otherwise the next SaveConfig would silently replace it.
+		backup := fmt.Sprintf("%s.corrupt-%d", configPath(), time.Now().Unix())
+		if renameErr := os.Rename(configPath(), backup); renameErr == nil {
+			_, _ = fmt.Fprintf(os.Stderr, "warning: %s is corrupt (%v), moved to %s\n", configPath(), err, backup)
+		}
+		return &LocalConfig{Version: CurrentConfigVersion, PortOverrides: make(map[string]int)}
 	}
 
 	if cfg.PortOverrides == nil {
 		cfg.PortOverrides = make(map[string]int)
 	}
 
+	if migrated {
+		_ = SaveConfig(cfg)
+	}
+
 	return cfg
 }
 
-// SaveConfig persists the config to disk
+// saveMu serializes config writes from concurrent tea.Cmd goroutines
+var saveMu sync.Mutex
+
+// SaveConfig persists the config to disk atomically (temp file + rename),
+// so a crash mid-write never leaves a truncated config.json behind.
 func SaveConfig(cfg *LocalConfig) error {
+	saveMu.Lock()
+	defer saveMu.Unlock()
+
 	dir := configDir()
 	if err := os.MkdirAll(dir, 0o755); err != nil {
 		return err
 	}
 
-	data, err := json.MarshalIndent(cfg, "", "  ")
+	data, err := marshalConfig(cfg)
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(configPath(), data, 0o644)
+	return renameio.WriteFile(configPath(), data, 0o644)
 }
 
 // AddScanDir adds a directory to the scan list if not already present. Returns true if added.
@@ -105,6 +206,106 @@ func (c *LocalConfig) RemoveScanDir(dir string) bool {
 	return false
 }
 
+// EncoreArgsFor returns the extra `encore run` args for a project directory:
+// the project's .devdash.json value if set, otherwise the global default.
+func (c *LocalConfig) EncoreArgsFor(dir string) []string {
+	if pc := LoadProjectConfig(dir); len(pc.EncoreArgs) > 0 {
+		return pc.EncoreArgs
+	}
+	if c == nil {
+		return nil
+	}
+	return c.EncoreArgs
+}
+
+// IdleAfter returns how long a session may go without log output before it is
+// shown as idle. Zero means idle marking is disabled.
+func (c *LocalConfig) IdleAfter() time.Duration {
+	if c == nil {
+		return DefaultIdleTimeout
+	}
+	return parseDuration(c.IdleTimeout, DefaultIdleTimeout)
+}
+
+// AutoRemoveAfter returns how long a cleanly exited session stays listed
+// before it is removed. Zero means never.
+func (c *LocalConfig) AutoRemoveAfter() time.Duration {
+	if c == nil {
+		return 0
+	}
+	return parseDuration(c.AutoRemove, 0)
+}
+
+// StopTimeoutFor returns the graceful-shutdown window for a project directory:
+// the project's .devdash.json value if set, otherwise the global one.
+// Returns 0 when neither is set, meaning the process manager's default.
+func (c *LocalConfig) StopTimeoutFor(dir string) time.Duration {
+	if d := parseDuration(LoadProjectConfig(dir).StopTimeout, 0); d > 0 {
+		return d
+	}
+	if c == nil {
+		return 0
+	}
+	return parseDuration(c.StopTimeout, 0)
+}
+
+// StopSignalFor returns the graceful stop signal name for a project directory:
+// the project's .devdash.json value if set, otherwise the global one ("" = SIGTERM)
+func (c *LocalConfig) StopSignalFor(dir string) string {
+	if sig := LoadProjectConfig(dir).StopSignal; sig != "" {
+		return sig
+	}
+	if c == nil {
+		return ""
+	}
+	return c.StopSignal
+}
+
+// OpenFilesFor returns the soft open-file limit to launch a project
+// directory's processes with: the project's .devdash.json value if set,
+// otherwise the global one (0 = inherit devdash's)
+func (c *LocalConfig) OpenFilesFor(dir string) int {
+	if n := LoadProjectConfig(dir).OpenFiles; n > 0 {
+		return n
+	}
+	if c == nil {
+		return 0
+	}
+	return c.OpenFiles
+}
+
+// CleanEnvFor reports whether a project directory's processes launch with a
+// clean environment: as its .devdash.json says, else the global setting
+func (c *LocalConfig) CleanEnvFor(dir string) bool {
+	if clean := LoadProjectConfig(dir).CleanEnv; clean != nil {
+		return *clean
+	}
+	return c != nil && c.CleanEnv
+}
+
+// parseDuration parses a Go duration string, returning def if s is empty,
+// invalid, or negative
+func parseDuration(s string, def time.Duration) time.Duration {
+	if s == "" {
+		return def
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return def
+	}
+	return d
+}
+
+// ProfileNames returns the configured profile names, sorted
+func (c *LocalConfig) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // PortKey generates a port override key from worktree and project name
 func PortKey(wtName, projectName string) string {
 	return wtName + ":" + projectName
@@ -119,3 +320,70 @@ func (c *LocalConfig) GetPort(key string) int {
 func (c *LocalConfig) SetPort(key string, port int) {
 	c.PortOverrides[key] = port
 }
+
+// LastProject returns the project last launched from a worktree, "" if none
+func (c *LocalConfig) LastProject(worktree string) string {
+	if c == nil {
+		return ""
+	}
+	return c.LastProjects[worktree]
+}
+
+// SetLastProject remembers the project launched from a worktree
+func (c *LocalConfig) SetLastProject(worktree, project string) {
+	if c.LastProjects == nil {
+		c.LastProjects = make(map[string]string)
+	}
+	c.LastProjects[worktree] = project
+}
+
+// Tags returns the tags of a session, nil if it has none
+func (c *LocalConfig) Tags(session string) []string {
+	return c.SessionTags[session]
+}
+
+// SetTags replaces the tags of a session; an empty list removes its entry
+func (c *LocalConfig) SetTags(session string, tags []string) {
+	if len(tags) == 0 {
+		delete(c.SessionTags, session)
+		return
+	}
+	if c.SessionTags == nil {
+		c.SessionTags = make(map[string][]string)
+	}
+	c.SessionTags[session] = tags
+}
+
+// AllTags returns every tag assigned to any session, sorted
+func (c *LocalConfig) AllTags() []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, list := range c.SessionTags {
+		for _, tag := range list {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// ParseTags splits user input on commas and spaces into lowercase tags,
+// dropping empties and duplicates but keeping the order they were typed in
+func ParseTags(s string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	var tags []string
+	seen := make(map[string]bool)
+	for _, f := range fields {
+		f = strings.TrimPrefix(f, "#")
+		if f != "" && !seen[f] {
+			seen[f] = true
+			tags = append(tags, f)
+		}
+	}
+	return tags
+}
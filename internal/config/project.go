@@ -0,0 +1,213 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/renameio/v2"
+)
+
+// ProjectConfigFile is the per-project config file name, read from the project directory
+const ProjectConfigFile = ".devdash.json"
+
+// ProjectConfig holds per-project settings from a .devdash.json file.
+// Values here take precedence over the global LocalConfig defaults.
+type ProjectConfig struct {
+	EncoreArgs  []string `json:"encore_args,omitempty"`  // extra args for `encore run` (e.g. --browser=never)
+	StopTimeout string   `json:"stop_timeout,omitempty"` // SIGTERM→SIGKILL window, e.g. "15s"
+	StopSignal  string   `json:"stop_signal,omitempty"`  // "SIGTERM" or "SIGINT"
+	OpenFiles   int      `json:"open_files,omitempty"`   // soft open-file limit to raise to, e.g. 65536
+	Port        int      `json:"port,omitempty"`         // default dev port, below a hardcoded one
+	Env         []string `json:"env,omitempty"`          // extra KEY=VALUE pairs for script launches, after PORT
+	// TunnelScheme, TunnelHost and TunnelHostHeader are where tunnels reach
+	// the dev server; empty means http, localhost and host:port
+	TunnelScheme     string `json:"tunnel_scheme,omitempty"`
+	TunnelHost       string `json:"tunnel_host,omitempty"`
+	TunnelHostHeader string `json:"tunnel_host_header,omitempty"`
+	// Commands are launchable alongside (or instead of) package.json scripts
+	Commands []CustomCommand `json:"commands,omitempty"`
+	// StrictEnv makes an undefined ${VAR} in a command an error instead of ""
+	StrictEnv bool `json:"strict_env,omitempty"`
+	// CleanEnv launches with a minimal environment (PATH, HOME, locale, ...)
+	// plus env instead of everything devdash inherited from the shell; nil
+	// follows the global setting, false opts out of it
+	CleanEnv *bool `json:"clean_env,omitempty"`
+	// BuildStart and BuildDone are regexps for the log lines that begin and
+	// end a rebuild, to time it; empty uses devdash's framework defaults
+	BuildStart string `json:"build_start,omitempty"`
+	BuildDone  string `json:"build_done,omitempty"`
+	// ReadyPattern is a regexp for the log line that says the server is up,
+	// to flash its session; empty uses devdash's framework defaults
+	ReadyPattern string `json:"ready_pattern,omitempty"`
+}
+
+// CheckLogPatterns reports an invalid build_start, build_done or
+// ready_pattern regexp
+func (pc *ProjectConfig) CheckLogPatterns() error {
+	for _, p := range []struct{ key, expr string }{{"build_start", pc.BuildStart}, {"build_done", pc.BuildDone}, {"ready_pattern", pc.ReadyPattern}} {
+		if _, err := regexp.Compile(p.expr); err != nil {
+			return fmt.Errorf("%s in %s: %w", p.key, ProjectConfigFile, err)
+		}
+	}
+	return nil
+}
+
+// CustomCommand is an arbitrary dev command from .devdash.json, for projects
+// whose server isn't a package.json script (a binary, `make dev`, a shell
+// one-liner via "sh", "-c"). It runs as-is, without package manager or
+// Node version manager wrapping; PORT is still set from the launcher.
+type CustomCommand struct {
+	Name    string   `json:"name"`    // shown in the launcher's script list
+	Command string   `json:"command"` // executable, looked up in PATH unless it contains a "/"
+	Args    []string `json:"args,omitempty"`
+	Cwd     string   `json:"cwd,omitempty"` // working dir, relative to the project dir; default the project dir
+	Env     []string `json:"env,omitempty"` // extra KEY=VALUE pairs, applied after PORT
+
+	strict bool // from ProjectConfig.StrictEnv
+}
+
+// Dir returns the command's working directory for a project at projectDir
+func (c CustomCommand) Dir(projectDir string) string {
+	if c.Cwd == "" {
+		return projectDir
+	}
+	if filepath.IsAbs(c.Cwd) {
+		return filepath.Clean(c.Cwd)
+	}
+	return filepath.Join(projectDir, c.Cwd)
+}
+
+// varRef matches a ${NAME} reference, or the "$${" escape for a literal "${"
+var varRef = regexp.MustCompile(`\$\$\{|\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// Expand resolves ${VAR} references in the command, args, cwd, and env values
+// against environ (KEY=VALUE pairs, e.g. os.Environ() plus PORT). Env entries
+// are expanded in order, each visible to the ones after it and to the
+// command. Any other "$" — $VAR, $1, $?, $$ — is left for the shell; "$${"
+// is a literal "${". Undefined variables expand to "" unless strict_env is
+// set, in which case they are an error.
+func (c CustomCommand) Expand(environ []string) (CustomCommand, error) {
+	vars := make(map[string]string, len(environ)+len(c.Env))
+	for _, kv := range environ {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			vars[k] = v
+		}
+	}
+	var missing []string
+	expand := func(s string) string {
+		return varRef.ReplaceAllStringFunc(s, func(ref string) string {
+			if ref == "$${" {
+				return "${"
+			}
+			name := ref[2 : len(ref)-1]
+			v, ok := vars[name]
+			if !ok && !slices.Contains(missing, name) {
+				missing = append(missing, name)
+			}
+			return v
+		})
+	}
+
+	out := c
+	out.Env = slices.Clone(c.Env)
+	for i, kv := range c.Env {
+		k, v, _ := strings.Cut(kv, "=")
+		v = expand(v)
+		vars[k] = v
+		out.Env[i] = k + "=" + v
+	}
+	out.Command = expand(c.Command)
+	out.Cwd = expand(c.Cwd)
+	out.Args = slices.Clone(c.Args)
+	for i, a := range c.Args {
+		out.Args[i] = expand(a)
+	}
+
+	if c.strict && len(missing) > 0 {
+		return CustomCommand{}, fmt.Errorf("command %q: undefined variable %s", c.Name, strings.Join(missing, ", "))
+	}
+	return out, nil
+}
+
+// ExpandEnv resolves ${VAR} references in Env, the env of script launches,
+// against environ the same way Expand does for a custom command's env
+func (pc *ProjectConfig) ExpandEnv(environ []string) ([]string, error) {
+	c, err := CustomCommand{Name: "env", Env: pc.Env, strict: pc.StrictEnv}.Expand(environ)
+	return c.Env, err
+}
+
+// LaunchCommands returns the usable custom commands in file order, skipping
+// entries without a name or command and repeated names
+func (pc *ProjectConfig) LaunchCommands() []CustomCommand {
+	var cmds []CustomCommand
+	seen := make(map[string]bool)
+	for _, c := range pc.Commands {
+		if c.Name == "" || c.Command == "" || seen[c.Name] {
+			continue
+		}
+		seen[c.Name] = true
+		c.strict = pc.StrictEnv
+		cmds = append(cmds, c)
+	}
+	return cmds
+}
+
+// LaunchCommand returns the custom command called name
+func (pc *ProjectConfig) LaunchCommand(name string) (CustomCommand, bool) {
+	for _, c := range pc.LaunchCommands() {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return CustomCommand{}, false
+}
+
+// SaveProjectLaunch writes a default port and script env into dir's
+// .devdash.json, keeping every other key of an existing file as written.
+// Zero port or empty env removes the key.
+func SaveProjectLaunch(dir string, port int, env []string) error {
+	path := filepath.Join(dir, ProjectConfigFile)
+	raw := make(map[string]json.RawMessage)
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	case !os.IsNotExist(err):
+		return err
+	}
+
+	set := func(key string, v any, keep bool) {
+		if !keep {
+			delete(raw, key)
+			return
+		}
+		raw[key], _ = json.Marshal(v)
+	}
+	set("port", port, port > 0)
+	set("env", env, len(env) > 0)
+
+	out, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return err
+	}
+	return renameio.WriteFile(path, append(out, '\n'), 0o644)
+}
+
+// LoadProjectConfig reads .devdash.json from dir. Returns an empty config if
+// the file doesn't exist or can't be parsed.
+func LoadProjectConfig(dir string) *ProjectConfig {
+	cfg := &ProjectConfig{}
+	data, err := os.ReadFile(filepath.Join(dir, ProjectConfigFile))
+	if err != nil {
+		return cfg
+	}
+	_ = json.Unmarshal(data, cfg)
+	return cfg
+}
@@ -0,0 +1,111 @@
+package devdash
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Attach adopts a process devdash didn't start, by PID. It is tracked like a
+// reconnected session: its log file (info.LogPath, optional) is tailed, it can
+// be stopped and tunneled, and the session file makes it survive a devdash
+// restart. There is no stdin, so interactive mode is unavailable.
+func (pm *ProcessManager) Attach(info SessionInfo) (*RunningProcess, error) {
+	if !IsProcessAlive(info.PID) {
+		return nil, fmt.Errorf("no running process with PID %d", info.PID)
+	}
+	if info.Name == "" {
+		info.Name = fmt.Sprintf("pid-%d", info.PID)
+	}
+	if info.Command == "" {
+		info.Command = processName(pm.runner, info.PID)
+	}
+	if info.LogPath != "" {
+		abs, err := filepath.Abs(info.LogPath)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(abs); err != nil {
+			return nil, fmt.Errorf("log file: %w", err)
+		}
+		info.LogPath = abs
+	}
+	info.Attached = true
+	info.StartedAt = pm.clock.Now().Unix()
+
+	if pm.Get(info.Name) != nil {
+		return nil, fmt.Errorf("session %q already exists", info.Name)
+	}
+	if err := SaveSession(pm.sessionsDir, info); err != nil {
+		return nil, fmt.Errorf("failed to save session %q: %w", info.Name, err)
+	}
+
+	rp := pm.reconnectSession(info)
+	if rp == nil {
+		return nil, fmt.Errorf("process %d exited while attaching", info.PID)
+	}
+	pm.mu.Lock()
+	pm.notify(rp, EventStart, nil)
+	pm.mu.Unlock()
+	return rp, nil
+}
+
+// sessionLogPath returns the log file tailed for a session: devdash's own log,
+// or for attached processes the file the user pointed at ("" for none)
+func (pm *ProcessManager) sessionLogPath(info SessionInfo) string {
+	if info.Attached {
+		return info.LogPath
+	}
+	if info.NoLog {
+		return ""
+	}
+	return pm.logFilePath(info.Name)
+}
+
+// terminatePID is terminate for an attached process: sig and then SIGKILL go
+// to pid alone, not to a process group or tree devdash doesn't own
+func (pm *ProcessManager) terminatePID(pid int, sig syscall.Signal, exited <-chan struct{}, timeout time.Duration) {
+	_ = syscall.Kill(pid, sig)
+	select {
+	case <-exited:
+	case <-pm.clock.After(timeout):
+		_ = syscall.Kill(pid, syscall.SIGKILL)
+		<-exited
+	}
+}
+
+// watchAttached marks an attached process stopped once its PID is gone.
+// Stops from devdash are left to StopReconnected.
+func (pm *ProcessManager) watchAttached(rp *RunningProcess) {
+	<-pm.watchExit(rp.Info.PID)
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	if pm.processes[rp.Info.Name] != rp || !rp.stopDeadline.IsZero() {
+		return
+	}
+	rp.Status = StatusStopped
+	rp.ExitedAt = pm.clock.Now()
+	if rp.Build != nil {
+		rp.Build.Cancel()
+	}
+	rp.LogBuf.Write([]byte("\n[attached process exited]\n"))
+	rp.LogBuf.Flush()
+	pm.notify(rp, EventStop, nil)
+}
+
+// processName returns the executable name of pid, e.g. "node"; "" if unknown
+func processName(r Runner, pid int) string {
+	if comm, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "comm")); err == nil {
+		return strings.TrimSpace(string(comm))
+	}
+	out, err := r.Output("ps", "-o", "comm=", "-p", strconv.Itoa(pid))
+	if err != nil {
+		return ""
+	}
+	return filepath.Base(strings.TrimSpace(string(out)))
+}
@@ -0,0 +1,134 @@
+package devdash
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestAttachTracksExternalProcess(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "server.log")
+	if err := os.WriteFile(logPath, []byte("listening on :4000\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command("sleep", "30")
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer cmd.Process.Kill()
+	go cmd.Wait()
+
+	sessions := t.TempDir()
+	pm := NewProcessManager(sessions, t.TempDir())
+	rp, err := pm.Attach(SessionInfo{PID: cmd.Process.Pid, Port: 4000, LogPath: logPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rp.Info.Name != "pid-"+itoa(cmd.Process.Pid) || rp.Info.Command != "sleep" || rp.StdinPipe != nil {
+		t.Errorf("attached info = %+v", rp.Info)
+	}
+	if !strings.Contains(rp.LogBuf.Content(), "listening on :4000") {
+		t.Errorf("log not loaded: %q", rp.LogBuf.Content())
+	}
+	if _, err := pm.Restart(rp.Info.Name); err == nil {
+		t.Error("restarting an attached process should fail")
+	}
+
+	// Persisted so the next devdash run reconnects to it
+	saved, _ := LoadAllSessions(sessions)
+	if len(saved) != 1 || !saved[0].Attached || saved[0].LogPath != logPath {
+		t.Errorf("saved sessions = %+v", saved)
+	}
+
+	// Exiting outside devdash is noticed
+	_ = cmd.Process.Kill()
+	status := func() ProcessStatus {
+		pm.mu.RLock()
+		defer pm.mu.RUnlock()
+		return rp.Status
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for status() == StatusRunning && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+	if st := status(); st != StatusStopped {
+		t.Errorf("status = %v after the process exited", st)
+	}
+}
+
+func TestAttachRejectsDeadPID(t *testing.T) {
+	pm := NewProcessManager(t.TempDir(), t.TempDir())
+	cmd := exec.Command("true")
+	if err := cmd.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pm.Attach(SessionInfo{PID: cmd.Process.Pid}); err == nil {
+		t.Error("attaching to an exited PID should fail")
+	}
+}
+
+func TestStopAttachedSignalsOnlyItsPID(t *testing.T) {
+	// Two processes in one group; only the first is attached
+	target := exec.Command("sleep", "30")
+	target.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if err := target.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer target.Process.Kill()
+	go target.Wait()
+	sibling := exec.Command("sleep", "30")
+	sibling.SysProcAttr = &syscall.SysProcAttr{Setpgid: true, Pgid: target.Process.Pid}
+	if err := sibling.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer sibling.Process.Kill()
+	go sibling.Wait()
+
+	pm := NewProcessManager(t.TempDir(), t.TempDir())
+	rp, err := pm.Attach(SessionInfo{PID: target.Process.Pid})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pm.StopReconnected(rp.Info.Name); err != nil {
+		t.Fatal(err)
+	}
+	if IsProcessAlive(target.Process.Pid) && !isZombie(target.Process.Pid) {
+		t.Error("attached process survived the stop")
+	}
+	if !IsProcessAlive(sibling.Process.Pid) {
+		t.Error("stop signaled the attached process's whole group")
+	}
+}
+
+func TestStopReconnectedLeavesStoppedPIDAlone(t *testing.T) {
+	// The PID of a session that already exited may belong to something else
+	other := exec.Command("sleep", "30")
+	if err := other.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer other.Process.Kill()
+	go other.Wait()
+
+	pm := NewProcessManager(t.TempDir(), t.TempDir())
+	rp, err := pm.Attach(SessionInfo{PID: other.Process.Pid})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pm.mu.Lock()
+	rp.Status = StatusStopped
+	pm.mu.Unlock()
+
+	if err := pm.StopReconnected(rp.Info.Name); err != nil {
+		t.Fatal(err)
+	}
+	if !IsProcessAlive(other.Process.Pid) {
+		t.Error("stopping a stopped session signaled its old PID")
+	}
+	if pm.Get(rp.Info.Name) != nil {
+		t.Error("stopped session still tracked")
+	}
+}
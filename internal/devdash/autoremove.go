@@ -0,0 +1,42 @@
+package devdash
+
+import "time"
+
+// SetAutoRemove makes RemoveExpired drop sessions that exited cleanly at
+// least d ago. Zero, the default, keeps them until they are killed.
+func (pm *ProcessManager) SetAutoRemove(d time.Duration) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.autoRemove = d
+}
+
+// RemoveExpired removes sessions that exited cleanly longer than the
+// auto-remove period before now, returning their names. Errored sessions are
+// kept so the failure stays visible. Session state is deleted as on Stop;
+// the log file is kept.
+func (pm *ProcessManager) RemoveExpired(now time.Time) []string {
+	pm.mu.Lock()
+	if pm.autoRemove <= 0 {
+		pm.mu.Unlock()
+		return nil
+	}
+	var removed []string
+	for name, rp := range pm.processes {
+		if rp.Status != StatusStopped || rp.ExitedAt.IsZero() || now.Sub(rp.ExitedAt) < pm.autoRemove {
+			continue
+		}
+		// Started processes stop their tail on exit; attached ones keep
+		// tailing their log file until removed
+		if rp.Cmd == nil && rp.tailStop != nil {
+			close(rp.tailStop)
+		}
+		delete(pm.processes, name)
+		removed = append(removed, name)
+	}
+	pm.mu.Unlock()
+
+	for _, name := range removed {
+		_ = RemoveSession(pm.sessionsDir, name)
+	}
+	return removed
+}
@@ -0,0 +1,72 @@
+package devdash
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRemoveExpired(t *testing.T) {
+	sessionsDir := t.TempDir()
+	pm := NewProcessManager(sessionsDir, t.TempDir())
+	now := time.Now()
+
+	add := func(name string, status ProcessStatus, exited time.Duration) {
+		info := SessionInfo{Name: name}
+		if err := SaveSession(sessionsDir, info); err != nil {
+			t.Fatal(err)
+		}
+		rp := &RunningProcess{Info: info, Status: status}
+		if exited > 0 {
+			rp.ExitedAt = now.Add(-exited)
+		}
+		pm.processes[name] = rp
+	}
+	add("done", StatusStopped, 10*time.Minute)
+	add("recent", StatusStopped, time.Minute)
+	add("crashed", StatusError, time.Hour)
+	add("web", StatusRunning, 0)
+
+	if got := pm.RemoveExpired(now); got != nil {
+		t.Fatalf("auto-remove is off by default, removed %v", got)
+	}
+
+	pm.SetAutoRemove(5 * time.Minute)
+	if got := pm.RemoveExpired(now); !reflect.DeepEqual(got, []string{"done"}) {
+		t.Fatalf("removed %v, want [done]", got)
+	}
+	if pm.Get("done") != nil {
+		t.Error("done is still managed")
+	}
+	if _, err := os.Stat(sessionFilePath(sessionsDir, "done")); !os.IsNotExist(err) {
+		t.Errorf("session file of done should be deleted, stat err = %v", err)
+	}
+	for _, name := range []string{"recent", "crashed", "web"} {
+		if pm.Get(name) == nil {
+			t.Errorf("%s should be kept", name)
+		}
+	}
+
+	if got := pm.RemoveExpired(now.Add(5 * time.Minute)); !reflect.DeepEqual(got, []string{"recent"}) {
+		t.Errorf("later removed %v, want [recent]", got)
+	}
+}
+
+func TestRemoveExpiredAfterCleanExit(t *testing.T) {
+	pm := NewProcessManager(t.TempDir(), t.TempDir())
+	pm.SetAutoRemove(time.Minute)
+	rp, err := pm.Start(SessionInfo{Name: "once", Command: "true", WorkDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-rp.Done()
+	waitForLog(t, rp, "[process exited normally]")
+
+	if got := pm.RemoveExpired(time.Now()); got != nil {
+		t.Fatalf("removed %v before the grace period", got)
+	}
+	if got := pm.RemoveExpired(rp.ExitedAt.Add(time.Minute)); !reflect.DeepEqual(got, []string{"once"}) {
+		t.Errorf("removed %v, want [once]", got)
+	}
+}
@@ -0,0 +1,129 @@
+package devdash
+
+import (
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Default build patterns, matched against ANSI-stripped log lines. They cover
+// the rebuild messages of common dev servers: webpack/Next.js, Vite, tsc
+// --watch, nodemon, cargo-watch, air, and Angular.
+var (
+	defaultBuildStart = regexp.MustCompile(`(?i)(file change detected|change detected|compiling|rebuilding|building|restarting due to changes|starting compilation)`)
+	defaultBuildDone  = regexp.MustCompile(`(?i)(compiled successfully|compiled with warnings|compiled .* in \d|ready in \d|built in \d|build (succeeded|complete|finished)|found \d+ errors?\. watching for file changes|server running)`)
+)
+
+// BuildTimer times rebuilds of a session: from a log line matching the start
+// pattern, or a manual start, to the next line matching the done pattern.
+type BuildTimer struct {
+	mu      sync.Mutex
+	start   *regexp.Regexp
+	done    *regexp.Regexp
+	started time.Time     // zero unless a build is in progress
+	last    time.Duration // duration of the last finished build
+	lastAt  time.Time     // when the last build finished
+}
+
+// NewBuildTimer creates a timer for info's build_start/build_done patterns,
+// falling back to the defaults for an empty or invalid pattern
+func NewBuildTimer(info SessionInfo) *BuildTimer {
+	return &BuildTimer{
+		start: compileOr(info.BuildStart, defaultBuildStart),
+		done:  compileOr(info.BuildDone, defaultBuildDone),
+	}
+}
+
+// compileOr compiles expr, returning def when it is empty or invalid
+func compileOr(expr string, def *regexp.Regexp) *regexp.Regexp {
+	if expr == "" {
+		return def
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return def
+	}
+	return re
+}
+
+// Begin starts timing at t unless a build is already being timed, so a burst
+// of "compiling..." lines measures from the first one
+func (bt *BuildTimer) Begin(t time.Time) {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+	if bt.started.IsZero() {
+		bt.started = t
+	}
+}
+
+// Finish ends the build in progress at t and returns its duration.
+// ok is false if no build was being timed.
+func (bt *BuildTimer) Finish(t time.Time) (d time.Duration, ok bool) {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+	if bt.started.IsZero() {
+		return 0, false
+	}
+	bt.last, bt.lastAt = t.Sub(bt.started), t
+	bt.started = time.Time{}
+	return bt.last, true
+}
+
+// Cancel drops the build in progress without recording it, for a process that
+// exited before the build finished
+func (bt *BuildTimer) Cancel() {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+	bt.started = time.Time{}
+}
+
+// Toggle is the manual start/stop: it finishes a build in progress, or
+// starts one. running reports whether a build is being timed afterwards.
+func (bt *BuildTimer) Toggle(t time.Time) (d time.Duration, running bool) {
+	if d, ok := bt.Finish(t); ok {
+		return d, false
+	}
+	bt.Begin(t)
+	return 0, true
+}
+
+// Snapshot returns when the build in progress started (zero if none) and the
+// duration of the last finished build (zero if none)
+func (bt *BuildTimer) Snapshot() (started time.Time, last time.Duration) {
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+	return bt.started, bt.last
+}
+
+// Observe checks one log line at time t. The done pattern is checked first,
+// since lines like "compiled successfully" also match a start pattern.
+func (bt *BuildTimer) Observe(line string, t time.Time) {
+	plain := ansi.Strip(line)
+	switch {
+	case bt.done.MatchString(plain):
+		bt.Finish(t)
+	case bt.start.MatchString(plain):
+		bt.Begin(t)
+	}
+}
+
+// watchBuilds feeds the log lines arriving on ch, a subscription to rp's log
+// buffer taken before tailing starts, to its build timer and ready detector
+// until stop closes
+func (pm *ProcessManager) watchBuilds(rp *RunningProcess, ch chan string, stop <-chan struct{}) {
+	defer rp.LogBuf.Unsubscribe(ch)
+	for {
+		select {
+		case line := <-ch:
+			now := pm.clock.Now()
+			rp.Build.Observe(line, now)
+			if rp.Ready.Observe(line, now) {
+				pm.markReady(rp.Info.Name)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package devdash
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildTimerDefaultPatterns(t *testing.T) {
+	bt := NewBuildTimer(SessionInfo{})
+	t0 := time.Unix(1000, 0)
+
+	bt.Observe("\x1b[33mwait\x1b[0m  - compiling /page ...", t0)
+	bt.Observe("event - compiling /api ...", t0.Add(time.Second)) // still the same build
+	if started, _ := bt.Snapshot(); !started.Equal(t0) {
+		t.Fatalf("build should start at the first compiling line, got %v", started)
+	}
+	bt.Observe("event - compiled successfully in 1.8s (312 modules)", t0.Add(1800*time.Millisecond))
+	started, last := bt.Snapshot()
+	if !started.IsZero() || last != 1800*time.Millisecond {
+		t.Errorf("after done: started=%v last=%v, want zero and 1.8s", started, last)
+	}
+
+	// A done line with no build in progress changes nothing
+	bt.Observe("VITE v5.0.0  ready in 320 ms", t0.Add(time.Minute))
+	if _, last := bt.Snapshot(); last != 1800*time.Millisecond {
+		t.Errorf("unmatched done line replaced the last build: %v", last)
+	}
+}
+
+func TestBuildTimerCustomPatterns(t *testing.T) {
+	bt := NewBuildTimer(SessionInfo{BuildStart: `^>> go`, BuildDone: `^>> ok`})
+	t0 := time.Unix(1000, 0)
+
+	bt.Observe("compiling", t0) // default start pattern is replaced
+	if started, _ := bt.Snapshot(); !started.IsZero() {
+		t.Fatal("default start pattern should not apply")
+	}
+	bt.Observe(">> go", t0)
+	bt.Observe(">> ok", t0.Add(3*time.Second))
+	if _, last := bt.Snapshot(); last != 3*time.Second {
+		t.Errorf("last = %v, want 3s", last)
+	}
+}
+
+func TestBuildTimerToggle(t *testing.T) {
+	bt := NewBuildTimer(SessionInfo{})
+	t0 := time.Unix(1000, 0)
+	if _, running := bt.Toggle(t0); !running {
+		t.Fatal("first toggle should start the timer")
+	}
+	d, running := bt.Toggle(t0.Add(2 * time.Second))
+	if running || d != 2*time.Second {
+		t.Errorf("second toggle: d=%v running=%v, want 2s and stopped", d, running)
+	}
+}
+
+func TestStartTimesBootAsBuild(t *testing.T) {
+	pm := NewProcessManager(t.TempDir(), t.TempDir())
+	script := `sleep 0.2; echo "Compiled successfully"; while :; do sleep 0.05; done`
+	rp, err := pm.Start(SessionInfo{Name: "web", Command: "sh", Args: []string{"-c", script}, WorkDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pm.Stop("web")
+
+	waitForLog(t, rp, "Compiled successfully")
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, last := rp.Build.Snapshot(); last >= 200*time.Millisecond {
+			break
+		}
+		if time.Now().After(deadline) {
+			started, last := rp.Build.Snapshot()
+			t.Fatalf("boot was not timed: started=%v last=%v", started, last)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestExitCancelsBuild(t *testing.T) {
+	// Started processes are timed from launch; one that never prints a done
+	// line must not leave the timer running once it exits
+	pm := NewProcessManager(t.TempDir(), t.TempDir())
+	rp, err := pm.Start(SessionInfo{Name: "web", Command: "sh", Args: []string{"-c", "sleep 0.1"}, WorkDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-rp.Done()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		started, last := rp.Build.Snapshot()
+		if started.IsZero() {
+			if last != 0 {
+				t.Errorf("exit recorded a build of %v", last)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("build still in progress after the process exited")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
@@ -0,0 +1,49 @@
+package devdash
+
+import (
+	"context"
+	"os/exec"
+	"time"
+)
+
+// Clock is a ProcessManager's time source: timestamps, stop deadlines and
+// the waits in between. Tests swap in a fake one to avoid real sleeps.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+	Sleep(d time.Duration)
+}
+
+// SystemClock is the real time, every ProcessManager's default Clock
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (systemClock) Sleep(d time.Duration)                  { time.Sleep(d) }
+
+// Runner resolves binaries and runs the short commands a ProcessManager
+// queries, like ps. Command builds the ones the UI runs itself: the editor,
+// the file manager, installs and clipboard helpers. Launched sessions and
+// tunnels are exec'd directly.
+type Runner interface {
+	LookPath(file string) (string, error)
+	Output(name string, args ...string) ([]byte, error)
+	Command(ctx context.Context, name string, args ...string) *exec.Cmd
+}
+
+// SystemRunner looks up and runs real commands, the default Runner
+var SystemRunner Runner = systemRunner{}
+
+type systemRunner struct{}
+
+func (systemRunner) LookPath(file string) (string, error) { return exec.LookPath(file) }
+
+func (systemRunner) Output(name string, args ...string) ([]byte, error) {
+	return exec.Command(name, args...).Output()
+}
+
+func (systemRunner) Command(ctx context.Context, name string, args ...string) *exec.Cmd {
+	return exec.CommandContext(ctx, name, args...)
+}
@@ -0,0 +1,161 @@
+package devdash
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock whose waits move its time forward and return after a
+// real millisecond, so polling loops on it don't spin
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.Sleep(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.Now()
+	return ch
+}
+
+func (c *fakeClock) Sleep(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+	time.Sleep(time.Millisecond)
+}
+
+// fakeRunner finds only the binaries in paths and runs nothing, answering
+// with the canned output for the command's name instead
+type fakeRunner struct {
+	paths   map[string]string
+	outputs map[string]string
+}
+
+func (r fakeRunner) LookPath(file string) (string, error) {
+	if p, ok := r.paths[file]; ok {
+		return p, nil
+	}
+	return "", errors.New("not found")
+}
+
+func (r fakeRunner) Output(name string, _ ...string) ([]byte, error) {
+	if out, ok := r.outputs[name]; ok {
+		return []byte(out), nil
+	}
+	return nil, errors.New("not run")
+}
+
+// Command runs true in place of any command
+func (r fakeRunner) Command(ctx context.Context, _ string, _ ...string) *exec.Cmd {
+	return exec.CommandContext(ctx, "true")
+}
+
+func TestStopTimeoutUsesClock(t *testing.T) {
+	pm := NewProcessManager(t.TempDir(), t.TempDir())
+	clock := &fakeClock{now: time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)}
+	pm.SetClock(clock)
+	startAt := clock.Now()
+
+	rp, err := pm.Start(SessionInfo{
+		Name:        "stubborn",
+		Command:     "sh",
+		Args:        []string{"-c", "trap '' TERM; while true; do sleep 0.1; done"},
+		WorkDir:     t.TempDir(),
+		StopTimeout: time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rp.StartedAt.Equal(startAt) {
+		t.Errorf("StartedAt = %v, want the clock's %v", rp.StartedAt, startAt)
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	// The hour-long timeout passes on the fake clock, not in real time. The
+	// log tail polls on the clock too, so it keeps moving while the test waits.
+	stopAt := clock.Now()
+	start := time.Now()
+	if err := pm.Stop("stubborn"); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("Stop took %v, want no real wait", elapsed)
+	}
+	if d := rp.StopDeadline().Sub(stopAt); d < time.Hour || d > time.Hour+time.Minute {
+		t.Errorf("StopDeadline = %v, want an hour after %v", rp.StopDeadline(), stopAt)
+	}
+}
+
+func TestRestartPauseUsesClock(t *testing.T) {
+	pm := NewProcessManager(t.TempDir(), t.TempDir())
+	clock := &fakeClock{now: time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)}
+	pm.SetClock(clock)
+
+	if _, err := pm.Start(SessionInfo{Name: "web", Command: "sleep", Args: []string{"30"}, WorkDir: t.TempDir()}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = pm.Stop("web") })
+	before := clock.Now()
+
+	rp, err := pm.Restart("web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The pause between stop and start passes on the fake clock
+	if waited := rp.LastRestart.Sub(before); waited < 200*time.Millisecond {
+		t.Errorf("restart waited %v on the clock, want at least 200ms", waited)
+	}
+	// The new session's log tail moves the clock on, so Now can only be later
+	if now := clock.Now(); rp.StartedAt.After(now) || !rp.LastRestart.Equal(rp.StartedAt) {
+		t.Errorf("StartedAt %v, LastRestart %v; want both at the clock's time, by %v", rp.StartedAt, rp.LastRestart, now)
+	}
+}
+
+func TestRunnerAnswersPSQueries(t *testing.T) {
+	r := fakeRunner{outputs: map[string]string{"ps": "  10     1\n  11    10\n  12    11\n  20     1\n"}}
+	if got := descendants(psChildren(r), 10); !reflect.DeepEqual(got, []int{11, 12}) {
+		t.Errorf("descendants of 10 = %v, want [11 12]", got)
+	}
+
+	r.outputs["ps"] = "  10     1  1.5  2048\n  11    10  2.5  1024\n  20     1  9.0   512\n"
+	got := psUsage(r, []int{10, 30})
+	if u := got[10]; !u.HasCPU || u.CPU != 4 || u.RSS != 3072*1024 {
+		t.Errorf("usage of 10 = %+v, want 4%% CPU and 3 MiB summed over its tree", u)
+	}
+	if _, ok := got[30]; ok {
+		t.Error("a PID missing from ps should be left out")
+	}
+}
+
+func TestRunnerResolvesBinaries(t *testing.T) {
+	pm := NewProcessManager(t.TempDir(), t.TempDir())
+	pm.SetRunner(fakeRunner{paths: map[string]string{"pnpm": "/opt/pnpm/bin/pnpm"}})
+
+	if got := pm.PnpmPath(); got != "/opt/pnpm/bin/pnpm" {
+		t.Errorf("PnpmPath() = %q, want the runner's path", got)
+	}
+	if pm.CloudflaredAvailable() {
+		t.Error("CloudflaredAvailable() = true without cloudflared on the runner's path")
+	}
+
+	pm.SetRunner(fakeRunner{paths: map[string]string{"cloudflared": "/usr/bin/cloudflared"}})
+	if got := pm.PnpmPath(); got != "pnpm" {
+		t.Errorf("PnpmPath() = %q, want the bare name when pnpm isn't found", got)
+	}
+	if !pm.CloudflaredAvailable() {
+		t.Error("CloudflaredAvailable() = false with cloudflared on the runner's path")
+	}
+}
@@ -0,0 +1,60 @@
+package devdash
+
+import "fmt"
+
+// RunningElsewhereError reports a session that can't be started because a
+// session file of the same name belongs to a live process this manager
+// doesn't track, usually one started by another devdash
+type RunningElsewhereError struct {
+	Info SessionInfo // the running session, from its session file
+}
+
+func (e *RunningElsewhereError) Error() string {
+	return fmt.Sprintf("%q is already running as PID %d, possibly under another devdash", e.Info.Name, e.Info.PID)
+}
+
+// RunningElsewhere returns the session file of a live session called name
+// that this manager doesn't track, false if there is none
+func (pm *ProcessManager) RunningElsewhere(name string) (SessionInfo, bool) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	if _, tracked := pm.processes[name]; tracked {
+		return SessionInfo{}, false
+	}
+	return pm.runningElsewhere(name)
+}
+
+// runningElsewhere is RunningElsewhere without the tracked check; pm.mu held
+func (pm *ProcessManager) runningElsewhere(name string) (SessionInfo, bool) {
+	info, ok := LoadSession(pm.sessionsDir, name)
+	if !ok || !IsProcessAlive(info.PID) {
+		return SessionInfo{}, false
+	}
+	return info, true
+}
+
+// startConflict reports why a session called name can't be started: it is
+// already tracked here, or running under another devdash; pm.mu held
+func (pm *ProcessManager) startConflict(name string) error {
+	if _, exists := pm.processes[name]; exists {
+		return fmt.Errorf("process %q already running", name)
+	}
+	if other, ok := pm.runningElsewhere(name); ok {
+		return &RunningElsewhereError{Info: other}
+	}
+	return nil
+}
+
+// Adopt reconnects to the live session called name found by
+// RunningElsewhere, as Reconnect does at startup, so it shows up here too
+func (pm *ProcessManager) Adopt(name string) (*RunningProcess, error) {
+	info, ok := pm.RunningElsewhere(name)
+	if !ok {
+		return nil, fmt.Errorf("%q is no longer running", name)
+	}
+	rp := pm.reconnectSession(info)
+	if rp == nil {
+		return nil, fmt.Errorf("%q is no longer running", name)
+	}
+	return rp, nil
+}
@@ -0,0 +1,40 @@
+package devdash
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestRunningElsewhere(t *testing.T) {
+	dir := t.TempDir()
+	pm := NewProcessManager(dir, t.TempDir())
+	if _, ok := pm.RunningElsewhere("web"); ok {
+		t.Fatal("RunningElsewhere = true without a session file")
+	}
+
+	// A session file for a live process this manager doesn't track
+	info := SessionInfo{Name: "web", PID: os.Getpid(), Port: 3000}
+	if err := SaveSession(dir, info); err != nil {
+		t.Fatal(err)
+	}
+	got, ok := pm.RunningElsewhere("web")
+	if !ok || got.PID != info.PID {
+		t.Fatalf("RunningElsewhere = %+v, %v; want PID %d", got, ok, info.PID)
+	}
+
+	_, err := pm.Start(SessionInfo{Name: "web", Command: "true"})
+	var elsewhere *RunningElsewhereError
+	if !errors.As(err, &elsewhere) || elsewhere.Info.PID != info.PID {
+		t.Errorf("Start error = %v, want a RunningElsewhereError for PID %d", err, info.PID)
+	}
+
+	// A stale session file doesn't count
+	info.PID = 1 << 30
+	if err := SaveSession(dir, info); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := pm.RunningElsewhere("web"); ok {
+		t.Error("RunningElsewhere = true for a dead PID")
+	}
+}
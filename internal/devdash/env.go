@@ -0,0 +1,51 @@
+package devdash
+
+import (
+	"os"
+	"strings"
+)
+
+// cleanEnvKeys are the inherited variables a clean environment keeps: what
+// shells, package managers and Node version managers need to find things
+var cleanEnvKeys = map[string]bool{
+	"PATH": true, "HOME": true, "USER": true, "LOGNAME": true, "SHELL": true,
+	"TERM": true, "LANG": true, "TZ": true, "TMPDIR": true, "SSH_AUTH_SOCK": true,
+	"NVM_DIR": true, "VOLTA_HOME": true, "PNPM_HOME": true, "COREPACK_HOME": true,
+}
+
+// cleanEnvPrefixes are variable name prefixes a clean environment keeps:
+// locale, XDG directories, and fnm's and asdf's settings
+var cleanEnvPrefixes = []string{"LC_", "XDG_", "FNM_", "ASDF_"}
+
+// BaseEnv returns the environment a launched process starts from, before
+// LaunchEnv is added: devdash's own, or with clean only its essentials, so a
+// stray NODE_ENV or PORT in the shell doesn't leak into the dev server
+func BaseEnv(clean bool) []string {
+	if !clean {
+		return os.Environ()
+	}
+	return cleanEnviron(os.Environ())
+}
+
+// cleanEnviron keeps the variables of environ listed in cleanEnvKeys or
+// starting with one of cleanEnvPrefixes
+func cleanEnviron(environ []string) []string {
+	var out []string
+	for _, kv := range environ {
+		key, _, _ := strings.Cut(kv, "=")
+		if cleanEnvKeys[key] || hasAnyPrefix(key, cleanEnvPrefixes) {
+			out = append(out, kv)
+		}
+	}
+	return out
+}
+
+// hasAnyPrefix reports whether s starts with one of prefixes
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,27 @@
+package devdash
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCleanEnviron(t *testing.T) {
+	environ := []string{
+		"PATH=/usr/bin", "HOME=/home/me", "NODE_ENV=production", "PORT=8080",
+		"LC_ALL=en_US.UTF-8", "FNM_DIR=/home/me/.fnm", "AWS_PROFILE=prod", "Path=C:\\bin",
+	}
+	want := []string{"PATH=/usr/bin", "HOME=/home/me", "LC_ALL=en_US.UTF-8", "FNM_DIR=/home/me/.fnm"}
+	if got := cleanEnviron(environ); !slices.Equal(got, want) {
+		t.Errorf("cleanEnviron = %v, want %v", got, want)
+	}
+}
+
+func TestBaseEnv(t *testing.T) {
+	t.Setenv("NODE_ENV", "production")
+	if !slices.Contains(BaseEnv(false), "NODE_ENV=production") {
+		t.Error("inherited environment should keep NODE_ENV")
+	}
+	if slices.Contains(BaseEnv(true), "NODE_ENV=production") {
+		t.Error("clean environment should drop NODE_ENV")
+	}
+}
@@ -1,9 +1,9 @@
 package devdash
 
 import (
+	"bytes"
 	"io"
 	"os"
-	"os/exec"
 	"time"
 
 	"github.com/kimaguri/simplx-toolkit/internal/process"
@@ -14,11 +14,19 @@ import (
 // calling LogBuffer methods directly to remove/replace lines across chunk boundaries.
 // All other content is passed through process.SanitizeForLog.
 type interactiveSanitizer struct {
-	buf *process.LogBuffer
+	buf   *process.LogBuffer
+	carry []byte // a rune cut off at the end of the last chunk
 }
 
 // Write processes a chunk of raw terminal data.
 func (s *interactiveSanitizer) Write(p []byte) (int, error) {
+	n := len(p)
+	if len(s.carry) > 0 {
+		p = append(s.carry, p...)
+	}
+	p, rest := process.SplitIncompleteRune(p)
+	s.carry = bytes.Clone(rest) // rest may alias the caller's buffer
+
 	i := 0
 	segStart := 0
 
@@ -89,16 +97,20 @@ func (s *interactiveSanitizer) Write(p []byte) (int, error) {
 		s.buf.Write(process.SanitizeForLog(p[segStart:]))
 	}
 
-	return len(p), nil
+	return n, nil
 }
 
 // tailFile reads from a log file starting at offset and writes new content to buf.
-// Polls the file for new data until stop is closed.
+// Polls the file for new data until stop is closed, then reads what is left.
+// drained, if not nil, is closed once that last read is in buf.
 // Uses interactiveSanitizer for cross-chunk cursor-up and carriage return handling.
-func tailFile(path string, buf *process.LogBuffer, startOffset int64, stop <-chan struct{}) {
+func tailFile(clock Clock, path string, buf *process.LogBuffer, startOffset int64, stop <-chan struct{}, drained chan<- struct{}) {
+	if drained != nil {
+		defer close(drained)
+	}
 	sw := &interactiveSanitizer{buf: buf}
 
-	f, err := waitForFile(path, stop)
+	f, err := waitForFile(clock, path, stop)
 	if f == nil || err != nil {
 		return
 	}
@@ -122,7 +134,7 @@ func tailFile(path string, buf *process.LogBuffer, startOffset int64, stop <-cha
 			sw.Write(readBuf[:n])
 		}
 		if readErr == io.EOF || n == 0 {
-			time.Sleep(100 * time.Millisecond)
+			clock.Sleep(100 * time.Millisecond)
 			continue
 		}
 		if readErr != nil {
@@ -131,8 +143,39 @@ func tailFile(path string, buf *process.LogBuffer, startOffset int64, stop <-cha
 	}
 }
 
+// readPipe copies a session's output from the read end of its pipe into buf,
+// for sessions without a log file, until the pipe ends. Once stop is closed
+// only what the pipe already holds is read, so a descendant that keeps the
+// pipe open can't hold up the exit.
+func readPipe(clock Clock, r *os.File, buf *process.LogBuffer, stop <-chan struct{}, drained chan<- struct{}) {
+	defer close(drained)
+	defer r.Close()
+	sw := &interactiveSanitizer{buf: buf}
+
+	go func() {
+		<-stop
+		<-clock.After(100 * time.Millisecond)
+		_ = r.SetReadDeadline(pastDeadline)
+	}()
+
+	readBuf := make([]byte, 4096)
+	for {
+		n, err := r.Read(readBuf)
+		if n > 0 {
+			sw.Write(readBuf[:n])
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// pastDeadline is a read deadline that has already passed, failing the
+// reads in progress right away
+var pastDeadline = time.Unix(1, 0)
+
 // waitForFile tries to open the file, retrying up to 50 times (5s total).
-func waitForFile(path string, stop <-chan struct{}) (*os.File, error) {
+func waitForFile(clock Clock, path string, stop <-chan struct{}) (*os.File, error) {
 	f, err := os.Open(path)
 	if err == nil {
 		return f, nil
@@ -142,7 +185,7 @@ func waitForFile(path string, stop <-chan struct{}) (*os.File, error) {
 		select {
 		case <-stop:
 			return nil, nil
-		case <-time.After(100 * time.Millisecond):
+		case <-clock.After(100 * time.Millisecond):
 		}
 		f, err = os.Open(path)
 		if err == nil {
@@ -165,9 +208,9 @@ func drainFile(f *os.File, buf []byte, sw *interactiveSanitizer) {
 	}
 }
 
-// findPnpm locates the pnpm binary
-func findPnpm() string {
-	path, err := exec.LookPath("pnpm")
+// findPnpm locates the pnpm binary with r
+func findPnpm(r Runner) string {
+	path, err := r.LookPath("pnpm")
 	if err != nil {
 		return "pnpm"
 	}
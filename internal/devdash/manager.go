@@ -37,17 +37,29 @@ func (s ProcessStatus) String() string {
 
 // RunningProcess holds runtime information about a managed process
 type RunningProcess struct {
-	Info      SessionInfo
-	Cmd       *exec.Cmd
-	LogBuf    *process.LogBuffer
-	Status    ProcessStatus
-	StartedAt time.Time
-	StdinPipe *os.File             // stdin pipe write end (nil for reconnected processes)
-	VTerm     *process.VTermScreen // Virtual terminal screen (nil for reconnected)
-	Tunnel    *TunnelInfo          // Cloudflare tunnel (nil if none)
-	done      chan struct{}         // closed when process exits (by waitForExit)
-	tailStop  chan struct{}         // closed to stop the tail goroutine
-	logFile   *os.File             // log file handle (for started processes)
+	Info         SessionInfo
+	Cmd          *exec.Cmd
+	LogBuf       *process.LogBuffer
+	Status       ProcessStatus
+	StartedAt    time.Time
+	StdinPipe    *os.File             // stdin pipe write end (nil for reconnected processes)
+	VTerm        *process.VTermScreen // Virtual terminal screen (nil for reconnected)
+	Tunnel       *TunnelInfo          // Cloudflare tunnel (nil if none)
+	Restarts     int                  // number of times restarted via Restart
+	LastRestart  time.Time            // when Restart last started it; zero if never
+	ExitedAt     time.Time            // when the process exited on its own; zero while running
+	BootFailed   bool                 // exited with an error within bootWindow of starting
+	Build        *BuildTimer          // rebuild timing from log patterns (nil if not tracked)
+	Ready        *ReadyDetector       // first "server ready" log line (nil if not tracked)
+	done         chan struct{}        // closed when process exits (by waitForExit)
+	tailStop     chan struct{}        // closed to stop the tail goroutine
+	logFile      *os.File             // log file handle (for started processes with one)
+
+	// stopDeadline is when a stop escalates to SIGKILL; zero unless stopping.
+	// Set with the manager's lock held, so code holding it reads the field;
+	// everything else goes through StopDeadline.
+	stopMu       sync.Mutex
+	stopDeadline time.Time
 }
 
 // Done returns a channel that is closed when the process exits
@@ -55,6 +67,21 @@ func (rp *RunningProcess) Done() <-chan struct{} {
 	return rp.done
 }
 
+// StopDeadline returns when the stop in progress escalates to SIGKILL, zero
+// unless the process is being stopped
+func (rp *RunningProcess) StopDeadline() time.Time {
+	rp.stopMu.Lock()
+	defer rp.stopMu.Unlock()
+	return rp.stopDeadline
+}
+
+// setStopDeadline records a stop in progress; pm.mu must be held
+func (rp *RunningProcess) setStopDeadline(t time.Time) {
+	rp.stopMu.Lock()
+	rp.stopDeadline = t
+	rp.stopMu.Unlock()
+}
+
 // ProcessManager manages the lifecycle of dev processes
 type ProcessManager struct {
 	mu          sync.RWMutex
@@ -62,19 +89,52 @@ type ProcessManager struct {
 	sessionsDir string
 	logsDir     string
 	pnpmPath    string
+	webhook     *Webhook      // lifecycle event delivery (nil = off)
+	autoRemove  time.Duration // drop cleanly exited sessions after this long (0 = never)
+	preload     int64         // log bytes loaded on reconnect (0 = DefaultPreloadBytes)
+	clock       Clock         // time source (SystemClock)
+	runner      Runner        // binary lookups and ps queries (SystemRunner)
+	ready       chan string   // names of sessions whose server came up, see Ready
 }
 
+// readyBacklog is how many ready sessions the Ready channel holds unreceived
+const readyBacklog = 16
+
 // NewProcessManager creates a new manager.
 func NewProcessManager(sessionsDir, logsDir string) *ProcessManager {
-	pnpmPath := findPnpm()
 	return &ProcessManager{
 		processes:   make(map[string]*RunningProcess),
 		sessionsDir: sessionsDir,
 		logsDir:     logsDir,
-		pnpmPath:    pnpmPath,
+		pnpmPath:    findPnpm(SystemRunner),
+		clock:       SystemClock,
+		runner:      SystemRunner,
+		ready:       make(chan string, readyBacklog),
 	}
 }
 
+// SetClock replaces the time source. Call it before starting processes.
+func (pm *ProcessManager) SetClock(c Clock) {
+	pm.clock = c
+}
+
+// Clock returns the manager's time source
+func (pm *ProcessManager) Clock() Clock {
+	return pm.clock
+}
+
+// SetRunner replaces how binaries are found and queries are run, and looks
+// pnpm up again with it. Call it before starting processes.
+func (pm *ProcessManager) SetRunner(r Runner) {
+	pm.runner = r
+	pm.pnpmPath = findPnpm(r)
+}
+
+// Runner returns how the manager finds binaries and runs queries
+func (pm *ProcessManager) Runner() Runner {
+	return pm.runner
+}
+
 // PnpmPath returns the detected pnpm binary path
 func (pm *ProcessManager) PnpmPath() string {
 	return pm.pnpmPath
@@ -86,45 +146,121 @@ func (pm *ProcessManager) logFilePath(name string) string {
 	return fmt.Sprintf("%s/%s.log", pm.logsDir, safe)
 }
 
+// restartCarryLines is how many lines of the previous run's output are
+// carried into the fresh log buffer on restart
+const restartCarryLines = 200
+
+// bootWindow is how soon after starting an error exit counts as a failed boot
+// (bad flags, missing module, port in use) rather than a crash of a running server
+const bootWindow = 2 * time.Second
+
+// StartError reports a process that could not be spawned at all, e.g. a
+// missing binary or a permission error. It never got a PID.
+type StartError struct {
+	Name string
+	Err  error
+}
+
+func (e *StartError) Error() string {
+	return fmt.Sprintf("failed to start %q: %v", e.Name, e.Err)
+}
+
+func (e *StartError) Unwrap() error {
+	return e.Err
+}
+
 // Start spawns a new process based on the given SessionInfo
 func (pm *ProcessManager) Start(info SessionInfo) (*RunningProcess, error) {
+	return pm.start(info, nil, 0)
+}
+
+// start spawns the process. carry holds lines from a previous run to seed the
+// log buffer with; restarts > 0 writes a restart separator at the top of the log.
+func (pm *ProcessManager) start(info SessionInfo, carry []string, restarts int) (*RunningProcess, error) {
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
 
-	if _, exists := pm.processes[info.Name]; exists {
-		return nil, fmt.Errorf("process %q already running", info.Name)
+	if err := pm.startConflict(info.Name); err != nil {
+		return nil, err
 	}
 
+	// Without a log file the output goes through a pipe into the log buffer
+	// only. The process still runs; it just can't be reconnected to.
 	logFile, logPath, err := pm.createLogFile(info.Name)
+	for attempt := 1; err != nil && attempt < logCreateAttempts; attempt++ {
+		// Other callers (the UI's List on every tick) aren't held up by the
+		// wait; the name may have been taken meanwhile
+		pm.mu.Unlock()
+		pm.clock.Sleep(logCreateRetryDelay)
+		pm.mu.Lock()
+		if err := pm.startConflict(info.Name); err != nil {
+			return nil, err
+		}
+		logFile, logPath, err = pm.createLogFile(info.Name)
+	}
+	var output *os.File // pipe read end, when there is no log file
 	if err != nil {
-		return nil, err
+		r, w, pipeErr := os.Pipe()
+		if pipeErr != nil {
+			return nil, err
+		}
+		output, logFile, logPath = r, w, ""
+		_, _ = fmt.Fprintf(logFile, "[no persisted log: %v; output is kept in memory only and is lost if devdash quits]\n", err)
+	}
+	info.NoLog = output != nil
+	if restarts > 0 {
+		_, _ = fmt.Fprintf(logFile, "[=== restart %d at %s ===]\n", restarts, pm.clock.Now().Format("15:04:05"))
 	}
 
-	cmd := exec.Command(info.Command, info.Args...)
+	command, args := info.Command, info.Args
+	if info.OpenFiles > 0 {
+		limit, capped := openFilesLimit(info.OpenFiles)
+		if capped {
+			_, _ = fmt.Fprintf(logFile, "[open file limit capped at %d, the hard limit]\n", limit)
+		}
+		command, args = withOpenFiles(limit, command, args)
+	}
+
+	cmd := exec.Command(command, args...)
 	cmd.Dir = info.WorkDir
-	cmd.Env = append(os.Environ(), info.ExtraEnv...)
-	// FORCE_COLOR: colored output even though stdout is a file, not a TTY
-	// CI=true: prevents Vite from calling process.exit() on stdin EOF
-	//          (Vite listens for stdin 'end' event and exits when CI!=true)
-	cmd.Env = append(cmd.Env, "FORCE_COLOR=3", "CLICOLOR_FORCE=1", "CI=true")
+	cmd.Env = append(BaseEnv(info.CleanEnv), LaunchEnv(info)...)
 
 	// stdout/stderr → logFile (survives parent exit)
 	// stdin → pipe (child gets EOF on parent exit, not EIO)
 	stdinPipe, err := process.StartDaemon(cmd, logFile)
 	if err != nil {
 		logFile.Close()
-		os.Remove(logPath)
-		return nil, fmt.Errorf("failed to start %q: %w", info.Name, err)
+		if output != nil {
+			output.Close()
+		} else {
+			os.Remove(logPath)
+		}
+		return nil, &StartError{Name: info.Name, Err: err}
+	}
+	if output != nil {
+		// The child has its own copy; ours would keep the pipe from ending
+		logFile.Close()
+		logFile = nil
 	}
 
+	startedAt := pm.clock.Now()
 	info.PID = cmd.Process.Pid
-	info.StartedAt = time.Now().Unix()
+	info.StartedAt = startedAt.Unix()
+	info.Restarts = restarts
+	info.LastRestart = 0
+	if restarts > 0 {
+		info.LastRestart = info.StartedAt
+	}
 
 	if err := SaveSession(pm.sessionsDir, info); err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "warning: failed to save session %q: %v\n", info.Name, err)
 	}
 
 	logBuf := process.NewLogBuffer(process.DefaultMaxLines)
+	if len(carry) > 0 {
+		logBuf.Write([]byte(strings.Join(carry, "\n") + "\n"))
+		logBuf.Flush()
+	}
 	tailStop := make(chan struct{})
 	done := make(chan struct{})
 
@@ -133,23 +269,71 @@ func (pm *ProcessManager) Start(info SessionInfo) (*RunningProcess, error) {
 		Cmd:       cmd,
 		LogBuf:    logBuf,
 		Status:    StatusRunning,
-		StartedAt: time.Unix(info.StartedAt, 0),
+		StartedAt: startedAt,
 		StdinPipe: stdinPipe,
 		done:      done,
 		tailStop:  tailStop,
 		logFile:   logFile,
+		Restarts:  restarts,
+		Build:     NewBuildTimer(info),
+		Ready:     NewReadyDetector(info),
 	}
+	if restarts > 0 {
+		rp.LastRestart = startedAt
+	}
+	// The initial boot is timed as the first build
+	rp.Build.Begin(startedAt)
 	pm.processes[info.Name] = rp
+	pm.notify(rp, EventStart, nil)
 
 	// Tail the log file for live output (same mechanism as reconnect)
-	go tailFile(logPath, logBuf, 0, tailStop)
+	go pm.watchBuilds(rp, logBuf.Subscribe(), tailStop)
+	tailDrained := make(chan struct{})
+	if output != nil {
+		go readPipe(pm.clock, output, logBuf, tailStop, tailDrained)
+	} else {
+		go tailFile(pm.clock, logPath, logBuf, 0, tailStop, tailDrained)
+	}
 
 	// Wait for process exit
-	go pm.waitForExit(info.Name, cmd, logFile, done, tailStop, stdinPipe)
+	go pm.waitForExit(info.Name, cmd, logFile, done, tailStop, tailDrained, stdinPipe)
 
 	return rp, nil
 }
 
+// forcedEnv is appended to every launched process environment.
+// FORCE_COLOR: colored output even though stdout is a file, not a TTY
+// CI=true: prevents Vite from calling process.exit() on stdin EOF
+// (Vite listens for stdin 'end' event and exits when CI!=true)
+var forcedEnv = []string{"FORCE_COLOR=3", "CLICOLOR_FORCE=1", "CI=true"}
+
+// noColorEnv replaces forcedEnv when devdash runs with NO_COLOR set, so
+// children follow the same convention instead of being forced into color
+var noColorEnv = []string{"NO_COLOR=1", "CI=true"}
+
+// LaunchEnv returns the variables added on top of BaseEnv when starting
+// info: the session's ExtraEnv, its size as COLUMNS/LINES when known, then
+// devdash's forced variables.
+func LaunchEnv(info SessionInfo) []string {
+	forced := forcedEnv
+	if os.Getenv("NO_COLOR") != "" {
+		forced = noColorEnv
+	}
+	env := make([]string, 0, len(info.ExtraEnv)+len(forced)+2)
+	env = append(env, info.ExtraEnv...)
+	if info.Rows > 0 && info.Cols > 0 {
+		env = append(env, fmt.Sprintf("COLUMNS=%d", info.Cols), fmt.Sprintf("LINES=%d", info.Rows))
+	}
+	return append(env, forced...)
+}
+
+// logCreateAttempts is how often start tries to create a session's log file
+// before running it without one, logCreateRetryDelay apart
+const (
+	logCreateAttempts   = 3
+	logCreateRetryDelay = 100 * time.Millisecond
+)
+
 // createLogFile ensures the logs directory exists and creates a log file.
 func (pm *ProcessManager) createLogFile(name string) (*os.File, string, error) {
 	if err := os.MkdirAll(pm.logsDir, 0o755); err != nil {
@@ -169,17 +353,21 @@ func (pm *ProcessManager) waitForExit(
 	cmd *exec.Cmd,
 	logFile *os.File,
 	done, tailStop chan struct{},
+	tailDrained <-chan struct{},
 	stdinPipe *os.File,
 ) {
 	err := cmd.Wait()
 
-	// Give tail goroutine a moment to catch up on remaining output
-	time.Sleep(200 * time.Millisecond)
+	// Let the tail goroutine read the rest of the output before the exit
+	// banner is written
 	close(tailStop)
+	<-tailDrained
 	if stdinPipe != nil {
 		stdinPipe.Close()
 	}
-	logFile.Close()
+	if logFile != nil {
+		logFile.Close()
+	}
 	close(done)
 
 	pm.mu.Lock()
@@ -192,11 +380,23 @@ func (pm *ProcessManager) waitForExit(
 
 	// Stop tunnel if process exits on its own
 	if rp.Tunnel != nil {
-		StopTunnel(rp.Tunnel)
+		StopTunnel(rp.Tunnel, pm.clock)
 		rp.Tunnel = nil
 	}
 
-	if err != nil {
+	// A line left unterminated (killed mid-print) stays a line of its own
+	rp.LogBuf.Flush()
+	if rp.Build != nil {
+		rp.Build.Cancel()
+	}
+
+	rp.ExitedAt = pm.clock.Now()
+	ran := rp.ExitedAt.Sub(rp.StartedAt)
+	if err != nil && ran < bootWindow && rp.stopDeadline.IsZero() {
+		rp.Status = StatusError
+		rp.BootFailed = true
+		rp.LogBuf.Write([]byte(fmt.Sprintf("\n[process failed to boot: exited after %.1fs: %v]\n", ran.Seconds(), err)))
+	} else if err != nil {
 		rp.Status = StatusError
 		rp.LogBuf.Write([]byte(fmt.Sprintf("\n[process exited with error: %v]\n", err)))
 	} else {
@@ -204,6 +404,15 @@ func (pm *ProcessManager) waitForExit(
 		rp.LogBuf.Write([]byte("\n[process exited normally]\n"))
 	}
 	rp.LogBuf.Flush()
+
+	// A stop from devdash reports its own event
+	if rp.stopDeadline.IsZero() {
+		event := EventStop
+		if err != nil {
+			event = EventError
+		}
+		pm.notify(rp, event, exitCode(err))
+	}
 }
 
 // Stop sends SIGTERM then SIGKILL after timeout, removes session state
@@ -214,67 +423,143 @@ func (pm *ProcessManager) Stop(name string) error {
 		pm.mu.Unlock()
 		return fmt.Errorf("process %q not found", name)
 	}
+	wasRunning := rp.Status == StatusRunning
+	signal := wasRunning && rp.Cmd != nil && rp.Cmd.Process != nil
+	timeout := stopTimeout(rp.Info)
+	if signal {
+		rp.setStopDeadline(pm.clock.Now().Add(timeout))
+	}
 	pm.mu.Unlock()
 
 	// Stop tunnel before killing the process
 	if rp.Tunnel != nil {
-		StopTunnel(rp.Tunnel)
+		StopTunnel(rp.Tunnel, pm.clock)
 		rp.Tunnel = nil
 	}
 
-	if rp.Status == StatusRunning && rp.Cmd != nil && rp.Cmd.Process != nil {
-		pm.signalStop(rp)
+	if signal {
+		pm.terminate(rp.Cmd.Process.Pid, stopSignal(rp.Info), rp.done, timeout)
 	}
 
 	pm.mu.Lock()
 	rp.Status = StatusStopped
 	delete(pm.processes, name)
+	if wasRunning {
+		pm.notify(rp, EventStop, nil)
+	}
 	pm.mu.Unlock()
 
 	_ = RemoveSession(pm.sessionsDir, name)
 	return nil
 }
 
-// signalStop sends SIGTERM to the process group, waits, then SIGKILL if needed.
-func (pm *ProcessManager) signalStop(rp *RunningProcess) {
-	pgid, err := syscall.Getpgid(rp.Cmd.Process.Pid)
-	if err == nil {
-		_ = syscall.Kill(-pgid, syscall.SIGTERM)
-	} else {
-		_ = rp.Cmd.Process.Signal(syscall.SIGTERM)
+// DefaultStopTimeout is how long Stop waits after SIGTERM before sending
+// SIGKILL, for sessions without their own StopTimeout
+const DefaultStopTimeout = 5 * time.Second
+
+// stopTimeout returns the graceful-shutdown window for a session
+func stopTimeout(info SessionInfo) time.Duration {
+	if info.StopTimeout > 0 {
+		return info.StopTimeout
 	}
+	return DefaultStopTimeout
+}
+
+// stopSignal returns the graceful stop signal for a session. Only SIGTERM and
+// SIGINT are accepted ("INT", "sigint" work too); anything else is SIGTERM.
+func stopSignal(info SessionInfo) syscall.Signal {
+	name := strings.TrimPrefix(strings.ToUpper(info.StopSignal), "SIG")
+	if name == "INT" {
+		return syscall.SIGINT
+	}
+	return syscall.SIGTERM
+}
 
+// terminate sends sig to pid's process group, waits up to timeout for
+// exited to close, then sends SIGKILL and waits for the exit. Descendants
+// that left the group get the same signals, and any descendant still alive
+// once the timeout is up is killed, so no orphan keeps holding a port.
+func (pm *ProcessManager) terminate(pid int, sig syscall.Signal, exited <-chan struct{}, timeout time.Duration) {
+	deadline := pm.clock.Now().Add(timeout)
+	tree, escaped := processTree(pm.runner, pid)
+
+	signalGroup(pid, sig)
+	signalPIDs(escaped, sig)
 	select {
-	case <-rp.done:
+	case <-exited:
 		// exited gracefully
-	case <-time.After(5 * time.Second):
-		if pgid, err := syscall.Getpgid(rp.Cmd.Process.Pid); err == nil {
-			_ = syscall.Kill(-pgid, syscall.SIGKILL)
-		} else {
-			_ = rp.Cmd.Process.Kill()
-		}
-		<-rp.done
+	case <-pm.clock.After(timeout):
+		// Pick up anything spawned during the wait
+		more, _ := processTree(pm.runner, pid)
+		tree = append(tree, more...)
+		signalGroup(pid, syscall.SIGKILL)
+		<-exited
+	}
+
+	// Give the rest of the tree what is left of the timeout, then kill it
+	for len(alivePIDs(tree)) > 0 && pm.clock.Now().Before(deadline) {
+		pm.clock.Sleep(exitPollInterval)
+	}
+	signalPIDs(alivePIDs(tree), syscall.SIGKILL)
+}
+
+// signalGroup signals pid's process group, or pid alone if it has none
+func signalGroup(pid int, sig syscall.Signal) {
+	if pgid, err := syscall.Getpgid(pid); err == nil {
+		_ = syscall.Kill(-pgid, sig)
+		return
+	}
+	if proc, err := os.FindProcess(pid); err == nil {
+		_ = proc.Signal(sig)
 	}
 }
 
-// Restart stops a process and starts it again with the same configuration
+// Restart stops a process and starts it again with the same configuration.
+// The previous log file is kept as <name>.log.prev and the tail of the old
+// output is carried into the new log buffer above a restart separator.
 func (pm *ProcessManager) Restart(name string) (*RunningProcess, error) {
+	return pm.restart(name, nil)
+}
+
+// RestartWith is Restart with an updated launch configuration, e.g. after the
+// project's detected command changed. The session keeps its name.
+func (pm *ProcessManager) RestartWith(name string, info SessionInfo) (*RunningProcess, error) {
+	info.Name = name
+	return pm.restart(name, &info)
+}
+
+// restart stops name and starts it again with next, or its current
+// configuration if next is nil
+func (pm *ProcessManager) restart(name string, next *SessionInfo) (*RunningProcess, error) {
 	pm.mu.RLock()
 	rp, exists := pm.processes[name]
 	if !exists {
 		pm.mu.RUnlock()
 		return nil, fmt.Errorf("process %q not found", name)
 	}
+	if rp.Info.Attached {
+		pm.mu.RUnlock()
+		return nil, fmt.Errorf("%q was attached by PID; devdash doesn't know how to start it", name)
+	}
 	info := rp.Info
+	if next != nil {
+		info = *next
+	}
+	restarts := rp.Restarts + 1
 	pm.mu.RUnlock()
 
 	if err := pm.Stop(name); err != nil {
 		return nil, fmt.Errorf("failed to stop %q for restart: %w", name, err)
 	}
 
-	time.Sleep(200 * time.Millisecond)
+	pm.clock.Sleep(200 * time.Millisecond)
 
-	return pm.Start(info)
+	// Read the tail after Stop so the exit message is included
+	carry := rp.LogBuf.Tail(restartCarryLines)
+	logPath := pm.logFilePath(name)
+	_ = os.Rename(logPath, logPath+".prev")
+
+	return pm.start(info, carry, restarts)
 }
 
 // WriteInput sends raw bytes to the process stdin
@@ -289,22 +574,25 @@ func (pm *ProcessManager) WriteInput(name string, data []byte) error {
 	return err
 }
 
-// ResizePTY changes the terminal window size for a process (no-op for daemon processes)
+// ResizePTY records the window size a process is shown at, used for its next
+// restart, and resizes its virtual terminal if it has one
 func (pm *ProcessManager) ResizePTY(name string, rows, cols uint16) error {
-	pm.mu.RLock()
-	defer pm.mu.RUnlock()
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
 	rp := pm.processes[name]
 	if rp == nil {
 		return nil
 	}
+	rp.Info.Rows, rp.Info.Cols = int(rows), int(cols)
 	if rp.VTerm != nil {
 		rp.VTerm.Resize(int(rows), int(cols))
 	}
 	return nil
 }
 
-// StartTunnel opens a Cloudflare Quick Tunnel for a running process
-func (pm *ProcessManager) StartTunnel(name string) (*TunnelInfo, error) {
+// StartTunnel opens a Cloudflare Quick Tunnel for a running process, to its
+// port on origin
+func (pm *ProcessManager) StartTunnel(name string, origin TunnelOrigin) (*TunnelInfo, error) {
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
 
@@ -319,7 +607,7 @@ func (pm *ProcessManager) StartTunnel(name string) (*TunnelInfo, error) {
 		return nil, fmt.Errorf("tunnel already active for %q", name)
 	}
 
-	ti, err := StartTunnel(rp.Info.Port)
+	ti, err := StartTunnel(rp.Info.Port, origin)
 	if err != nil {
 		return nil, err
 	}
@@ -327,6 +615,19 @@ func (pm *ProcessManager) StartTunnel(name string) (*TunnelInfo, error) {
 	return ti, nil
 }
 
+// TunnelReady records the URL of a tunnel that finished starting and reports
+// it to the webhook
+func (pm *ProcessManager) TunnelReady(name string, ti *TunnelInfo, url string) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	ti.Status = TunnelActive
+	ti.URL = url
+	if rp, exists := pm.processes[name]; exists && rp.Tunnel == ti {
+		pm.notify(rp, EventTunnel, nil)
+	}
+}
+
 // StopProcessTunnel stops the Cloudflare tunnel for a process
 func (pm *ProcessManager) StopProcessTunnel(name string) error {
 	pm.mu.Lock()
@@ -340,7 +641,7 @@ func (pm *ProcessManager) StopProcessTunnel(name string) error {
 		return fmt.Errorf("no tunnel for %q", name)
 	}
 
-	StopTunnel(rp.Tunnel)
+	StopTunnel(rp.Tunnel, pm.clock)
 	rp.Tunnel = nil
 	return nil
 }
@@ -357,6 +658,20 @@ func (pm *ProcessManager) List() []*RunningProcess {
 	return result
 }
 
+// FlushAll completes the partial last line of every session's log buffer, so
+// a prompt or progress line still waiting for its newline is captured by
+// copies and snapshots
+func (pm *ProcessManager) FlushAll() {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	for _, rp := range pm.processes {
+		if rp.LogBuf != nil {
+			rp.LogBuf.Flush()
+		}
+	}
+}
+
 // Get returns a single process by name, or nil if not found
 func (pm *ProcessManager) Get(name string) *RunningProcess {
 	pm.mu.RLock()
@@ -364,3 +679,15 @@ func (pm *ProcessManager) Get(name string) *RunningProcess {
 	return pm.processes[name]
 }
 
+// Info returns a copy of a process's session info, taken under the lock so
+// it doesn't race with ResizePTY or a restart
+func (pm *ProcessManager) Info(name string) (SessionInfo, bool) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	rp := pm.processes[name]
+	if rp == nil {
+		return SessionInfo{}, false
+	}
+	return rp.Info, true
+}
+
@@ -1,8 +1,10 @@
 package devdash
 
 import (
+	"errors"
 	"os"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
@@ -21,7 +23,7 @@ func TestTailFileWritesToLogBuffer(t *testing.T) {
 	stop := make(chan struct{})
 
 	// Start tailing (same as Start() does now)
-	go tailFile(logFile.Name(), logBuf, 0, stop)
+	go tailFile(SystemClock, logFile.Name(), logBuf, 0, stop, nil)
 
 	// Simulate child process writing to log file
 	logFile.Write([]byte("hello world\n"))
@@ -36,3 +38,368 @@ func TestTailFileWritesToLogBuffer(t *testing.T) {
 		t.Errorf("LogBuffer should contain 'hello world', got: %q", content)
 	}
 }
+
+func TestRestartKeepsPreviousLog(t *testing.T) {
+	pm := NewProcessManager(t.TempDir(), t.TempDir())
+
+	rp, err := pm.Start(SessionInfo{
+		Name:    "crashy",
+		Command: "sh",
+		Args:    []string{"-c", "echo first run output; exit 1"},
+		WorkDir: t.TempDir(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-rp.Done()
+
+	rp, err = pm.Restart("crashy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-rp.Done()
+	defer pm.Stop("crashy")
+
+	content := rp.LogBuf.Content()
+	for _, want := range []string{"first run output", "[=== restart 1 at "} {
+		if !strings.Contains(content, want) {
+			t.Errorf("log buffer missing %q, got:\n%s", want, content)
+		}
+	}
+	if rp.Restarts != 1 {
+		t.Errorf("Restarts = %d, want 1", rp.Restarts)
+	}
+	if rp.LastRestart.IsZero() || rp.Info.Restarts != 1 || rp.Info.LastRestart != rp.LastRestart.Unix() {
+		t.Errorf("restart not recorded for the session file: LastRestart %v, Info %+v", rp.LastRestart, rp.Info)
+	}
+
+	prev, err := os.ReadFile(pm.logFilePath("crashy") + ".prev")
+	if err != nil {
+		t.Fatalf("previous log not kept: %v", err)
+	}
+	if !strings.Contains(string(prev), "first run output") {
+		t.Errorf("previous log missing output: %q", prev)
+	}
+}
+
+func TestRestartWithNewCommand(t *testing.T) {
+	pm := NewProcessManager(t.TempDir(), t.TempDir())
+
+	rp, err := pm.Start(SessionInfo{Name: "web", Command: "sh", Args: []string{"-c", "echo old"}, WorkDir: t.TempDir(), Port: 3000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-rp.Done()
+
+	rp, err = pm.RestartWith("web", SessionInfo{Name: "ignored", Command: "sh", Args: []string{"-c", "echo new"}, WorkDir: t.TempDir(), Port: 4000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-rp.Done()
+	defer pm.Stop("web")
+
+	if rp.Info.Name != "web" || rp.Info.Port != 4000 || rp.Restarts != 1 {
+		t.Errorf("restarted info = %+v (restarts %d)", rp.Info, rp.Restarts)
+	}
+	if content := rp.LogBuf.Content(); !strings.Contains(content, "new") {
+		t.Errorf("new command didn't run, got:\n%s", content)
+	}
+}
+
+func TestLaunchEnv_NoColor(t *testing.T) {
+	info := SessionInfo{ExtraEnv: []string{"PORT=4000"}}
+
+	t.Setenv("NO_COLOR", "")
+	env := strings.Join(LaunchEnv(info), " ")
+	if !strings.Contains(env, "FORCE_COLOR=3") || !strings.HasPrefix(env, "PORT=4000") {
+		t.Errorf("default env = %q", env)
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	env = strings.Join(LaunchEnv(info), " ")
+	if strings.Contains(env, "FORCE_COLOR") || !strings.Contains(env, "NO_COLOR=1") {
+		t.Errorf("NO_COLOR env = %q", env)
+	}
+}
+
+func TestLaunchEnv_TerminalSize(t *testing.T) {
+	env := strings.Join(LaunchEnv(SessionInfo{Rows: 40, Cols: 132}), " ")
+	if !strings.Contains(env, "COLUMNS=132 LINES=40") {
+		t.Errorf("sized env = %q", env)
+	}
+	if env := strings.Join(LaunchEnv(SessionInfo{}), " "); strings.Contains(env, "COLUMNS") {
+		t.Errorf("unsized env should leave COLUMNS alone, got %q", env)
+	}
+}
+
+func TestStopHonorsStopTimeout(t *testing.T) {
+	pm := NewProcessManager(t.TempDir(), t.TempDir())
+
+	// Ignores SIGTERM, so Stop has to escalate to SIGKILL after the timeout
+	_, err := pm.Start(SessionInfo{
+		Name:        "stubborn",
+		Command:     "sh",
+		Args:        []string{"-c", "trap '' TERM; echo ready; while true; do sleep 0.1; done"},
+		WorkDir:     t.TempDir(),
+		StopTimeout: 300 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	start := time.Now()
+	if err := pm.Stop("stubborn"); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 300*time.Millisecond || elapsed > 3*time.Second {
+		t.Errorf("Stop took %v, want about 300ms", elapsed)
+	}
+}
+
+func TestStopSignal(t *testing.T) {
+	tests := map[string]syscall.Signal{
+		"":        syscall.SIGTERM,
+		"SIGTERM": syscall.SIGTERM,
+		"SIGINT":  syscall.SIGINT,
+		"int":     syscall.SIGINT,
+		"SIGHUP":  syscall.SIGTERM,
+	}
+	for name, want := range tests {
+		if got := stopSignal(SessionInfo{StopSignal: name}); got != want {
+			t.Errorf("stopSignal(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestStopSendsSIGINT(t *testing.T) {
+	pm := NewProcessManager(t.TempDir(), t.TempDir())
+
+	// Only exits on SIGINT; a SIGTERM would wait out the whole timeout
+	_, err := pm.Start(SessionInfo{
+		Name:        "int-only",
+		Command:     "sh",
+		Args:        []string{"-c", "trap '' TERM; trap 'exit 0' INT; while true; do sleep 0.1; done"},
+		WorkDir:     t.TempDir(),
+		StopTimeout: 10 * time.Second,
+		StopSignal:  "SIGINT",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	start := time.Now()
+	if err := pm.Stop("int-only"); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Stop took %v; SIGINT was not delivered", elapsed)
+	}
+}
+
+func TestStartFailuresAreDistinct(t *testing.T) {
+	pm := NewProcessManager(t.TempDir(), t.TempDir())
+
+	_, err := pm.Start(SessionInfo{Name: "missing", Command: "/nonexistent/devdash-test-binary", WorkDir: t.TempDir()})
+	var startErr *StartError
+	if !errors.As(err, &startErr) || startErr.Name != "missing" {
+		t.Fatalf("missing binary: got %v, want a StartError", err)
+	}
+
+	rp, err := pm.Start(SessionInfo{Name: "boot", Command: "sh", Args: []string{"-c", "echo cannot find module >&2; exit 1"}, WorkDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pm.Stop("boot")
+	<-rp.Done()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		pm.mu.RLock()
+		status, bootFailed := rp.Status, rp.BootFailed
+		pm.mu.RUnlock()
+		if status == StatusError {
+			if !bootFailed {
+				t.Error("an immediate error exit should be marked as a failed boot")
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("status = %v, want error", status)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if content := rp.LogBuf.Content(); !strings.Contains(content, "[process failed to boot") {
+		t.Errorf("log should say the process failed to boot, got:\n%s", content)
+	}
+}
+
+// TestPartialLineBeforeExitBanner kills a process right after it prints an
+// unterminated line, before the tail has polled it, and checks the line is
+// kept whole with the exit banner on a line of its own after it.
+func TestPartialLineBeforeExitBanner(t *testing.T) {
+	pm := NewProcessManager(t.TempDir(), t.TempDir())
+	rp, err := pm.Start(SessionInfo{
+		Name:    "cut",
+		Command: "sh",
+		Args:    []string{"-c", "printf 'half a line'; kill -9 $$"},
+		WorkDir: t.TempDir(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-rp.Done()
+	waitForLog(t, rp, "[process")
+
+	lines := rp.LogBuf.Lines()
+	partial, banner := -1, -1
+	for i, line := range lines {
+		if line == "half a line" {
+			partial = i
+		}
+		if strings.HasPrefix(line, "[process") {
+			banner = i
+		}
+	}
+	if partial < 0 || banner < partial {
+		t.Errorf("want the partial line whole, then the banner on its own line; got %q", lines)
+	}
+}
+
+func TestFlushAll(t *testing.T) {
+	pm := NewProcessManager(t.TempDir(), t.TempDir())
+	prompt := process.NewLogBuffer(10)
+	_, _ = prompt.Write([]byte("done\nContinue? [y/N] "))
+	pm.processes["prompt"] = &RunningProcess{LogBuf: prompt}
+	pm.processes["nobuf"] = &RunningProcess{}
+
+	pm.FlushAll()
+
+	lines := prompt.Lines()
+	if len(lines) != 2 || lines[1] != "Continue? [y/N] " {
+		t.Errorf("want the prompt flushed as its own line, got %q", lines)
+	}
+}
+
+func TestStartWithoutLogFile(t *testing.T) {
+	// A regular file where the logs directory should be makes every attempt fail
+	logsDir := t.TempDir() + "/logs"
+	if err := os.WriteFile(logsDir, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	pm := NewProcessManager(t.TempDir(), logsDir)
+	pm.SetClock(&fakeClock{})
+
+	rp, err := pm.Start(SessionInfo{
+		Name:    "memory-only",
+		Command: "sh",
+		Args:    []string{"-c", "echo still running"},
+		WorkDir: t.TempDir(),
+	})
+	if err != nil {
+		t.Fatalf("Start without a log file: %v", err)
+	}
+	<-rp.Done()
+	waitForLog(t, rp, "[process exited normally]")
+
+	if !rp.Info.NoLog {
+		t.Error("Info.NoLog = false for a session without a log file")
+	}
+	content := rp.LogBuf.Content()
+	for _, want := range []string{"[no persisted log:", "still running"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("log buffer missing %q:\n%s", want, content)
+		}
+	}
+}
+
+// sleepHookClock is a fakeClock that runs onSleep at every Sleep
+type sleepHookClock struct {
+	fakeClock
+	onSleep func()
+}
+
+func (c *sleepHookClock) Sleep(d time.Duration) {
+	c.onSleep()
+	c.fakeClock.Sleep(d)
+}
+
+func TestStartLogRetryDoesNotHoldLock(t *testing.T) {
+	logsDir := t.TempDir() + "/logs"
+	if err := os.WriteFile(logsDir, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	pm := NewProcessManager(t.TempDir(), logsDir)
+	sleeps := 0
+	pm.SetClock(&sleepHookClock{onSleep: func() {
+		sleeps++
+		listed := make(chan struct{})
+		go func() {
+			pm.List()
+			close(listed)
+		}()
+		select {
+		case <-listed:
+		case <-time.After(2 * time.Second):
+			t.Error("List blocked while start waited to retry the log file")
+		}
+	}})
+
+	rp, err := pm.Start(SessionInfo{Name: "memory-only", Command: "true", WorkDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-rp.Done()
+	if sleeps != logCreateAttempts-1 {
+		t.Errorf("start waited %d times, want %d", sleeps, logCreateAttempts-1)
+	}
+}
+
+func TestStartLogRetryRechecksElsewhere(t *testing.T) {
+	logsDir := t.TempDir() + "/logs"
+	if err := os.WriteFile(logsDir, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sessionsDir := t.TempDir()
+	pm := NewProcessManager(sessionsDir, logsDir)
+	pm.SetClock(&sleepHookClock{onSleep: func() {
+		// Another devdash starts the same session while this one waits
+		_ = SaveSession(sessionsDir, SessionInfo{Name: "api", PID: os.Getpid()})
+	}})
+
+	_, err := pm.Start(SessionInfo{Name: "api", Command: "true", WorkDir: t.TempDir()})
+	var elsewhere *RunningElsewhereError
+	if !errors.As(err, &elsewhere) {
+		t.Fatalf("err = %v, want RunningElsewhereError", err)
+	}
+	if pm.Get("api") != nil {
+		t.Error("the session shouldn't be started here too")
+	}
+}
+
+func TestInfoCopiesUnderLock(t *testing.T) {
+	pm := NewProcessManager(t.TempDir(), t.TempDir())
+	pm.processes["web"] = &RunningProcess{Info: SessionInfo{Name: "web", Rows: 24, Cols: 80}}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			_ = pm.ResizePTY("web", uint16(30+i%2), 100)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		if info, ok := pm.Info("web"); !ok || info.Name != "web" {
+			t.Fatalf("Info(web) = %+v, %v", info, ok)
+		}
+	}
+	<-done
+
+	if info, _ := pm.Info("web"); info.Rows != 31 || info.Cols != 100 {
+		t.Errorf("size = %dx%d, want 31x100", info.Rows, info.Cols)
+	}
+	if _, ok := pm.Info("missing"); ok {
+		t.Error("Info of an unknown session should report false")
+	}
+}
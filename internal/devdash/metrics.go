@@ -0,0 +1,123 @@
+package devdash
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// metricsContentType is the Prometheus text exposition format
+const metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// metricsSample is one session's state, copied under pm.mu
+type metricsSample struct {
+	name      string
+	status    ProcessStatus
+	startedAt time.Time
+	restarts  int
+	logLines  int
+}
+
+// metricsSamples snapshots every session, sorted by name
+func (pm *ProcessManager) metricsSamples() []metricsSample {
+	pm.mu.RLock()
+	samples := make([]metricsSample, 0, len(pm.processes))
+	for _, rp := range pm.processes {
+		s := metricsSample{name: rp.Info.Name, status: rp.Status, startedAt: rp.StartedAt, restarts: rp.Restarts}
+		if rp.LogBuf != nil {
+			s.logLines = rp.LogBuf.Appended()
+		}
+		samples = append(samples, s)
+	}
+	pm.mu.RUnlock()
+
+	sort.Slice(samples, func(i, j int) bool { return samples[i].name < samples[j].name })
+	return samples
+}
+
+// WriteMetrics writes per-session gauges and counters in Prometheus text
+// format, one sample per session, sorted by session name
+func (pm *ProcessManager) WriteMetrics(w io.Writer, now time.Time) error {
+	samples := pm.metricsSamples()
+
+	metrics := []struct {
+		name, kind, help string
+		value            func(s metricsSample) float64
+	}{
+		{"devdash_up", "gauge", "Whether the session's process is running (1) or not (0).", func(s metricsSample) float64 {
+			if s.status == StatusRunning {
+				return 1
+			}
+			return 0
+		}},
+		{"devdash_uptime_seconds", "gauge", "Seconds since the session's process started; 0 when not running.", func(s metricsSample) float64 {
+			if s.status != StatusRunning || s.startedAt.IsZero() {
+				return 0
+			}
+			return now.Sub(s.startedAt).Seconds()
+		}},
+		{"devdash_restarts_total", "counter", "Times the session was restarted from devdash.", func(s metricsSample) float64 {
+			return float64(s.restarts)
+		}},
+		{"devdash_log_lines_total", "counter", "Log lines captured for the session since devdash attached to it.", func(s metricsSample) float64 {
+			return float64(s.logLines)
+		}},
+	}
+
+	var b strings.Builder
+	for _, m := range metrics {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", m.name, m.help, m.name, m.kind)
+		for _, s := range samples {
+			fmt.Fprintf(&b, "%s{session=\"%s\"} %g\n", m.name, escapeLabel(s.name), m.value(s))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// escapeLabel escapes a Prometheus label value: backslash, quote and newline
+func escapeLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// MetricsAddr normalizes a metrics bind address. A bare port or ":port"
+// binds to localhost; an explicit host (e.g. 0.0.0.0 or [::1]) is kept.
+// Anything that is neither host:port nor a port is returned as is, for
+// net.Listen to reject.
+func MetricsAddr(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		if _, convErr := strconv.Atoi(addr); convErr != nil {
+			return addr
+		}
+		host, port = "", addr
+	}
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// ServeMetrics listens on addr (see MetricsAddr) and serves /metrics in the
+// background. Listen errors, like a port already in use, are returned
+// immediately. Close the returned server to stop it.
+func (pm *ProcessManager) ServeMetrics(addr string) (*http.Server, error) {
+	ln, err := net.Listen("tcp", MetricsAddr(addr))
+	if err != nil {
+		return nil, fmt.Errorf("metrics: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", metricsContentType)
+		_ = pm.WriteMetrics(w, pm.clock.Now())
+	})
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	go func() { _ = srv.Serve(ln) }()
+	return srv, nil
+}
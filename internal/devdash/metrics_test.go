@@ -0,0 +1,120 @@
+package devdash
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kimaguri/simplx-toolkit/internal/process"
+)
+
+func TestWriteMetrics(t *testing.T) {
+	pm := NewProcessManager(t.TempDir(), t.TempDir())
+	now := time.Now()
+
+	buf := process.NewLogBuffer(10)
+	buf.Write([]byte("one\ntwo\nthree\n"))
+	pm.processes["web"] = &RunningProcess{
+		Info:      SessionInfo{Name: "web"},
+		LogBuf:    buf,
+		Status:    StatusRunning,
+		StartedAt: now.Add(-90 * time.Second),
+		Restarts:  2,
+	}
+	pm.processes[`api"x`] = &RunningProcess{
+		Info:   SessionInfo{Name: `api"x`},
+		LogBuf: process.NewLogBuffer(10),
+		Status: StatusError,
+	}
+
+	var out strings.Builder
+	if err := pm.WriteMetrics(&out, now); err != nil {
+		t.Fatal(err)
+	}
+	got := out.String()
+	for _, want := range []string{
+		"# TYPE devdash_up gauge\n",
+		`devdash_up{session="api\"x"} 0` + "\n" + `devdash_up{session="web"} 1`,
+		`devdash_uptime_seconds{session="web"} 90`,
+		`devdash_uptime_seconds{session="api\"x"} 0`,
+		`devdash_restarts_total{session="web"} 2`,
+		`devdash_log_lines_total{session="web"} 3`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("metrics missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestWriteMetricsConcurrentRestart(t *testing.T) {
+	pm := NewProcessManager(t.TempDir(), t.TempDir())
+	rp := &RunningProcess{Info: SessionInfo{Name: "web"}, Status: StatusRunning}
+	pm.processes["web"] = rp
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			pm.mu.Lock()
+			rp.Restarts++
+			rp.StartedAt = time.Now()
+			pm.mu.Unlock()
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		if err := pm.WriteMetrics(io.Discard, time.Now()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	<-done
+}
+
+func TestMetricsAddr(t *testing.T) {
+	tests := map[string]string{
+		"9273":         "127.0.0.1:9273",
+		":9273":        "127.0.0.1:9273",
+		"0.0.0.0:9273": "0.0.0.0:9273",
+		"[::1]:9273":   "[::1]:9273",
+		"localhost:80": "localhost:80",
+		"::1":          "::1",
+	}
+	for in, want := range tests {
+		if got := MetricsAddr(in); got != want {
+			t.Errorf("MetricsAddr(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestServeMetrics(t *testing.T) {
+	// Grab a free port, then hand it to ServeMetrics
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	pm := NewProcessManager(t.TempDir(), t.TempDir())
+	srv, err := pm.ServeMetrics(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	if _, err := pm.ServeMetrics(addr); err == nil {
+		t.Error("second listen on the same address should fail")
+	}
+
+	resp, err := http.Get("http://" + addr + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/plain") || !strings.Contains(string(body), "# TYPE devdash_up gauge") {
+		t.Errorf("unexpected response %q: %s", resp.Header.Get("Content-Type"), body)
+	}
+}
@@ -0,0 +1,236 @@
+package devdash
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// PortHolder is a process listening on a TCP port
+type PortHolder struct {
+	PID         int
+	Command     string // executable name, e.g. "node"
+	CommandLine string // full command line, e.g. "node server.js --port 3000"; "" if unknown
+	Dir         string // working directory; "" if unknown
+	Session     string // the devdash session it belongs to; "" if none
+}
+
+// String formats the holder for display: `node (PID 1234)`, or
+// `node (PID 1234, session web)` for one of devdash's own
+func (h PortHolder) String() string {
+	if h.Session != "" {
+		return fmt.Sprintf("%s (PID %d, session %s)", h.Command, h.PID, h.Session)
+	}
+	return fmt.Sprintf("%s (PID %d)", h.Command, h.PID)
+}
+
+// PortHolders returns the processes listening on port, with their command
+// lines and working directories so the user can tell what they'd be killing.
+// Holders that are a running session or one of its descendants are marked
+// with the session's name. Uses /proc on Linux and lsof/ps elsewhere.
+// Processes owned by other users may not be visible. Blocks on ps and lsof,
+// so keep it off the UI goroutine.
+func (pm *ProcessManager) PortHolders(port int) []PortHolder {
+	holders, ok := procPortHolders(pm.runner, port)
+	if !ok {
+		holders = lsofPortHolders(pm.runner, port)
+	}
+	if len(holders) == 0 {
+		return holders
+	}
+	sessions := pm.sessionPIDs()
+	for i := range holders {
+		holders[i].CommandLine = processCommandLine(pm.runner, holders[i].PID)
+		holders[i].Dir = processDir(pm.runner, holders[i].PID)
+		holders[i].Session = sessions[holders[i].PID]
+	}
+	return holders
+}
+
+// sessionPIDs maps the PIDs of running sessions and their descendants to
+// the session's name
+func (pm *ProcessManager) sessionPIDs() map[int]string {
+	roots := make(map[int]string)
+	pm.mu.RLock()
+	for name, rp := range pm.processes {
+		if rp.Status == StatusRunning && rp.Info.PID > 0 {
+			roots[rp.Info.PID] = name
+		}
+	}
+	pm.mu.RUnlock()
+
+	pids := make(map[int]string)
+	if len(roots) == 0 {
+		return pids
+	}
+	tree := childrenByParent(pm.runner)
+	for root, name := range roots {
+		pids[root] = name
+		for _, p := range descendants(tree, root) {
+			pids[p] = name
+		}
+	}
+	return pids
+}
+
+// processCommandLine returns pid's arguments joined by spaces; "" if unknown
+func processCommandLine(r Runner, pid int) string {
+	if data, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "cmdline")); err == nil {
+		return strings.Join(strings.Fields(strings.ReplaceAll(string(data), "\x00", " ")), " ")
+	}
+	out, err := r.Output("ps", "-o", "command=", "-p", strconv.Itoa(pid))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// processDir returns pid's working directory; "" if unknown
+func processDir(r Runner, pid int) string {
+	if dir, err := os.Readlink(filepath.Join("/proc", strconv.Itoa(pid), "cwd")); err == nil {
+		return dir
+	}
+	// lsof -Fn prints the cwd as an `n<path>` line
+	out, err := r.Output("lsof", "-a", "-p", strconv.Itoa(pid), "-d", "cwd", "-Fn")
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		if strings.HasPrefix(line, "n") {
+			return line[1:]
+		}
+	}
+	return ""
+}
+
+// tcpListenState is the LISTEN state code in /proc/net/tcp
+const tcpListenState = "0A"
+
+// procPortHolders finds listening socket inodes for port in /proc/net/tcp{,6}
+// and maps them to PIDs through /proc/<pid>/fd
+func procPortHolders(r Runner, port int) ([]PortHolder, bool) {
+	inodes := make(map[string]bool)
+	found := false
+	for _, name := range []string{"/proc/net/tcp", "/proc/net/tcp6"} {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			continue
+		}
+		found = true
+		for _, inode := range listeningInodes(string(data), port) {
+			inodes[inode] = true
+		}
+	}
+	if !found {
+		return nil, false
+	}
+	if len(inodes) == 0 {
+		return nil, true
+	}
+
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, true
+	}
+	var holders []PortHolder
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+		fdDir := filepath.Join("/proc", e.Name(), "fd")
+		fds, err := os.ReadDir(fdDir)
+		if err != nil {
+			continue // other user's process or exited
+		}
+		for _, fd := range fds {
+			link, err := os.Readlink(filepath.Join(fdDir, fd.Name()))
+			if err != nil || !strings.HasPrefix(link, "socket:[") {
+				continue
+			}
+			if inodes[strings.TrimSuffix(strings.TrimPrefix(link, "socket:["), "]")] {
+				holders = append(holders, PortHolder{PID: pid, Command: processName(r, pid)})
+				break
+			}
+		}
+	}
+	sort.Slice(holders, func(i, j int) bool { return holders[i].PID < holders[j].PID })
+	return holders, true
+}
+
+// listeningInodes returns the socket inodes in a /proc/net/tcp table that
+// listen on port. Rows look like:
+// `0: 00000000:0FA0 00000000:0000 0A ... uid timeout inode ...`
+func listeningInodes(table string, port int) []string {
+	var inodes []string
+	sc := bufio.NewScanner(strings.NewReader(table))
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) < 10 || fields[3] != tcpListenState {
+			continue // header or not listening
+		}
+		i := strings.LastIndexByte(fields[1], ':')
+		if i < 0 {
+			continue
+		}
+		p, err := strconv.ParseInt(fields[1][i+1:], 16, 32)
+		if err != nil || int(p) != port {
+			continue
+		}
+		inodes = append(inodes, fields[9])
+	}
+	return inodes
+}
+
+// lsofPortHolders asks lsof for listeners on port (macOS)
+func lsofPortHolders(r Runner, port int) []PortHolder {
+	out, err := r.Output("lsof", "-nP", fmt.Sprintf("-iTCP:%d", port), "-sTCP:LISTEN", "-Fpc")
+	if err != nil {
+		return nil // lsof exits 1 when nothing matches
+	}
+	return parseLsof(out)
+}
+
+// parseLsof parses `lsof -Fpc` output: a `p<pid>` line followed by `c<command>`
+func parseLsof(out []byte) []PortHolder {
+	var holders []PortHolder
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		line := sc.Text()
+		if line == "" {
+			continue
+		}
+		switch line[0] {
+		case 'p':
+			if pid, err := strconv.Atoi(line[1:]); err == nil {
+				holders = append(holders, PortHolder{PID: pid})
+			}
+		case 'c':
+			if len(holders) > 0 {
+				holders[len(holders)-1].Command = line[1:]
+			}
+		}
+	}
+	return holders
+}
+
+// KillPID stops a single process that devdash didn't start: SIGTERM, then
+// SIGKILL after DefaultStopTimeout. Only pid itself is signaled — its process
+// group may be a shell or another tool the user still needs.
+func (pm *ProcessManager) KillPID(pid int) error {
+	if err := syscall.Kill(pid, syscall.SIGTERM); err != nil {
+		return fmt.Errorf("kill %d: %w", pid, err)
+	}
+	select {
+	case <-pm.watchExit(pid):
+	case <-pm.clock.After(DefaultStopTimeout):
+		_ = syscall.Kill(pid, syscall.SIGKILL)
+	}
+	return nil
+}
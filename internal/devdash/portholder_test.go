@@ -0,0 +1,80 @@
+package devdash
+
+import (
+	"net"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestListeningInodes(t *testing.T) {
+	table := `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000:0FA0 00000000:0000 0A 00000000:00000000 00:00000000 00000000  1000        0 11111 1 0000000000000000 100 0 0 10 0
+   1: 0100007F:0FA0 0100007F:D431 01 00000000:00000000 00:00000000 00000000  1000        0 22222 1 0000000000000000 20 4 30 10 -1
+   2: 0100007F:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000  1000        0 33333 1 0000000000000000 100 0 0 10 0
+`
+	// 0x0FA0 = 4000; the established connection on row 1 must not count
+	if got := listeningInodes(table, 4000); !reflect.DeepEqual(got, []string{"11111"}) {
+		t.Errorf("listeningInodes(4000) = %v", got)
+	}
+	if got := listeningInodes(table, 8080); !reflect.DeepEqual(got, []string{"33333"}) {
+		t.Errorf("listeningInodes(8080) = %v", got)
+	}
+}
+
+func TestParseLsof(t *testing.T) {
+	out := []byte("p123\ncnode\np456\ncvite\n")
+	want := []PortHolder{{PID: 123, Command: "node"}, {PID: 456, Command: "vite"}}
+	if got := parseLsof(out); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseLsof = %v, want %v", got, want)
+	}
+}
+
+func TestPortHolders_FindsListener(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip("cannot listen:", err)
+	}
+	defer ln.Close()
+	port := ln.Addr().(*net.TCPAddr).Port
+
+	holders := NewProcessManager(t.TempDir(), t.TempDir()).PortHolders(port)
+	for _, h := range holders {
+		if h.PID == os.Getpid() {
+			wd, _ := os.Getwd()
+			if h.CommandLine == "" || h.Dir != wd {
+				t.Errorf("holder details: command line %q, dir %q (want %q)", h.CommandLine, h.Dir, wd)
+			}
+			return
+		}
+	}
+	if len(holders) == 0 {
+		t.Skip("no /proc or lsof available")
+	}
+	t.Errorf("PortHolders(%d) = %v, want our PID %d", port, holders, os.Getpid())
+}
+
+func TestSessionPIDsCoverDescendants(t *testing.T) {
+	pm := NewProcessManager(t.TempDir(), t.TempDir())
+	rp, err := pm.Start(SessionInfo{Name: "web", Command: "sh", Args: []string{"-c", "sleep 30 & wait"}, WorkDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = pm.Stop("web") })
+
+	// Wait for the shell to fork its child
+	var pids map[int]string
+	for i := 0; i < 50 && len(pids) < 2; i++ {
+		time.Sleep(20 * time.Millisecond)
+		pids = pm.sessionPIDs()
+	}
+	if pids[rp.Info.PID] != "web" || len(pids) < 2 {
+		t.Fatalf("sessionPIDs = %v, want PID %d and its child under web", pids, rp.Info.PID)
+	}
+	for pid, name := range pids {
+		if name != "web" {
+			t.Errorf("PID %d mapped to %q", pid, name)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package devdash
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// childrenByParent maps each PID to the PIDs of its direct children.
+// Reads /proc where available (Linux) and falls back to ps (macOS).
+func childrenByParent(r Runner) map[int][]int {
+	if tree, ok := procChildren(); ok {
+		return tree
+	}
+	return psChildren(r)
+}
+
+// procChildren builds the parent→children map from /proc/<pid>/stat
+func procChildren() (map[int][]int, bool) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, false
+	}
+	tree := make(map[int][]int)
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join("/proc", e.Name(), "stat"))
+		if err != nil {
+			continue // exited while scanning
+		}
+		if ppid, ok := parseStatPPID(string(data)); ok {
+			tree[ppid] = append(tree[ppid], pid)
+		}
+	}
+	return tree, true
+}
+
+// parseStatPPID extracts the parent PID from a /proc/<pid>/stat line:
+// `pid (comm) state ppid ...`. comm may contain spaces and parens, so
+// fields are read after the last ')'.
+func parseStatPPID(stat string) (int, bool) {
+	i := strings.LastIndexByte(stat, ')')
+	if i < 0 {
+		return 0, false
+	}
+	fields := strings.Fields(stat[i+1:])
+	if len(fields) < 2 {
+		return 0, false
+	}
+	ppid, err := strconv.Atoi(fields[1])
+	return ppid, err == nil
+}
+
+// psChildren builds the parent→children map from `ps -A -o pid=,ppid=`
+func psChildren(r Runner) map[int][]int {
+	tree := make(map[int][]int)
+	out, err := r.Output("ps", "-A", "-o", "pid=,ppid=")
+	if err != nil {
+		return tree
+	}
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		pid, err1 := strconv.Atoi(fields[0])
+		ppid, err2 := strconv.Atoi(fields[1])
+		if err1 == nil && err2 == nil {
+			tree[ppid] = append(tree[ppid], pid)
+		}
+	}
+	return tree
+}
+
+// descendants returns every PID below pid in tree (children, grandchildren, ...)
+func descendants(tree map[int][]int, pid int) []int {
+	var out []int
+	queue := append([]int(nil), tree[pid]...)
+	seen := map[int]bool{pid: true}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		out = append(out, p)
+		queue = append(queue, tree[p]...)
+	}
+	return out
+}
+
+// processTree returns all descendants of pid, and the subset that left its
+// process group — the ones a group signal misses (e.g. a dev server that
+// called setsid). Must be called while pid is alive: once it exits, its
+// children are reparented and can't be traced back to it.
+func processTree(r Runner, pid int) (all, escaped []int) {
+	all = descendants(childrenByParent(r), pid)
+	pgid, err := syscall.Getpgid(pid)
+	if err != nil {
+		return all, nil
+	}
+	for _, p := range all {
+		if g, err := syscall.Getpgid(p); err == nil && g != pgid {
+			escaped = append(escaped, p)
+		}
+	}
+	return all, escaped
+}
+
+// signalPIDs sends sig to each PID; PIDs that are already gone are ignored
+func signalPIDs(pids []int, sig syscall.Signal) {
+	for _, p := range pids {
+		_ = syscall.Kill(p, sig)
+	}
+}
+
+// alivePIDs returns the PIDs from pids that are still running. Zombies
+// (exited, waiting for a reaper) don't count.
+func alivePIDs(pids []int) []int {
+	var out []int
+	for _, p := range pids {
+		if IsProcessAlive(p) && !isZombie(p) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// isZombie reports whether pid has exited but not been reaped. Only
+// detectable through /proc; elsewhere it reports false.
+func isZombie(pid int) bool {
+	data, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return false
+	}
+	stat := string(data)
+	i := strings.LastIndexByte(stat, ')')
+	return i >= 0 && strings.HasPrefix(strings.TrimSpace(stat[i+1:]), "Z")
+}
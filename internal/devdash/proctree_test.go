@@ -0,0 +1,78 @@
+package devdash
+
+import (
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseStatPPID(t *testing.T) {
+	tests := []struct {
+		stat string
+		want int
+		ok   bool
+	}{
+		{"1234 (node) S 1200 1234 1234 0 -1", 1200, true},
+		{"1234 (my (weird) proc) R 99 1 1", 99, true},
+		{"garbage", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseStatPPID(tt.stat)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseStatPPID(%q) = %d, %v; want %d, %v", tt.stat, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestDescendants(t *testing.T) {
+	tree := map[int][]int{1: {2, 3}, 2: {4}, 4: {5}, 9: {10}}
+	got := descendants(tree, 1)
+	sort.Ints(got)
+	if want := []int{2, 3, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("descendants = %v, want %v", got, want)
+	}
+}
+
+// TestStopKillsEscapedDescendant starts a grandchild in its own session (so a
+// process-group kill misses it) and checks Stop still takes it down.
+func TestStopKillsEscapedDescendant(t *testing.T) {
+	if _, err := os.Stat("/proc"); err != nil {
+		t.Skip("needs /proc")
+	}
+	pidFile := t.TempDir() + "/grandchild.pid"
+	pm := NewProcessManager(t.TempDir(), t.TempDir())
+	_, err := pm.Start(SessionInfo{
+		Name:        "escapee",
+		Command:     "sh",
+		Args:        []string{"-c", "setsid sh -c 'echo $$ > " + pidFile + "; exec sleep 60' & wait"},
+		WorkDir:     t.TempDir(),
+		StopTimeout: 500 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var grandchild int
+	for i := 0; i < 50 && grandchild == 0; i++ {
+		time.Sleep(50 * time.Millisecond)
+		data, _ := os.ReadFile(pidFile)
+		grandchild, _ = strconv.Atoi(strings.TrimSpace(string(data)))
+	}
+	if grandchild == 0 {
+		t.Skip("setsid not available")
+	}
+
+	if err := pm.Stop("escapee"); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 20 && len(alivePIDs([]int{grandchild})) > 0; i++ {
+		time.Sleep(50 * time.Millisecond)
+	}
+	if len(alivePIDs([]int{grandchild})) > 0 {
+		t.Errorf("grandchild %d survived Stop", grandchild)
+	}
+}
@@ -0,0 +1,68 @@
+package devdash
+
+import (
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// defaultReady matches the line dev servers print once they accept requests,
+// against ANSI-stripped log lines: Vite's "ready in", Next.js' "Ready in" and
+// "started server on", Express-style "listening on", Encore's "development
+// server running", and "Local: http://..." URLs.
+var defaultReady = regexp.MustCompile(`(?i)(ready in \d|ready - started server|started server on|listening (on|at)\b|server (is )?running (on|at)\b|development server running|local:\s+https?://|app running at)`)
+
+// ReadyDetector notices the first log line of a run saying its dev server is
+// up, for a one-time signal when a session goes from starting to ready
+type ReadyDetector struct {
+	mu      sync.Mutex
+	pattern *regexp.Regexp
+	at      time.Time // when the ready line arrived; zero until then
+}
+
+// NewReadyDetector creates a detector for info's ready_pattern, falling back
+// to the defaults for an empty or invalid pattern
+func NewReadyDetector(info SessionInfo) *ReadyDetector {
+	return &ReadyDetector{pattern: compileOr(info.ReadyPattern, defaultReady)}
+}
+
+// Observe checks one log line at time t and reports whether it is the first
+// to say the server is ready
+func (rd *ReadyDetector) Observe(line string, t time.Time) bool {
+	if rd == nil {
+		return false
+	}
+	rd.mu.Lock()
+	defer rd.mu.Unlock()
+	if !rd.at.IsZero() || !rd.pattern.MatchString(ansi.Strip(line)) {
+		return false
+	}
+	rd.at = t
+	return true
+}
+
+// ReadyAt returns when the server said it was ready, zero if it hasn't yet
+func (rd *ReadyDetector) ReadyAt() time.Time {
+	if rd == nil {
+		return time.Time{}
+	}
+	rd.mu.Lock()
+	defer rd.mu.Unlock()
+	return rd.at
+}
+
+// Ready returns the channel that receives the name of each session whose log
+// says its server is up, once per run. Names nobody receives are dropped.
+func (pm *ProcessManager) Ready() <-chan string {
+	return pm.ready
+}
+
+// markReady reports that name's server is up on the Ready channel
+func (pm *ProcessManager) markReady(name string) {
+	select {
+	case pm.ready <- name:
+	default:
+	}
+}
@@ -0,0 +1,70 @@
+package devdash
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReadyDetectorDefaults(t *testing.T) {
+	for line, want := range map[string]bool{
+		"  VITE v5.0.0  ready in 312 ms":               true,
+		" ✓ Ready in 1.2s":                             true,
+		"ready - started server on 0.0.0.0:3000":       true,
+		"Server listening on http://localhost:4000":    true,
+		"\x1b[32m  ➜  Local:   http://localhost:5173/": true,
+		"Encore development server running!":           true,
+		"compiling...":                                 false,
+		"GET /api/health 200":                          false,
+	} {
+		rd := NewReadyDetector(SessionInfo{})
+		if got := rd.Observe(line, time.Now()); got != want {
+			t.Errorf("Observe(%q) = %v, want %v", line, got, want)
+		}
+	}
+}
+
+func TestReadyDetectorFiresOnce(t *testing.T) {
+	rd := NewReadyDetector(SessionInfo{ReadyPattern: `^up$`})
+	first := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	if rd.Observe("listening on :3000", first) {
+		t.Error("a custom pattern should replace the defaults")
+	}
+	if !rd.Observe("up", first) {
+		t.Fatal("first ready line not detected")
+	}
+	if rd.Observe("up", first.Add(time.Minute)) {
+		t.Error("a second ready line should not fire again")
+	}
+	if got := rd.ReadyAt(); !got.Equal(first) {
+		t.Errorf("ReadyAt() = %v, want the first ready line's %v", got, first)
+	}
+	if (*ReadyDetector)(nil).Observe("up", first) {
+		t.Error("a nil detector should never fire")
+	}
+}
+
+func TestReadyReportsSession(t *testing.T) {
+	pm := NewProcessManager(t.TempDir(), t.TempDir())
+	rp, err := pm.Start(SessionInfo{
+		Name:    "web",
+		Command: "sh",
+		Args:    []string{"-c", "echo starting; echo 'listening on :3000'; sleep 1"},
+		WorkDir: t.TempDir(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { <-rp.Done() }()
+
+	select {
+	case name := <-pm.Ready():
+		if name != "web" {
+			t.Errorf("Ready() sent %q, want web", name)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("no ready signal for a server that printed a listening line")
+	}
+	if rp.Ready.ReadyAt().IsZero() {
+		t.Error("ReadyAt() is zero after the ready signal")
+	}
+}
@@ -1,16 +1,17 @@
 package devdash
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
-	"syscall"
 	"time"
 
 	"github.com/kimaguri/simplx-toolkit/internal/process"
 )
 
 // Reconnect scans existing session files and re-attaches to alive processes.
-// Reads previous log output from the log file and starts tailing for new lines.
+// Loads the end of each log file (see SetPreload) and starts tailing for new lines.
 func (pm *ProcessManager) Reconnect() []*RunningProcess {
 	sessions, err := LoadAllSessions(pm.sessionsDir)
 	if err != nil {
@@ -46,16 +47,24 @@ func (pm *ProcessManager) reconnectSession(info SessionInfo) *RunningProcess {
 	tailStop := make(chan struct{})
 
 	// Read previous log content from file (sanitize raw PTY output)
-	logPath := pm.logFilePath(info.Name)
-	var startOffset int64
-	if data, readErr := os.ReadFile(logPath); readErr == nil && len(data) > 0 {
-		logBuf.Write(process.SanitizeForLog(data))
+	logPath := pm.sessionLogPath(info)
+	if logPath == "" {
+		note := fmt.Sprintf("[attached to PID %d without a log file: tracking liveness only]\n", info.PID)
+		if info.NoLog {
+			note = fmt.Sprintf("[PID %d was started without a persisted log: its output is lost]\n", info.PID)
+		}
+		logBuf.Write([]byte(note))
 		logBuf.Flush()
-		startOffset = int64(len(data))
-	}
+	} else {
+		data, startOffset, readErr := readLogTail(logPath, pm.preloadBytes())
+		if readErr == nil && len(data) > 0 {
+			logBuf.Write(process.SanitizeForLog(data))
+			logBuf.Flush()
+		}
 
-	// Continue tailing the log file for new output
-	go tailFile(logPath, logBuf, startOffset, tailStop)
+		// Continue tailing the log file for new output
+		go tailFile(pm.clock, logPath, logBuf, startOffset, tailStop, nil)
+	}
 
 	rp := &RunningProcess{
 		Info:      info,
@@ -64,16 +73,80 @@ func (pm *ProcessManager) reconnectSession(info SessionInfo) *RunningProcess {
 		Status:    StatusRunning,
 		StartedAt: time.Unix(info.StartedAt, 0),
 		tailStop:  tailStop,
+		Build:     NewBuildTimer(info),
+		Ready:     NewReadyDetector(info),
+		Restarts:  info.Restarts,
+	}
+	if info.LastRestart > 0 {
+		rp.LastRestart = time.Unix(info.LastRestart, 0)
 	}
+	go pm.watchBuilds(rp, logBuf.Subscribe(), tailStop)
 
 	pm.mu.Lock()
 	pm.processes[info.Name] = rp
 	pm.mu.Unlock()
 
+	if info.Attached {
+		go pm.watchAttached(rp)
+	}
 	return rp
 }
 
-// StopReconnected kills a process that was reconnected (no exec.Cmd available)
+// DefaultPreloadBytes is how much of the end of a session's log file is
+// loaded on reconnect unless SetPreload says otherwise. About a full log
+// buffer of typical lines; the rest would be evicted right away.
+const DefaultPreloadBytes = 1 << 20
+
+// SetPreload sets how many bytes from the end of a log file Reconnect and
+// Attach load into the buffer. Zero or less restores DefaultPreloadBytes.
+func (pm *ProcessManager) SetPreload(n int64) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.preload = n
+}
+
+// preloadBytes returns the configured preload size
+func (pm *ProcessManager) preloadBytes() int64 {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	if pm.preload <= 0 {
+		return DefaultPreloadBytes
+	}
+	return pm.preload
+}
+
+// readLogTail reads at most the last n bytes of the log file at path, without
+// reading the rest. When that cuts a line, the cut part is dropped. size is
+// where the returned data ends, for tailing to continue from.
+func readLogTail(path string, n int64) (data []byte, size int64, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, 0, err
+	}
+	size = fi.Size()
+	from := max(size-n, 0)
+	data = make([]byte, size-from)
+	if _, err := io.ReadFull(io.NewSectionReader(f, from, size-from), data); err != nil {
+		return nil, 0, err
+	}
+	if from > 0 {
+		if nl := bytes.IndexByte(data, '\n'); nl >= 0 {
+			data = data[nl+1:]
+		}
+	}
+	return data, size, nil
+}
+
+// StopReconnected kills a process that was reconnected (no exec.Cmd available).
+// One already marked stopped is only forgotten: its PID may belong to an
+// unrelated process by now. Attached processes get the signals on their PID
+// only, as with Signal.
 func (pm *ProcessManager) StopReconnected(name string) error {
 	pm.mu.Lock()
 	rp, exists := pm.processes[name]
@@ -88,6 +161,11 @@ func (pm *ProcessManager) StopReconnected(name string) error {
 	}
 
 	pid := rp.Info.PID
+	wasRunning := rp.Status == StatusRunning
+	timeout := stopTimeout(rp.Info)
+	if wasRunning {
+		rp.setStopDeadline(pm.clock.Now().Add(timeout))
+	}
 	pm.mu.Unlock()
 
 	// Stop tailing
@@ -95,32 +173,38 @@ func (pm *ProcessManager) StopReconnected(name string) error {
 		close(rp.tailStop)
 	}
 
-	signalReconnectedProcess(pid)
+	switch {
+	case !wasRunning:
+	case rp.Info.Attached:
+		pm.terminatePID(pid, stopSignal(rp.Info), pm.watchExit(pid), timeout)
+	default:
+		pm.terminate(pid, stopSignal(rp.Info), pm.watchExit(pid), timeout)
+	}
 
 	pm.mu.Lock()
+	rp.Status = StatusStopped
 	delete(pm.processes, name)
+	if wasRunning {
+		pm.notify(rp, EventStop, nil)
+	}
 	pm.mu.Unlock()
 
 	_ = RemoveSession(pm.sessionsDir, name)
 	return nil
 }
 
-// signalReconnectedProcess sends SIGTERM, waits, then SIGKILL if still alive.
-func signalReconnectedProcess(pid int) {
-	pgid, err := syscall.Getpgid(pid)
-	if err == nil {
-		_ = syscall.Kill(-pgid, syscall.SIGTERM)
-	} else {
-		proc, findErr := os.FindProcess(pid)
-		if findErr == nil {
-			_ = proc.Signal(syscall.SIGTERM)
-		}
-	}
-
-	time.Sleep(1 * time.Second)
-	if IsProcessAlive(pid) {
-		if pgid, err := syscall.Getpgid(pid); err == nil {
-			_ = syscall.Kill(-pgid, syscall.SIGKILL)
+// exitPollInterval is how often watchExit checks a reconnected process
+const exitPollInterval = 100 * time.Millisecond
+
+// watchExit returns a channel closed once pid is gone. Reconnected processes
+// aren't our children, so there is no Wait to block on and we poll instead.
+func (pm *ProcessManager) watchExit(pid int) <-chan struct{} {
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		for IsProcessAlive(pid) {
+			pm.clock.Sleep(exitPollInterval)
 		}
-	}
+	}()
+	return exited
 }
@@ -0,0 +1,52 @@
+package devdash
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadLogTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	content := "first line\nsecond line\nthird\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{1 << 20, content},
+		{int64(len(content)), content},
+		{int64(len("ond line\nthird\n")), "third\n"}, // cut line dropped
+		{int64(len("\nthird\n")), "third\n"},
+	}
+	for _, tt := range tests {
+		data, size, err := readLogTail(path, tt.n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != tt.want {
+			t.Errorf("readLogTail(%d) = %q, want %q", tt.n, data, tt.want)
+		}
+		if size != int64(len(content)) {
+			t.Errorf("readLogTail(%d) size = %d, want %d", tt.n, size, len(content))
+		}
+	}
+
+	if _, _, err := readLogTail(filepath.Join(t.TempDir(), "missing.log"), 10); err == nil {
+		t.Error("want an error for a missing file")
+	}
+}
+
+func TestPreloadBytes(t *testing.T) {
+	pm := NewProcessManager(t.TempDir(), t.TempDir())
+	if got := pm.preloadBytes(); got != DefaultPreloadBytes {
+		t.Errorf("default preload = %d, want %d", got, DefaultPreloadBytes)
+	}
+	pm.SetPreload(4096)
+	if got := pm.preloadBytes(); got != 4096 {
+		t.Errorf("preload = %d, want 4096", got)
+	}
+}
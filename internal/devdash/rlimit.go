@@ -0,0 +1,37 @@
+package devdash
+
+import (
+	"strconv"
+	"syscall"
+)
+
+// raiseOpenFilesScript raises the soft open-file limit to $1 unless it is
+// already at least that, then runs the rest of its arguments. A limit that
+// can't be raised is reported in the log and the process starts anyway.
+const raiseOpenFilesScript = `cur=$(ulimit -Sn)
+if [ "$cur" != unlimited ] && [ "$cur" -lt "$1" ]; then
+	ulimit -Sn "$1" 2>/dev/null || echo "[could not raise the open file limit to $1]" >&2
+fi
+shift
+exec "$@"`
+
+// openFilesLimit clamps a requested open-file limit to the hard limit, which
+// an unprivileged process can't go past. capped reports that it was lowered.
+func openFilesLimit(n int) (limit int, capped bool) {
+	var rl syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &rl); err != nil {
+		return n, false
+	}
+	if uint64(n) > rl.Max {
+		return int(rl.Max), true
+	}
+	return n, false
+}
+
+// withOpenFiles wraps command in a shell that raises its soft open-file limit
+// to limit first. The shell execs the command, so its PID and process group
+// are the command's.
+func withOpenFiles(limit int, command string, args []string) (string, []string) {
+	wrapped := append([]string{"-c", raiseOpenFilesScript, "sh", strconv.Itoa(limit), command}, args...)
+	return "/bin/sh", wrapped
+}
@@ -0,0 +1,39 @@
+package devdash
+
+import (
+	"strconv"
+	"syscall"
+	"testing"
+)
+
+func TestStartRaisesOpenFiles(t *testing.T) {
+	var rl syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &rl); err != nil {
+		t.Skip(err)
+	}
+	if rl.Max > 1<<20 {
+		t.Skip("hard limit too high to ask past it")
+	}
+	hard := int(rl.Max)
+
+	pm := NewProcessManager(t.TempDir(), t.TempDir())
+	rp, err := pm.Start(SessionInfo{
+		Name:      "files",
+		Command:   "sh",
+		Args:      []string{"-c", "echo limit=$(ulimit -Sn)"},
+		WorkDir:   t.TempDir(),
+		OpenFiles: hard + 1000,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-rp.Done()
+	waitForLog(t, rp, "limit="+strconv.Itoa(hard))
+	waitForLog(t, rp, "[open file limit capped at "+strconv.Itoa(hard))
+}
+
+func TestOpenFilesLimitUnderHard(t *testing.T) {
+	if limit, capped := openFilesLimit(64); limit != 64 || capped {
+		t.Errorf("openFilesLimit(64) = %d, %v; want 64, false", limit, capped)
+	}
+}
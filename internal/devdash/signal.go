@@ -0,0 +1,47 @@
+package devdash
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// Signals lists the signals offered for sending to a session, in menu order
+var Signals = []struct {
+	Name   string
+	Signal syscall.Signal
+	Hint   string
+}{
+	{"SIGHUP", syscall.SIGHUP, "reload config (nginx, many daemons)"},
+	{"SIGUSR1", syscall.SIGUSR1, "tool-defined, e.g. dump stats or reopen logs"},
+	{"SIGUSR2", syscall.SIGUSR2, "tool-defined, e.g. nodemon restart"},
+	{"SIGINT", syscall.SIGINT, "like Ctrl+C"},
+	{"SIGTERM", syscall.SIGTERM, "ask to exit"},
+	{"SIGQUIT", syscall.SIGQUIT, "exit with a dump (Go prints goroutines)"},
+	{"SIGKILL", syscall.SIGKILL, "kill immediately, no cleanup"},
+}
+
+// Signal sends sig to a running session's process group. Attached processes
+// get it on their PID only, since devdash doesn't own their group. Only the
+// PID is needed, so reconnected sessions work the same as started ones.
+func (pm *ProcessManager) Signal(name string, sig syscall.Signal) error {
+	pm.mu.RLock()
+	rp, exists := pm.processes[name]
+	if !exists {
+		pm.mu.RUnlock()
+		return fmt.Errorf("process %q not found", name)
+	}
+	running, pid, attached := rp.Status == StatusRunning, rp.Info.PID, rp.Info.Attached
+	pm.mu.RUnlock()
+
+	if !running || pid <= 0 {
+		return fmt.Errorf("process %q is not running", name)
+	}
+	if attached {
+		return syscall.Kill(pid, sig)
+	}
+	pgid, err := syscall.Getpgid(pid)
+	if err != nil {
+		return fmt.Errorf("process %q: %w", name, err)
+	}
+	return syscall.Kill(-pgid, sig)
+}
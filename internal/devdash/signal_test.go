@@ -0,0 +1,41 @@
+package devdash
+
+import (
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestSignalReachesProcessGroup(t *testing.T) {
+	pm := NewProcessManager(t.TempDir(), t.TempDir())
+
+	// The trap reports the signal in the log
+	script := `trap "echo got hup" HUP; echo ready; while :; do sleep 0.05; done`
+	rp, err := pm.Start(SessionInfo{Name: "reload", Command: "sh", Args: []string{"-c", script}, WorkDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pm.Stop("reload")
+
+	waitForLog(t, rp, "ready")
+	if err := pm.Signal("reload", syscall.SIGHUP); err != nil {
+		t.Fatal(err)
+	}
+	waitForLog(t, rp, "got hup")
+
+	if err := pm.Signal("missing", syscall.SIGHUP); err == nil {
+		t.Error("signalling an unknown session should fail")
+	}
+}
+
+func waitForLog(t *testing.T, rp *RunningProcess, want string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(rp.LogBuf.Content(), want) {
+		if time.Now().After(deadline) {
+			t.Fatalf("log never showed %q:\n%s", want, rp.LogBuf.Content())
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
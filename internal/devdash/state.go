@@ -5,21 +5,57 @@ import (
 	"os"
 	"path/filepath"
 	"syscall"
+	"time"
+
+	"github.com/google/renameio/v2"
 )
 
 // SessionInfo represents a persisted session state, saved as JSON
 type SessionInfo struct {
-	Name      string   `json:"name"`
-	PID       int      `json:"pid"`
-	Port      int      `json:"port"`
-	Command   string   `json:"command"`
-	Args      []string `json:"args"`
-	ExtraEnv  []string `json:"extra_env,omitempty"`
-	WorkDir   string   `json:"work_dir"`
-	Project   string   `json:"project"`
-	WtName    string   `json:"wt_name"`
-	WtPath    string   `json:"wt_path"`
-	StartedAt int64    `json:"started_at"`
+	Name     string   `json:"name"`
+	PID      int      `json:"pid"`
+	Port     int      `json:"port"`
+	Command  string   `json:"command"`
+	Args     []string `json:"args"`
+	ExtraEnv []string `json:"extra_env,omitempty"`
+	WorkDir  string   `json:"work_dir"`
+	Project  string   `json:"project"`
+	Script   string   `json:"script,omitempty"`
+	// Custom marks Script as a .devdash.json command name, not a package.json script
+	Custom bool `json:"custom,omitempty"`
+	// StopTimeout is the SIGTERM→SIGKILL window; 0 uses DefaultStopTimeout
+	StopTimeout time.Duration `json:"stop_timeout,omitempty"`
+	// StopSignal is the graceful stop signal name ("SIGINT"); "" means SIGTERM
+	StopSignal string `json:"stop_signal,omitempty"`
+	// OpenFiles raises the process's soft open-file limit to this; 0 inherits
+	OpenFiles int `json:"open_files,omitempty"`
+	// BuildStart and BuildDone override the build timer's log patterns
+	BuildStart string `json:"build_start,omitempty"`
+	BuildDone  string `json:"build_done,omitempty"`
+	// ReadyPattern overrides the log pattern that says the server is up
+	ReadyPattern string `json:"ready_pattern,omitempty"`
+	// NoLog marks a process started without a log file, its output kept in
+	// memory only, so it can't be reconnected to
+	NoLog bool `json:"no_log,omitempty"`
+	// CleanEnv starts the process from a minimal environment (see BaseEnv)
+	// instead of everything devdash inherited
+	CleanEnv bool `json:"clean_env,omitempty"`
+	// Attached marks a process devdash didn't start, adopted by PID. It can
+	// be stopped but not restarted; LogPath is its log file ("" = none).
+	Attached  bool   `json:"attached,omitempty"`
+	LogPath   string `json:"log_path,omitempty"`
+	WtName    string `json:"wt_name"`
+	WtPath    string `json:"wt_path"`
+	StartedAt int64  `json:"started_at"`
+	// Restarts and LastRestart (Unix seconds) carry the restart history of
+	// RunningProcess over a devdash restart
+	Restarts    int   `json:"restarts,omitempty"`
+	LastRestart int64 `json:"last_restart,omitempty"`
+	// Rows and Cols are the log panel size at launch, passed to the process
+	// as LINES/COLUMNS since its output goes to a file, not a terminal.
+	// Not persisted; ResizePTY keeps them current for restarts.
+	Rows int `json:"-"`
+	Cols int `json:"-"`
 }
 
 // sessionFilePath returns the full path for a session JSON file
@@ -27,7 +63,7 @@ func sessionFilePath(sessionsDir, name string) string {
 	return filepath.Join(sessionsDir, name+".json")
 }
 
-// SaveSession writes a SessionInfo as JSON to the sessions directory
+// SaveSession atomically writes a SessionInfo as JSON to the sessions directory
 func SaveSession(sessionsDir string, info SessionInfo) error {
 	if err := os.MkdirAll(sessionsDir, 0o755); err != nil {
 		return err
@@ -36,7 +72,7 @@ func SaveSession(sessionsDir string, info SessionInfo) error {
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(sessionFilePath(sessionsDir, info.Name), data, 0o644)
+	return renameio.WriteFile(sessionFilePath(sessionsDir, info.Name), data, 0o644)
 }
 
 // LoadAllSessions reads all session files from the sessions directory
@@ -71,6 +107,20 @@ func LoadAllSessions(sessionsDir string) ([]SessionInfo, error) {
 	return sessions, nil
 }
 
+// LoadSession reads the session file of the session called name; false if
+// there is none or it can't be read
+func LoadSession(sessionsDir, name string) (SessionInfo, bool) {
+	data, err := os.ReadFile(sessionFilePath(sessionsDir, name))
+	if err != nil {
+		return SessionInfo{}, false
+	}
+	var info SessionInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return SessionInfo{}, false
+	}
+	return info, true
+}
+
 // RemoveSession deletes the session file for the given name
 func RemoveSession(sessionsDir, name string) error {
 	err := os.Remove(sessionFilePath(sessionsDir, name))
@@ -2,6 +2,7 @@ package devdash
 
 import (
 	"bufio"
+	"fmt"
 	"os"
 	"os/exec"
 	"regexp"
@@ -48,18 +49,60 @@ type TunnelInfo struct {
 var tunnelURLPattern = regexp.MustCompile(`(https://[a-z0-9-]+\.trycloudflare\.com)`)
 
 // CloudflaredAvailable checks if cloudflared binary is in PATH
-func CloudflaredAvailable() bool {
-	_, err := exec.LookPath("cloudflared")
+func (pm *ProcessManager) CloudflaredAvailable() bool {
+	_, err := pm.runner.LookPath("cloudflared")
 	return err == nil
 }
 
+// TunnelOrigin is the local side of a tunnel: how cloudflared reaches the dev
+// server. Empty fields take the defaults http, localhost and host:port.
+type TunnelOrigin struct {
+	Scheme     string // "http" or "https"
+	Host       string // e.g. "127.0.0.1"
+	HostHeader string // Host header sent to the server, e.g. "app.test"
+}
+
+// URL returns the origin URL for port
+func (o TunnelOrigin) URL(port int) string {
+	scheme, host := o.Scheme, o.Host
+	if scheme == "" {
+		scheme = "http"
+	}
+	if host == "" {
+		host = "localhost"
+	}
+	return scheme + "://" + host + ":" + itoa(port)
+}
+
+// args returns the cloudflared arguments that point a tunnel at port. Local
+// HTTPS servers mostly use self-signed certificates, so those aren't verified.
+func (o TunnelOrigin) args(port int) ([]string, error) {
+	if o.Scheme != "" && o.Scheme != "http" && o.Scheme != "https" {
+		return nil, fmt.Errorf("tunnel scheme %q: want http or https", o.Scheme)
+	}
+	header := o.HostHeader
+	if header == "" {
+		header = o.Host
+		if header == "" {
+			header = "localhost"
+		}
+		header += ":" + itoa(port)
+	}
+	args := []string{"tunnel", "--url", o.URL(port), "--http-host-header", header}
+	if o.Scheme == "https" {
+		args = append(args, "--no-tls-verify")
+	}
+	return args, nil
+}
+
 // StartTunnel launches a cloudflared quick tunnel for the given port.
 // Returns a TunnelInfo with channels for URL and completion.
-func StartTunnel(port int) (*TunnelInfo, error) {
-	localhost := "http://localhost:" + itoa(port)
-	cmd := exec.Command("cloudflared", "tunnel",
-		"--url", localhost,
-		"--http-host-header", "localhost:"+itoa(port))
+func StartTunnel(port int, origin TunnelOrigin) (*TunnelInfo, error) {
+	args, err := origin.args(port)
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command("cloudflared", args...)
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
 
 	stderr, err := cmd.StderrPipe()
@@ -103,8 +146,8 @@ func StartTunnel(port int) (*TunnelInfo, error) {
 }
 
 // StopTunnel gracefully stops a running cloudflared tunnel.
-// Sends SIGTERM, waits 3s, then SIGKILL if still alive.
-func StopTunnel(t *TunnelInfo) {
+// Sends SIGTERM, waits 3s on clock, then SIGKILL if still alive.
+func StopTunnel(t *TunnelInfo, clock Clock) {
 	if t == nil || t.Cmd == nil || t.Cmd.Process == nil {
 		return
 	}
@@ -119,7 +162,7 @@ func StopTunnel(t *TunnelInfo) {
 	select {
 	case <-t.Done:
 		return
-	case <-time.After(3 * time.Second):
+	case <-clock.After(3 * time.Second):
 	}
 
 	if pgid, err := syscall.Getpgid(t.Cmd.Process.Pid); err == nil {
@@ -0,0 +1,28 @@
+package devdash
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTunnelOriginArgs(t *testing.T) {
+	tests := []struct {
+		origin TunnelOrigin
+		want   []string
+	}{
+		{TunnelOrigin{}, []string{"tunnel", "--url", "http://localhost:3000", "--http-host-header", "localhost:3000"}},
+		{TunnelOrigin{Host: "127.0.0.1"}, []string{"tunnel", "--url", "http://127.0.0.1:3000", "--http-host-header", "127.0.0.1:3000"}},
+		{TunnelOrigin{Scheme: "https", HostHeader: "app.test"},
+			[]string{"tunnel", "--url", "https://localhost:3000", "--http-host-header", "app.test", "--no-tls-verify"}},
+	}
+	for _, tt := range tests {
+		got, err := tt.origin.args(3000)
+		if err != nil || !slices.Equal(got, tt.want) {
+			t.Errorf("%+v: args = %q, %v; want %q", tt.origin, got, err, tt.want)
+		}
+	}
+
+	if _, err := (TunnelOrigin{Scheme: "ftp"}).args(3000); err == nil {
+		t.Error("scheme ftp: want an error")
+	}
+}
@@ -0,0 +1,175 @@
+package devdash
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// clockTicks is USER_HZ, the unit of CPU times in /proc/<pid>/stat. It is 100
+// on every mainstream Linux build; Go has no portable sysconf to ask.
+const clockTicks = 100
+
+// Usage is the CPU and memory use of a session's process tree
+type Usage struct {
+	CPU    float64 // percent of one core, summed over the tree
+	HasCPU bool    // false until there are two samples to compare
+	RSS    uint64  // resident memory in bytes, summed over the tree
+}
+
+// UsageSampler measures the CPU and memory of process trees. On Linux CPU is
+// averaged between successive samples, so a PID's first sample has none;
+// elsewhere ps reports it directly.
+type UsageSampler struct {
+	mu     sync.Mutex
+	runner Runner            // runs ps where there is no /proc
+	prev   map[int]cpuSample // by root PID
+}
+
+// cpuSample is a tree's total CPU time at a point in time
+type cpuSample struct {
+	ticks uint64
+	at    time.Time
+}
+
+// NewUsageSampler creates a sampler with no history that queries ps
+// through r
+func NewUsageSampler(r Runner) *UsageSampler {
+	return &UsageSampler{runner: r, prev: make(map[int]cpuSample)}
+}
+
+// procStat is the part of /proc/<pid>/stat the sampler needs
+type procStat struct {
+	ppid  int
+	ticks uint64 // utime + stime
+	rss   uint64 // pages
+}
+
+// Sample returns the usage of each PID in pids together with its
+// descendants. PIDs that are gone are left out.
+func (s *UsageSampler) Sample(pids []int, now time.Time) map[int]Usage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats, ok := procStats()
+	if !ok {
+		return psUsage(s.runner, pids)
+	}
+	tree := make(map[int][]int)
+	for pid, st := range stats {
+		tree[st.ppid] = append(tree[st.ppid], pid)
+	}
+
+	page := uint64(os.Getpagesize())
+	out := make(map[int]Usage)
+	next := make(map[int]cpuSample)
+	for _, root := range pids {
+		if _, alive := stats[root]; !alive {
+			continue
+		}
+		var u Usage
+		var ticks uint64
+		for _, p := range append([]int{root}, descendants(tree, root)...) {
+			ticks += stats[p].ticks
+			u.RSS += stats[p].rss * page
+		}
+		if prev, seen := s.prev[root]; seen && now.After(prev.at) && ticks >= prev.ticks {
+			u.CPU = float64(ticks-prev.ticks) / clockTicks / now.Sub(prev.at).Seconds() * 100
+			u.HasCPU = true
+		}
+		next[root] = cpuSample{ticks: ticks, at: now}
+		out[root] = u
+	}
+	s.prev = next
+	return out
+}
+
+// procStats reads every process's stat from /proc, keyed by PID
+func procStats() (map[int]procStat, bool) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, false
+	}
+	stats := make(map[int]procStat)
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join("/proc", e.Name(), "stat"))
+		if err != nil {
+			continue // exited while scanning
+		}
+		if st, ok := parseProcStat(string(data)); ok {
+			stats[pid] = st
+		}
+	}
+	return stats, true
+}
+
+// parseProcStat extracts the parent PID, CPU ticks and RSS from a
+// /proc/<pid>/stat line. As in parseStatPPID, fields are counted from the
+// last ')': state is field 3 of the line, ppid 4, utime 14, stime 15, rss 24.
+func parseProcStat(stat string) (procStat, bool) {
+	i := strings.LastIndexByte(stat, ')')
+	if i < 0 {
+		return procStat{}, false
+	}
+	fields := strings.Fields(stat[i+1:])
+	if len(fields) < 22 {
+		return procStat{}, false
+	}
+	ppid, err1 := strconv.Atoi(fields[1])
+	utime, err2 := strconv.ParseUint(fields[11], 10, 64)
+	stime, err3 := strconv.ParseUint(fields[12], 10, 64)
+	rss, err4 := strconv.ParseInt(fields[21], 10, 64)
+	if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+		return procStat{}, false
+	}
+	return procStat{ppid: ppid, ticks: utime + stime, rss: uint64(max(rss, 0))}, true
+}
+
+// psUsage is the fallback without /proc (macOS): one ps call for the whole
+// table, whose %cpu is already a recent average
+func psUsage(r Runner, pids []int) map[int]Usage {
+	out := make(map[int]Usage)
+	data, err := r.Output("ps", "-A", "-o", "pid=,ppid=,pcpu=,rss=")
+	if err != nil {
+		return out
+	}
+	tree := make(map[int][]int)
+	self := make(map[int]Usage)
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) != 4 {
+			continue
+		}
+		pid, err1 := strconv.Atoi(fields[0])
+		ppid, err2 := strconv.Atoi(fields[1])
+		cpu, err3 := strconv.ParseFloat(fields[2], 64)
+		rssKB, err4 := strconv.ParseUint(fields[3], 10, 64)
+		if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+			continue
+		}
+		tree[ppid] = append(tree[ppid], pid)
+		self[pid] = Usage{CPU: cpu, HasCPU: true, RSS: rssKB * 1024}
+	}
+	for _, root := range pids {
+		if _, alive := self[root]; !alive {
+			continue
+		}
+		u := Usage{HasCPU: true}
+		for _, p := range append([]int{root}, descendants(tree, root)...) {
+			u.CPU += self[p].CPU
+			u.RSS += self[p].RSS
+		}
+		out[root] = u
+	}
+	return out
+}
@@ -0,0 +1,39 @@
+package devdash
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestParseProcStat(t *testing.T) {
+	stat := "1234 (my (weird) proc) S 99 1234 1234 0 -1 4194560 500 0 0 0 250 50 0 0 20 0 1 0 100 123456789 2048 18446744073709551615"
+	st, ok := parseProcStat(stat)
+	if !ok || st.ppid != 99 || st.ticks != 300 || st.rss != 2048 {
+		t.Errorf("parseProcStat = %+v, %v; want ppid 99, 300 ticks, 2048 pages", st, ok)
+	}
+	if _, ok := parseProcStat("1234 (short) S 1 2 3"); ok {
+		t.Error("truncated stat should not parse")
+	}
+}
+
+func TestUsageSamplerSelf(t *testing.T) {
+	s := NewUsageSampler(SystemRunner)
+	pid := os.Getpid()
+	t0 := time.Now()
+
+	first := s.Sample([]int{pid, 999999999}, t0)
+	u, ok := first[pid]
+	if !ok || u.RSS == 0 {
+		t.Fatalf("own process: %+v, %v; want nonzero RSS", u, ok)
+	}
+	if _, ok := first[999999999]; ok {
+		t.Error("a missing PID should be left out")
+	}
+
+	// Two samples give a CPU figure where it is computed from deltas
+	second := s.Sample([]int{pid}, t0.Add(time.Second))
+	if u := second[pid]; !u.HasCPU || u.CPU < 0 {
+		t.Errorf("second sample: %+v; want a CPU figure", u)
+	}
+}
@@ -0,0 +1,146 @@
+package devdash
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// Webhook event names, also accepted in the config's event filter
+const (
+	EventStart  = "start"  // process launched
+	EventStop   = "stop"   // process stopped from devdash or exited cleanly
+	EventError  = "error"  // process exited with an error
+	EventTunnel = "tunnel" // tunnel URL became available
+)
+
+// webhookTimeout bounds each delivery so a slow endpoint can't stall the queue
+const webhookTimeout = 3 * time.Second
+
+// webhookQueueSize is how many events wait for delivery before new ones are
+// dropped
+const webhookQueueSize = 64
+
+// Event is the JSON payload POSTed to the webhook
+type Event struct {
+	Event     string    `json:"event"`
+	Session   string    `json:"session"`
+	Status    string    `json:"status"`
+	Port      int       `json:"port"`
+	ExitCode  *int      `json:"exit_code,omitempty"` // set for stop/error after an exit; -1 when killed by a signal
+	TunnelURL string    `json:"tunnel_url,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+// Webhook delivers process lifecycle events to a URL, fire-and-forget, one
+// at a time in the order they were sent
+type Webhook struct {
+	url    string
+	events map[string]bool // nil = every event
+	client *http.Client
+	clock  Clock       // stamps events sent without a time; the manager's once set
+	queue  chan Event  // events waiting for the delivery goroutine
+	post   func(Event) // replaced in tests
+}
+
+// NewWebhook returns a webhook for url. events limits which events are sent;
+// empty sends all of them.
+func NewWebhook(url string, events []string) *Webhook {
+	w := &Webhook{
+		url:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+		clock:  SystemClock,
+		queue:  make(chan Event, webhookQueueSize),
+	}
+	if len(events) > 0 {
+		w.events = make(map[string]bool, len(events))
+		for _, e := range events {
+			w.events[e] = true
+		}
+	}
+	w.post = w.deliver
+	go w.run()
+	return w
+}
+
+// Send queues e for delivery if its event is enabled. Never blocks: when the
+// queue is full the event is dropped with a warning. Delivery errors are
+// dropped.
+func (w *Webhook) Send(e Event) {
+	if w == nil || (w.events != nil && !w.events[e.Event]) {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = w.clock.Now()
+	}
+	select {
+	case w.queue <- e:
+	default:
+		_, _ = fmt.Fprintf(os.Stderr, "warning: webhook queue full, dropped %s event for %q\n", e.Event, e.Session)
+	}
+}
+
+// run delivers queued events one by one, for the life of the program
+func (w *Webhook) run() {
+	for e := range w.queue {
+		w.post(e)
+	}
+}
+
+// deliver POSTs e as JSON
+func (w *Webhook) deliver(e Event) {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	resp, err := w.client.Post(w.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	_ = resp.Body.Close()
+}
+
+// SetWebhook enables event delivery for every process the manager runs
+func (pm *ProcessManager) SetWebhook(w *Webhook) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	if w != nil {
+		w.clock = pm.clock
+	}
+	pm.webhook = w
+}
+
+// notify sends a lifecycle event for rp. Must be called with pm.mu held.
+func (pm *ProcessManager) notify(rp *RunningProcess, event string, exitCode *int) {
+	e := Event{
+		Event:    event,
+		Session:  rp.Info.Name,
+		Status:   rp.Status.String(),
+		Port:     rp.Info.Port,
+		ExitCode: exitCode,
+		Time:     pm.clock.Now(),
+	}
+	if rp.Tunnel != nil {
+		e.TunnelURL = rp.Tunnel.URL
+	}
+	pm.webhook.Send(e)
+}
+
+// exitCode extracts a process exit code from cmd.Wait's error: 0 for nil,
+// -1 when it didn't exit normally (signal) or the error isn't an exit error
+func exitCode(err error) *int {
+	code := 0
+	if err != nil {
+		code = -1
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			code = exitErr.ExitCode()
+		}
+	}
+	return &code
+}
@@ -0,0 +1,149 @@
+package devdash
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// recordWebhook returns a webhook that sends events to a channel instead of HTTP
+func recordWebhook(events ...string) (*Webhook, chan Event) {
+	ch := make(chan Event, 16)
+	w := NewWebhook("http://unused", events)
+	w.post = func(e Event) { ch <- e }
+	return w, ch
+}
+
+func nextEvent(t *testing.T, ch chan Event) Event {
+	t.Helper()
+	select {
+	case e := <-ch:
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("no webhook event")
+		return Event{}
+	}
+}
+
+func TestWebhookLifecycleEvents(t *testing.T) {
+	pm := NewProcessManager(t.TempDir(), t.TempDir())
+	w, ch := recordWebhook()
+	pm.SetWebhook(w)
+
+	rp, err := pm.Start(SessionInfo{Name: "crashy", Command: "sh", Args: []string{"-c", "exit 3"}, WorkDir: t.TempDir(), Port: 4000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := nextEvent(t, ch); e.Event != EventStart || e.Session != "crashy" || e.Port != 4000 {
+		t.Errorf("start event = %+v", e)
+	}
+	<-rp.Done()
+	e := nextEvent(t, ch)
+	if e.Event != EventError || e.ExitCode == nil || *e.ExitCode != 3 || e.Status != "error" {
+		t.Errorf("exit event = %+v", e)
+	}
+
+	// A stop from devdash sends one stop event, not an error for the kill
+	if _, err := pm.Start(SessionInfo{Name: "server", Command: "sleep", Args: []string{"30"}, WorkDir: t.TempDir()}); err != nil {
+		t.Fatal(err)
+	}
+	nextEvent(t, ch)
+	if err := pm.Stop("server"); err != nil {
+		t.Fatal(err)
+	}
+	if e := nextEvent(t, ch); e.Event != EventStop || e.Session != "server" {
+		t.Errorf("stop event = %+v", e)
+	}
+	select {
+	case e := <-ch:
+		t.Errorf("unexpected extra event %+v", e)
+	case <-time.After(500 * time.Millisecond):
+	}
+}
+
+func TestWebhookEventFilter(t *testing.T) {
+	w, ch := recordWebhook(EventError)
+	w.Send(Event{Event: EventStart})
+	w.Send(Event{Event: EventError, Session: "api"})
+	if e := nextEvent(t, ch); e.Event != EventError {
+		t.Errorf("got %+v, want only the error event", e)
+	}
+	select {
+	case e := <-ch:
+		t.Errorf("filtered event delivered: %+v", e)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	var nilHook *Webhook
+	nilHook.Send(Event{Event: EventStart}) // disabled webhook is a no-op
+}
+
+func TestWebhookKeepsOrder(t *testing.T) {
+	w := NewWebhook("http://unused", nil)
+	ch := make(chan Event, webhookQueueSize)
+	release := make(chan struct{})
+	w.post = func(e Event) {
+		<-release // a slow endpoint: every event is queued before the first is delivered
+		ch <- e
+	}
+
+	sessions := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	for _, s := range sessions {
+		w.Send(Event{Event: EventStart, Session: s})
+	}
+	close(release)
+	for _, want := range sessions {
+		if e := nextEvent(t, ch); e.Session != want {
+			t.Fatalf("delivered %q, want %q: events out of order", e.Session, want)
+		}
+	}
+}
+
+func TestWebhookDropsWhenFull(t *testing.T) {
+	w := NewWebhook("http://unused", nil)
+	ch := make(chan Event, 2*webhookQueueSize)
+	release := make(chan struct{})
+	w.post = func(e Event) {
+		<-release
+		ch <- e
+	}
+
+	// One event is held by the stuck delivery, the queue takes the next
+	// webhookQueueSize, and the rest are dropped without blocking
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 2*webhookQueueSize; i++ {
+			w.Send(Event{Event: EventStart})
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Send blocked on a full queue")
+	}
+	close(release)
+
+	time.Sleep(200 * time.Millisecond)
+	if n := len(ch); n < webhookQueueSize || n > webhookQueueSize+1 {
+		t.Errorf("delivered %d events, want the %d queued (+1 in flight)", n, webhookQueueSize)
+	}
+}
+
+func TestWebhookDeliversJSON(t *testing.T) {
+	got := make(chan Event, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var e Event
+		if r.Header.Get("Content-Type") == "application/json" && json.NewDecoder(r.Body).Decode(&e) == nil {
+			got <- e
+		}
+	}))
+	defer srv.Close()
+
+	NewWebhook(srv.URL, nil).Send(Event{Event: EventTunnel, Session: "web", TunnelURL: "https://x.trycloudflare.com"})
+	if e := nextEvent(t, got); e.TunnelURL != "https://x.trycloudflare.com" || e.Time.IsZero() {
+		t.Errorf("delivered %+v", e)
+	}
+}
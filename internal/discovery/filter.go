@@ -0,0 +1,116 @@
+package discovery
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Filter restricts which projects are discovered within a worktree.
+// Patterns are glob patterns (filepath.Match syntax plus "**" for any number
+// of path segments), matched against the project path relative to the worktree
+// root, the directory name, and the package.json name.
+type Filter struct {
+	Ignore  []string // skip matching projects (and don't descend into matching dirs)
+	Include []string // when non-empty, only matching projects are returned
+
+	PriorityScripts []string // dev scripts, listed first; empty = dev, start, serve, watch
+	Orchestrators   []string // commands that run a whole monorepo; empty = turbo, lerna, nx
+}
+
+// priority returns the configured priority scripts, or the defaults
+func (f Filter) priority() []string {
+	if len(f.PriorityScripts) == 0 {
+		return priorityScripts
+	}
+	return f.PriorityScripts
+}
+
+// orchestrators returns the configured orchestrator commands, or the defaults
+func (f Filter) orchestrators() []string {
+	if len(f.Orchestrators) == 0 {
+		return orchestratorCommands
+	}
+	return f.Orchestrators
+}
+
+// ignored returns true if the project matches any ignore pattern
+func (f Filter) ignored(relPath, name, pkgName string) bool {
+	return matchAny(f.Ignore, relPath, name, pkgName)
+}
+
+// included returns true if the project matches an include pattern,
+// or if no include patterns are configured
+func (f Filter) included(relPath, name, pkgName string) bool {
+	if len(f.Include) == 0 {
+		return true
+	}
+	return matchAny(f.Include, relPath, name, pkgName)
+}
+
+// allows returns true if a project passes both the ignore and include lists
+func (f Filter) allows(relPath, name, pkgName string) bool {
+	return !f.ignored(relPath, name, pkgName) && f.included(relPath, name, pkgName)
+}
+
+// matchAny checks patterns against the relative path, the directory name, and the package name
+func matchAny(patterns []string, relPath, name, pkgName string) bool {
+	relPath = filepath.ToSlash(relPath)
+	for _, p := range patterns {
+		p = strings.TrimSuffix(filepath.ToSlash(strings.TrimSpace(p)), "/")
+		if p == "" {
+			continue
+		}
+		if matchGlob(p, relPath) {
+			return true
+		}
+		// Patterns without a slash also match the bare directory name
+		if !strings.Contains(p, "/") {
+			if ok, _ := filepath.Match(p, name); ok {
+				return true
+			}
+		}
+		// Package names may be scoped (@scope/name), so match them regardless of slashes
+		if pkgName != "" {
+			if ok, _ := filepath.Match(p, pkgName); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchGlob matches a slash-separated path against a pattern where "**"
+// matches zero or more path segments and other segments use filepath.Match.
+func matchGlob(pattern, path string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(path, "/"))
+}
+
+// matchSegments is the recursive worker for matchGlob
+func matchSegments(pat, segs []string) bool {
+	for len(pat) > 0 {
+		if pat[0] == "**" {
+			// Collapse consecutive ** and try every possible split
+			for len(pat) > 0 && pat[0] == "**" {
+				pat = pat[1:]
+			}
+			if len(pat) == 0 {
+				return true
+			}
+			for i := 0; i <= len(segs); i++ {
+				if matchSegments(pat, segs[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segs) == 0 {
+			return false
+		}
+		if ok, err := filepath.Match(pat[0], segs[0]); err != nil || !ok {
+			return false
+		}
+		pat = pat[1:]
+		segs = segs[1:]
+	}
+	return len(segs) == 0
+}
@@ -0,0 +1,148 @@
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"tools/*", "tools/eslint", true},
+		{"tools/*", "tools/eslint/config", false},
+		{"tools/**", "tools/eslint/config", true},
+		{"**/internal-*", "packages/internal-cli", true},
+		{"**/internal-*", "internal-cli", true},
+		{"apps/**/web", "apps/web", true},
+		{"apps/**/web", "apps/a/b/web", true},
+		{"apps/*", "packages/web", false},
+	}
+	for _, tt := range tests {
+		if got := matchGlob(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("matchGlob(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
+// TestDetectProjectsFiltered_Ignore verifies that ignored packages are skipped
+// by path glob and by package name.
+func TestDetectProjectsFiltered_Ignore(t *testing.T) {
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, "pnpm-workspace.yaml"), []byte("packages:\n  - apps/*\n"), 0644)
+
+	for _, name := range []string{"web", "api", "eslint-config"} {
+		dir := filepath.Join(root, "apps", name)
+		os.MkdirAll(dir, 0755)
+		writePackageJSON(t, dir, "@acme/"+name, map[string]string{"dev": "vite"})
+	}
+	tooling := filepath.Join(root, "tooling", "scripts")
+	os.MkdirAll(tooling, 0755)
+	writePackageJSON(t, tooling, "scripts", map[string]string{"dev": "tsx watch"})
+
+	wt := Worktree{Name: "mono", Path: root}
+	projects := DetectProjectsFiltered(wt, Filter{Ignore: []string{"tooling/**", "@acme/eslint-*"}})
+
+	if len(projects) != 2 {
+		t.Fatalf("expected 2 projects, got %d: %v", len(projects), projectNames(projects))
+	}
+	for _, p := range projects {
+		if p.Name != "web" && p.Name != "api" {
+			t.Errorf("unexpected project %q", p.Name)
+		}
+	}
+}
+
+// TestDetectProjectsFiltered_Include verifies that only included projects are returned.
+func TestDetectProjectsFiltered_Include(t *testing.T) {
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, "pnpm-workspace.yaml"), []byte("packages:\n  - apps/*\n"), 0644)
+
+	for _, name := range []string{"web", "api"} {
+		dir := filepath.Join(root, "apps", name)
+		os.MkdirAll(dir, 0755)
+		writePackageJSON(t, dir, name, map[string]string{"dev": "vite"})
+	}
+
+	wt := Worktree{Name: "mono", Path: root}
+	projects := DetectProjectsFiltered(wt, Filter{Include: []string{"apps/web"}})
+
+	if len(projects) != 1 || projects[0].Name != "web" {
+		t.Fatalf("expected only 'web', got %v", projectNames(projects))
+	}
+}
+
+// TestDetectProjectsFiltered_ScriptRules verifies that configured priority
+// scripts order the script list and that configured orchestrators skip the root.
+func TestDetectProjectsFiltered_ScriptRules(t *testing.T) {
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, "pnpm-workspace.yaml"), []byte("packages:\n  - apps/*\n"), 0644)
+	writePackageJSON(t, root, "mono", map[string]string{"dev:local": "moon run :dev"})
+
+	web := filepath.Join(root, "apps", "web")
+	os.MkdirAll(web, 0755)
+	writePackageJSON(t, web, "web", map[string]string{"build": "vite build", "serve:hot": "vite --port 5173", "dev": "vite"})
+
+	wt := Worktree{Name: "mono", Path: root}
+
+	// Defaults: dev is listed first, and the root is not recognized as an orchestrator
+	projects := DetectProjectsFiltered(wt, Filter{})
+	if len(projects) != 2 {
+		t.Fatalf("defaults: expected root and 'web', got %v", projectNames(projects))
+	}
+	if got := projects[1].Scripts; !reflect.DeepEqual(got, []string{"dev", "build", "serve:hot"}) {
+		t.Errorf("defaults: scripts = %v", got)
+	}
+
+	// The root's dev:local is now a priority script, but runs an orchestrator
+	filter := Filter{PriorityScripts: []string{"serve:hot", "dev:local"}, Orchestrators: []string{"moon run"}}
+	projects = DetectProjectsFiltered(wt, filter)
+	if len(projects) != 1 || projects[0].Name != "web" {
+		t.Fatalf("configured: expected only 'web', got %v", projectNames(projects))
+	}
+	if got := projects[0].Scripts; !reflect.DeepEqual(got, []string{"serve:hot", "build", "dev"}) {
+		t.Errorf("configured: scripts = %v", got)
+	}
+	if projects[0].DetectedPort != 5173 {
+		t.Errorf("configured: port = %d, want 5173 from serve:hot", projects[0].DetectedPort)
+	}
+
+	// Without moon as an orchestrator the root is a project of its own
+	filter.Orchestrators = nil
+	if projects = DetectProjectsFiltered(wt, filter); len(projects) != 2 {
+		t.Fatalf("default orchestrators: expected root and 'web', got %v", projectNames(projects))
+	}
+}
+
+func TestIsOrchestratorScript(t *testing.T) {
+	tests := []struct {
+		script string
+		want   bool
+	}{
+		{"turbo run dev", true},
+		{"turbo\tdev", true},
+		{"turbo", true},
+		{"  nx run-many -t serve", true},
+		{"lerna run dev --parallel", true},
+		{"pnpm -r dev", true},
+		{"pnpm --filter web dev", false},
+		{"concurrently vite \"tsc -w\"", false}, // not at a workspace root
+		{"turbogen", false},
+		{"vite", false},
+		{"next dev", false},
+	}
+	for _, tt := range tests {
+		if got := isOrchestratorScript(tt.script, orchestratorCommands, false); got != tt.want {
+			t.Errorf("isOrchestratorScript(%q) = %v, want %v", tt.script, got, tt.want)
+		}
+	}
+	if !isOrchestratorScript("moon run :dev", []string{"moon run"}, false) {
+		t.Error("configured multi-word command should match")
+	}
+	if isOrchestratorScript("moonlight", []string{"moon"}, false) {
+		t.Error("command must be followed by whitespace or end the script")
+	}
+}
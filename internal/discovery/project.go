@@ -5,9 +5,12 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/kimaguri/simplx-toolkit/internal/config"
 )
 
 // Project represents a runnable dev project within a worktree
@@ -22,6 +25,11 @@ type Project struct {
 	PackageManager string   // auto-detected: "pnpm"|"npm"|"yarn"|"bun"
 	DetectedPort   int      // port found in config files (webpack/vite), 0 = not detected
 	PortFixed      bool     // true if port is hardcoded (not reading PORT env)
+	Framework      string   // detected framework (e.g. "next", "vite"), empty if unknown
+	NodeVersion    string   // required Node version from .nvmrc/.node-version/.tool-versions, empty if none
+	Commands       []string // custom command names from .devdash.json (listed before Scripts)
+	// ToolVersions are the runtimes pinned in the nearest asdf .tool-versions
+	ToolVersions []ToolVersion
 }
 
 // skipDirs contains directory names to skip during scanning
@@ -43,10 +51,19 @@ var skipDirs = map[string]bool{
 // DetectProjects finds runnable projects within a worktree by scanning for:
 //   - package.json with a "dev" script (Node.js projects)
 //   - encore.app file (Encore projects)
+//   - .devdash.json with custom commands (any other project)
 //
-// Monorepo roots with turbo/lerna orchestrators are skipped — only leaf projects are returned.
+// Monorepo roots with orchestrators (turbo, lerna, pnpm -r, concurrently, ...) are skipped —
+// only leaf projects are returned.
 // Scans up to 2 levels deep, skipping known non-project directories.
 func DetectProjects(wt Worktree) []Project {
+	return DetectProjectsFiltered(wt, Filter{})
+}
+
+// DetectProjectsFiltered is DetectProjects with user-configured ignore/include patterns applied.
+// Ignored directories are not descended into; include patterns only restrict which
+// projects are returned, so nested matches are still found.
+func DetectProjectsFiltered(wt Worktree, filter Filter) []Project {
 	var projects []Project
 	seen := make(map[string]bool)
 
@@ -63,26 +80,31 @@ func DetectProjects(wt Worktree) []Project {
 			name = filepath.Base(wt.Path)
 		}
 		pm := detectPackageManager(wt.Path)
-		port, fixed := detectConfigPort(wt.Path)
+		port, fixed := detectPort(wt.Path, filter.priority())
 		projects = append(projects, Project{
 			Name:           name,
 			Path:           wt.Path,
 			IsEncore:       true,
 			PkgName:        name,
-			Scripts:        getScripts(wt.Path),
+			Scripts:        getScripts(wt.Path, filter.priority()),
 			PackageManager: pm,
 			DetectedPort:   port,
 			PortFixed:      fixed,
+			Framework:      "encore",
+			NodeVersion:    detectNodeVersion(wt.Path),
+			ToolVersions:   detectToolVersions(wt.Path),
+			Commands:       getCustomCommands(wt.Path),
 		})
 		seen[wt.Path] = true
 	}
 
 	// Check root for Node project (skip monorepo orchestrators like turbo/lerna)
 	if !seen[wt.Path] {
-		scripts := getScripts(wt.Path)
-		if len(scripts) > 0 && !hasOnlyOrchestratorScripts(scripts, wt.Path) {
+		scripts := getScripts(wt.Path, filter.priority())
+		commands := getCustomCommands(wt.Path)
+		if (len(scripts) > 0 && !hasOnlyOrchestratorScripts(wt.Path, filter)) || len(commands) > 0 {
 			pm := detectPackageManager(wt.Path)
-			port, fixed := detectConfigPort(wt.Path)
+			port, fixed := detectPort(wt.Path, filter.priority())
 			projects = append(projects, Project{
 				Name:           filepath.Base(wt.Path),
 				Path:           wt.Path,
@@ -91,6 +113,10 @@ func DetectProjects(wt Worktree) []Project {
 				PackageManager: pm,
 				DetectedPort:   port,
 				PortFixed:      fixed,
+				Framework:      detectFramework(wt.Path, filter.priority()),
+				NodeVersion:    detectNodeVersion(wt.Path),
+				ToolVersions:   detectToolVersions(wt.Path),
+				Commands:       commands,
 			})
 			seen[wt.Path] = true
 		}
@@ -103,14 +129,22 @@ func DetectProjects(wt Worktree) []Project {
 	// - No root project detected — scan to find nested projects
 	isEncore := len(projects) > 0 && projects[0].IsEncore
 	if !isEncore && (wsRoot != "" || len(projects) == 0) {
-		scanLevel(wt.Path, wsRoot, &projects, seen, 1, 2)
+		scanLevel(wt.Path, wt.Path, wsRoot, filter, &projects, seen, 1, 2)
+	}
+
+	// Root projects are detected before filtering so that an ignored root
+	// still suppresses subdirectory scanning for non-workspace repos
+	if len(projects) > 0 && projects[0].Path == wt.Path &&
+		!filter.allows(".", filepath.Base(wt.Path), projects[0].PkgName) {
+		projects = projects[1:]
 	}
 
 	return projects
 }
 
-// scanLevel recursively scans for projects up to maxDepth
-func scanLevel(dir, wsRoot string, projects *[]Project, seen map[string]bool, depth, maxDepth int) {
+// scanLevel recursively scans for projects up to maxDepth.
+// rootDir is the worktree root, used to build relative paths for filter matching.
+func scanLevel(dir, rootDir, wsRoot string, filter Filter, projects *[]Project, seen map[string]bool, depth, maxDepth int) {
 	if depth > maxDepth {
 		return
 	}
@@ -134,19 +168,33 @@ func scanLevel(dir, wsRoot string, projects *[]Project, seen map[string]bool, de
 			continue
 		}
 
-		scripts := getScripts(childPath)
-		if len(scripts) > 0 {
+		relPath, _ := filepath.Rel(rootDir, childPath)
+		pkgName := getPkgName(childPath)
+		if filter.ignored(relPath, name, pkgName) {
+			continue
+		}
+
+		scripts := getScripts(childPath, filter.priority())
+		commands := getCustomCommands(childPath)
+		if len(scripts) > 0 || len(commands) > 0 {
 			seen[childPath] = true
+			if !filter.included(relPath, name, pkgName) {
+				continue // don't scan inside a detected project
+			}
 			pm := detectPackageManager(childPath)
-			port, fixed := detectConfigPort(childPath)
+			port, fixed := detectPort(childPath, filter.priority())
 			proj := Project{
 				Name:           name,
 				Path:           childPath,
-				PkgName:        getPkgName(childPath),
+				PkgName:        pkgName,
 				Scripts:        scripts,
 				PackageManager: pm,
 				DetectedPort:   port,
 				PortFixed:      fixed,
+				Framework:      detectFramework(childPath, filter.priority()),
+				NodeVersion:    detectNodeVersion(childPath),
+				ToolVersions:   detectToolVersions(childPath),
+				Commands:       commands,
 			}
 			if wsRoot != "" && childPath != wsRoot {
 				proj.WorkspaceRoot = wsRoot
@@ -156,7 +204,7 @@ func scanLevel(dir, wsRoot string, projects *[]Project, seen map[string]bool, de
 		}
 
 		// Recurse into subdirectory
-		scanLevel(childPath, wsRoot, projects, seen, depth+1, maxDepth)
+		scanLevel(childPath, rootDir, wsRoot, filter, projects, seen, depth+1, maxDepth)
 	}
 }
 
@@ -211,11 +259,13 @@ func isEncoreProject(dir string) bool {
 	return err == nil
 }
 
-// priorityScripts are shown first in the script list
+// priorityScripts are shown first in the script list, unless the
+// priority_scripts config key names others
 var priorityScripts = []string{"dev", "start", "serve", "watch"}
 
-// getScripts reads all script names from package.json, sorted with dev/start first
-func getScripts(dir string) []string {
+// getScripts reads all script names from package.json, sorted with the
+// priority scripts first in their given order
+func getScripts(dir string, priorityScripts []string) []string {
 	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
 	if err != nil {
 		return nil
@@ -244,10 +294,19 @@ func getScripts(dir string) []string {
 	return append(priority, rest...)
 }
 
-// hasOnlyOrchestratorScripts returns true if all priority dev scripts (dev/start/serve/watch)
-// are orchestrators (turbo/lerna/nx). Non-priority scripts like lint/test/build are ignored
-// since the launcher is for running dev servers, not arbitrary scripts.
-func hasOnlyOrchestratorScripts(scripts []string, dir string) bool {
+// getCustomCommands returns the names of the custom commands in dir's .devdash.json
+func getCustomCommands(dir string) []string {
+	var names []string
+	for _, c := range config.LoadProjectConfig(dir).LaunchCommands() {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// hasOnlyOrchestratorScripts returns true if all priority dev scripts (dev/start/serve/watch
+// by default) are orchestrators (turbo/lerna/nx by default). Non-priority scripts like
+// lint/test/build are ignored since the launcher is for running dev servers, not arbitrary scripts.
+func hasOnlyOrchestratorScripts(dir string, filter Filter) bool {
 	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
 	if err != nil {
 		return false
@@ -259,14 +318,15 @@ func hasOnlyOrchestratorScripts(scripts []string, dir string) bool {
 		return false
 	}
 	// Only check priority scripts — these are what users actually launch
+	workspace := isWorkspace(dir)
 	hasPriority := false
-	for _, name := range priorityScripts {
+	for _, name := range filter.priority() {
 		cmd, ok := pkg.Scripts[name]
 		if !ok {
 			continue
 		}
 		hasPriority = true
-		if !isOrchestratorScript(cmd) {
+		if !isOrchestratorScript(cmd, filter.orchestrators(), workspace) {
 			return false
 		}
 	}
@@ -302,6 +362,146 @@ func detectPackageManager(dir string) string {
 	return "npm"
 }
 
+// nodeVersionFiles are read by detectNodeVersion, nearest first
+var nodeVersionFiles = []string{".nvmrc", ".node-version"}
+
+// detectNodeVersion walks up from dir looking for a pinned Node version.
+// Returns the first line of the nearest .nvmrc or .node-version, or the
+// nodejs entry of a .tool-versions, without a leading "v" (e.g. "20.11.1",
+// "lts/iron"), or "" if none is found.
+func detectNodeVersion(dir string) string {
+	current := dir
+	for {
+		for _, name := range nodeVersionFiles {
+			data, err := os.ReadFile(filepath.Join(current, name))
+			if err != nil {
+				continue
+			}
+			line, _, _ := strings.Cut(strings.TrimSpace(string(data)), "\n")
+			if v := strings.TrimPrefix(strings.TrimSpace(line), "v"); v != "" {
+				return v
+			}
+		}
+		pins, _ := readToolVersions(current)
+		for _, pin := range pins {
+			if pin.Tool == "nodejs" {
+				return strings.TrimPrefix(pin.Version, "v")
+			}
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			break
+		}
+		current = parent
+	}
+	return ""
+}
+
+// ToolVersion is a runtime pinned in an asdf .tool-versions file
+type ToolVersion struct {
+	Tool    string // asdf plugin name, e.g. "nodejs", "python"
+	Version string // e.g. "20.11.1"
+}
+
+// String formats the pin as it appears in the file: "nodejs 20.11.1"
+func (tv ToolVersion) String() string {
+	return tv.Tool + " " + tv.Version
+}
+
+// toolVersionsFile is asdf's per-directory runtime pin file
+const toolVersionsFile = ".tool-versions"
+
+// knownRuntimes are the .tool-versions entries kept on a Project; linters,
+// CLIs and other tools pinned there are ignored
+var knownRuntimes = map[string]bool{
+	"nodejs": true, "bun": true, "deno": true, "pnpm": true, "yarn": true,
+	"python": true, "ruby": true, "golang": true, "rust": true, "java": true, "elixir": true,
+}
+
+// readToolVersions parses dir's .tool-versions into the pins of known
+// runtimes, in file order. Of several versions on a line (asdf's fallbacks)
+// the first is kept. ok is false if dir has no .tool-versions.
+func readToolVersions(dir string) (pins []ToolVersion, ok bool) {
+	data, err := os.ReadFile(filepath.Join(dir, toolVersionsFile))
+	if err != nil {
+		return nil, false
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && knownRuntimes[fields[0]] {
+			pins = append(pins, ToolVersion{Tool: fields[0], Version: fields[1]})
+		}
+	}
+	return pins, true
+}
+
+// detectToolVersions returns the runtime pins of the nearest .tool-versions
+// at or above dir, nil if there is none
+func detectToolVersions(dir string) []ToolVersion {
+	current := dir
+	for {
+		if pins, ok := readToolVersions(current); ok {
+			return pins
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return nil
+		}
+		current = parent
+	}
+}
+
+// detectPort returns the project's dev port and whether it is hardcoded.
+// A port flag in the dev script wins over config files, since CLI flags
+// override the bundler config at runtime; a port in .devdash.json comes next.
+func detectPort(dir string, priorityScripts []string) (int, bool) {
+	if port, fixed := detectScriptPort(dir, priorityScripts); port > 0 {
+		return port, fixed
+	}
+	port, fixed := detectConfigPort(dir)
+	if !fixed {
+		// The team's default from .devdash.json, unless the bundler ignores PORT
+		if p := config.LoadProjectConfig(dir).Port; p > 0 {
+			return p, false
+		}
+	}
+	return port, fixed
+}
+
+// scriptPortRe matches `--port 4000`, `--port=4000`, `-p 4000` and `-p=4000` flags
+var scriptPortRe = regexp.MustCompile(`(?:^|\s)(?:--port|-p)(?:=|\s+)(\S+)`)
+
+// detectScriptPort parses the first priority script (dev/start/serve/watch by default) for a
+// port flag, taking the first one with a literal number: env references like $PORT and
+// flags like tsc's -p tsconfig.json are skipped.
+func detectScriptPort(dir string, priorityScripts []string) (int, bool) {
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return 0, false
+	}
+	var pkg struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return 0, false
+	}
+	for _, name := range priorityScripts {
+		script, ok := pkg.Scripts[name]
+		if !ok {
+			continue
+		}
+		for _, m := range scriptPortRe.FindAllStringSubmatch(script, -1) {
+			port, err := strconv.Atoi(strings.Trim(m[1], `"'`))
+			if err == nil && port >= 1 && port <= 65535 {
+				return port, true
+			}
+		}
+		return 0, false
+	}
+	return 0, false
+}
+
 // devConfigFiles are checked for hardcoded port values
 var devConfigFiles = []string{
 	"webpack.dev.ts", "webpack.dev.js",
@@ -344,18 +544,137 @@ func detectConfigPort(dir string) (int, bool) {
 	return 0, false
 }
 
+// frameworkDeps maps package.json dependencies to framework names.
+// Order matters: meta-frameworks are listed before the libraries they build on
+// (e.g. next before react, remix before vite) so the most specific match wins.
+var frameworkDeps = []struct {
+	dep       string
+	framework string
+}{
+	{"next", "next"},
+	{"@remix-run/dev", "remix"},
+	{"@remix-run/react", "remix"},
+	{"nuxt", "nuxt"},
+	{"@sveltejs/kit", "sveltekit"},
+	{"astro", "astro"},
+	{"@nestjs/core", "nest"},
+	{"@angular/core", "angular"},
+	{"gatsby", "gatsby"},
+	{"expo", "expo"},
+	{"motia", "motia"},
+	{"vite", "vite"},
+	{"webpack-dev-server", "webpack"},
+	{"fastify", "fastify"},
+	{"express", "express"},
+}
+
+// frameworkCommands maps leading dev script commands to framework names.
+// Used as a fallback when dependencies don't identify the framework
+// (e.g. the binary is hoisted to the workspace root).
+var frameworkCommands = []struct {
+	cmd       string
+	framework string
+}{
+	{"next", "next"},
+	{"remix", "remix"},
+	{"nuxt", "nuxt"},
+	{"nuxi", "nuxt"},
+	{"astro", "astro"},
+	{"nest", "nest"},
+	{"ng", "angular"},
+	{"gatsby", "gatsby"},
+	{"expo", "expo"},
+	{"vite", "vite"},
+	{"webpack", "webpack"},
+}
+
+// detectFramework identifies the project's framework from package.json dependencies,
+// falling back to the command of the first priority script. Returns "" if unknown.
+func detectFramework(dir string, priorityScripts []string) string {
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return ""
+	}
+	var pkg struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+		Scripts         map[string]string `json:"scripts"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return ""
+	}
+
+	for _, fd := range frameworkDeps {
+		if _, ok := pkg.Dependencies[fd.dep]; ok {
+			return fd.framework
+		}
+		if _, ok := pkg.DevDependencies[fd.dep]; ok {
+			return fd.framework
+		}
+	}
+
+	for _, name := range priorityScripts {
+		fields := strings.Fields(pkg.Scripts[name])
+		if len(fields) == 0 {
+			continue
+		}
+		for _, fc := range frameworkCommands {
+			if fields[0] == fc.cmd {
+				return fc.framework
+			}
+		}
+	}
+	return ""
+}
+
+// orchestratorCommands are the monorepo orchestrators recognized unless the
+// orchestrator_commands config key names others
+var orchestratorCommands = []string{"turbo", "lerna", "nx"}
+
+// parallelRunners start several scripts at once. A single app may use them too
+// (e.g. a server next to a type checker), so they only count as orchestrators
+// at a workspace root.
+var parallelRunners = []string{"concurrently", "npm-run-all", "run-p", "run-s"}
+
 // isOrchestratorScript returns true if the dev script is a monorepo orchestrator
-// (turbo, lerna, nx) that runs all sub-projects rather than a single app
-func isOrchestratorScript(script string) bool {
+// that runs all sub-projects rather than a single app: the script is one of
+// commands or starts with one followed by its arguments, runs a recursive
+// pnpm command, or, when workspace is set, runs a parallel runner
+func isOrchestratorScript(script string, commands []string, workspace bool) bool {
 	s := strings.TrimSpace(script)
-	for _, prefix := range []string{"turbo ", "turbo\t", "lerna ", "nx "} {
-		if strings.HasPrefix(s, prefix) {
+	for _, cmd := range commands {
+		cmd = strings.TrimSpace(cmd)
+		if cmd == "" {
+			continue
+		}
+		if s == cmd || (strings.HasPrefix(s, cmd) && strings.ContainsAny(s[len(cmd):len(cmd)+1], " \t")) {
 			return true
 		}
 	}
-	// Also match exact command names (e.g., "turbo dev" without "run")
-	for _, cmd := range []string{"turbo", "lerna", "nx"} {
-		if s == cmd {
+
+	fields := strings.Fields(s)
+	if len(fields) > 0 && fields[0] == "npx" {
+		fields = fields[1:]
+	}
+	if len(fields) == 0 {
+		return false
+	}
+	if fields[0] == "pnpm" && isRecursivePnpm(fields[1:]) {
+		return true
+	}
+	return workspace && slices.Contains(parallelRunners, fields[0])
+}
+
+// isRecursivePnpm reports whether pnpm arguments run the command in every
+// workspace package (-r/--recursive, as in `pnpm -r --parallel dev`). Flags
+// after "--" belong to the script and are not considered; --filter alone
+// selects a single package and is not recursive.
+func isRecursivePnpm(args []string) bool {
+	for _, arg := range args {
+		switch arg {
+		case "--":
+			return false
+		case "-r", "--recursive":
 			return true
 		}
 	}
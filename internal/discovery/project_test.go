@@ -172,6 +172,55 @@ func TestDetectProjects_LernaMonorepo(t *testing.T) {
 	}
 }
 
+// TestDetectProjects_ParallelOrchestrators verifies that workspace roots whose
+// dev script fans out with pnpm -r or a parallel runner are skipped.
+func TestDetectProjects_ParallelOrchestrators(t *testing.T) {
+	for _, dev := range []string{
+		"pnpm -r --parallel dev",
+		"pnpm --recursive --parallel run dev",
+		`concurrently "pnpm --filter web dev" "pnpm --filter api dev"`,
+		"npx concurrently -n web,api \"npm:dev:*\"",
+		"npm-run-all --parallel dev:*",
+		"run-p dev:web dev:api",
+		"run-s build:shared dev:all",
+	} {
+		t.Run(dev, func(t *testing.T) {
+			root := t.TempDir()
+			os.WriteFile(filepath.Join(root, "pnpm-workspace.yaml"), []byte("packages:\n  - apps/*\n"), 0644)
+			writePackageJSON(t, root, "mono", map[string]string{"dev": dev})
+
+			sub := filepath.Join(root, "apps", "web")
+			os.MkdirAll(sub, 0755)
+			writePackageJSON(t, sub, "web", map[string]string{"dev": "vite"})
+
+			projects := DetectProjects(Worktree{Name: "mono", Path: root})
+			if len(projects) != 1 || projects[0].Name != "web" {
+				t.Fatalf("expected only leaf 'web', got %v", projectNames(projects))
+			}
+		})
+	}
+}
+
+// TestDetectProjects_ParallelRunnerApp verifies that a standalone app using a
+// parallel runner, or a single pnpm --filter, is still launchable.
+func TestDetectProjects_ParallelRunnerApp(t *testing.T) {
+	for _, dev := range []string{
+		`concurrently "vite" "tsc --watch"`,
+		"run-p dev:server dev:types",
+		"pnpm --filter web dev -- -r",
+	} {
+		t.Run(dev, func(t *testing.T) {
+			root := t.TempDir()
+			writePackageJSON(t, root, "my-app", map[string]string{"dev": dev})
+
+			projects := DetectProjects(Worktree{Name: "my-app", Path: root})
+			if len(projects) != 1 || projects[0].Path != root {
+				t.Fatalf("expected the root app, got %v", projectNames(projects))
+			}
+		})
+	}
+}
+
 // TestDetectProjects_StandaloneNodeProject verifies that a non-monorepo
 // Node project with dev scripts does not scan subdirectories.
 func TestDetectProjects_StandaloneNodeProject(t *testing.T) {
@@ -215,6 +264,26 @@ func TestDetectProjects_NoRootScansSubdirs(t *testing.T) {
 	}
 }
 
+// TestDetectProjects_CustomCommandsOnly verifies that a directory without
+// package.json is launchable when its .devdash.json defines commands.
+func TestDetectProjects_CustomCommandsOnly(t *testing.T) {
+	root := t.TempDir()
+
+	sub := filepath.Join(root, "api")
+	os.MkdirAll(sub, 0755)
+	os.WriteFile(filepath.Join(sub, ".devdash.json"), []byte(`{"commands":[{"name":"make dev","command":"make","args":["dev"]}]}`), 0644)
+
+	wt := Worktree{Name: "services", Path: root}
+	projects := DetectProjects(wt)
+
+	if len(projects) != 1 || projects[0].Name != "api" {
+		t.Fatalf("expected project 'api', got %v", projectNames(projects))
+	}
+	if len(projects[0].Commands) != 1 || projects[0].Commands[0] != "make dev" || len(projects[0].Scripts) != 0 {
+		t.Errorf("commands = %v, scripts = %v", projects[0].Commands, projects[0].Scripts)
+	}
+}
+
 // projectNames extracts names for error messages
 func projectNames(projects []Project) []string {
 	names := make([]string, len(projects))
@@ -223,3 +292,125 @@ func projectNames(projects []Project) []string {
 	}
 	return names
 }
+
+// TestDetectFramework verifies framework detection from dependencies and dev scripts.
+func TestDetectFramework(t *testing.T) {
+	tests := []struct {
+		name string
+		pkg  map[string]any
+		want string
+	}{
+		{"next over react", map[string]any{"dependencies": map[string]string{"react": "18", "next": "14"}}, "next"},
+		{"remix over vite", map[string]any{"devDependencies": map[string]string{"vite": "5", "@remix-run/dev": "2"}}, "remix"},
+		{"nest", map[string]any{"dependencies": map[string]string{"@nestjs/core": "10"}}, "nest"},
+		{"script fallback", map[string]any{"scripts": map[string]string{"dev": "vite --host"}}, "vite"},
+		{"unknown", map[string]any{"scripts": map[string]string{"dev": "node server.js"}}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			data, _ := json.Marshal(tt.pkg)
+			if err := os.WriteFile(filepath.Join(dir, "package.json"), data, 0644); err != nil {
+				t.Fatal(err)
+			}
+			if got := detectFramework(dir, priorityScripts); got != tt.want {
+				t.Errorf("detectFramework() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDetectScriptPort verifies port extraction from dev script flags.
+func TestDetectScriptPort(t *testing.T) {
+	tests := []struct {
+		script    string
+		wantPort  int
+		wantFixed bool
+	}{
+		{"vite --port 4000", 4000, true},
+		{"next dev -p 4001", 4001, true},
+		{"astro dev --port=4002 --host", 4002, true},
+		{"next dev -p $PORT", 0, false},
+		{"vite --port ${PORT:-3000}", 0, false},
+		{"tsc -p tsconfig.json && node dist", 0, false},
+		{"tsc -p tsconfig.json && vite --port 4003", 4003, true},
+		{"vite --port $PORT --port 70000 -p 4004", 4004, true},
+		{"vite", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.script, func(t *testing.T) {
+			dir := t.TempDir()
+			writePackageJSON(t, dir, "app", map[string]string{"dev": tt.script})
+			port, fixed := detectScriptPort(dir, priorityScripts)
+			if port != tt.wantPort || fixed != tt.wantFixed {
+				t.Errorf("detectScriptPort(%q) = (%d, %v), want (%d, %v)", tt.script, port, fixed, tt.wantPort, tt.wantFixed)
+			}
+		})
+	}
+}
+
+func TestDetectPort_ProjectConfig(t *testing.T) {
+	dir := t.TempDir()
+	writePackageJSON(t, dir, "app", map[string]string{"dev": "vite"})
+	os.WriteFile(filepath.Join(dir, ".devdash.json"), []byte(`{"port":4100}`), 0644)
+	if port, fixed := detectPort(dir, priorityScripts); port != 4100 || fixed {
+		t.Errorf("detectPort = (%d, %v), want (4100, false)", port, fixed)
+	}
+
+	// A port flag in the script still wins
+	writePackageJSON(t, dir, "app", map[string]string{"dev": "vite --port 4000"})
+	if port, fixed := detectPort(dir, priorityScripts); port != 4000 || !fixed {
+		t.Errorf("detectPort = (%d, %v), want (4000, true)", port, fixed)
+	}
+}
+
+func TestDetectNodeVersion(t *testing.T) {
+	root := t.TempDir()
+	app := filepath.Join(root, "apps", "web")
+	os.MkdirAll(app, 0755)
+
+	if got := detectNodeVersion(app); got != "" {
+		t.Errorf("no version files: got %q", got)
+	}
+
+	// Inherited from the repo root
+	os.WriteFile(filepath.Join(root, ".nvmrc"), []byte("v20.11.1\n# comment\n"), 0644)
+	if got := detectNodeVersion(app); got != "20.11.1" {
+		t.Errorf("root .nvmrc: got %q, want 20.11.1", got)
+	}
+
+	// Nearest file wins
+	os.WriteFile(filepath.Join(app, ".node-version"), []byte("18\n"), 0644)
+	if got := detectNodeVersion(app); got != "18" {
+		t.Errorf("app .node-version: got %q, want 18", got)
+	}
+}
+
+func TestDetectToolVersions(t *testing.T) {
+	root := t.TempDir()
+	app := filepath.Join(root, "apps", "web")
+	os.MkdirAll(app, 0755)
+
+	if got := detectToolVersions(app); got != nil {
+		t.Errorf("no .tool-versions: got %v", got)
+	}
+
+	// Unknown tools are dropped, fallback versions ignored, comments stripped
+	os.WriteFile(filepath.Join(root, ".tool-versions"), []byte("nodejs 20.11.1 18.19.0\n# tools\nshellcheck 0.9.0\npython 3.12.1 # api\n"), 0644)
+	got := detectToolVersions(app)
+	want := []ToolVersion{{"nodejs", "20.11.1"}, {"python", "3.12.1"}}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("root .tool-versions: got %v, want %v", got, want)
+	}
+	if v := detectNodeVersion(app); v != "20.11.1" {
+		t.Errorf("Node version from .tool-versions: got %q, want 20.11.1", v)
+	}
+
+	// An .nvmrc in the same directory comes first
+	os.WriteFile(filepath.Join(root, ".nvmrc"), []byte("22\n"), 0644)
+	if v := detectNodeVersion(app); v != "22" {
+		t.Errorf(".nvmrc next to .tool-versions: got %q, want 22", v)
+	}
+}
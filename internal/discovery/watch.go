@@ -0,0 +1,211 @@
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultWatchDebounce is how long a Watcher waits for changes to settle
+// before reporting them, so a large git operation triggers one rescan
+const DefaultWatchDebounce = 500 * time.Millisecond
+
+// Watcher reports when the worktrees under the scan directories may have
+// changed: a repo or worktree directory added or removed, a linked worktree
+// registered or pruned, or a branch checked out. It watches the directories
+// ScanWorktrees walks, plus the git dir of each repo it finds; the set is
+// brought up to date after each change.
+type Watcher struct {
+	fs       *fsnotify.Watcher
+	debounce time.Duration
+	changes  chan struct{}
+
+	mu       sync.Mutex
+	scanDirs []string
+	dirsGen  int             // bumped by SetScanDirs; a sync walked for older dirs isn't applied
+	watched  map[string]bool // directories being watched
+	gitDirs  map[string]bool // the git dirs among them
+}
+
+// NewWatcher starts watching scanDirs. Changes are reported on Changes once
+// nothing has changed for debounce.
+func NewWatcher(scanDirs []string, debounce time.Duration) (*Watcher, error) {
+	fs, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	w := &Watcher{
+		fs:       fs,
+		debounce: debounce,
+		changes:  make(chan struct{}, 1),
+		scanDirs: scanDirs,
+		watched:  make(map[string]bool),
+		gitDirs:  make(map[string]bool),
+	}
+	w.sync()
+	go w.loop()
+	return w, nil
+}
+
+// Changes delivers a value after each settled burst of changes. Bursts that
+// happen while the previous one is unread are merged into it.
+func (w *Watcher) Changes() <-chan struct{} {
+	return w.changes
+}
+
+// SetScanDirs switches the watched scan directories, e.g. after settings
+// changed them
+func (w *Watcher) SetScanDirs(scanDirs []string) {
+	w.mu.Lock()
+	w.scanDirs = scanDirs
+	w.dirsGen++
+	w.mu.Unlock()
+	w.sync()
+}
+
+// Close stops watching; Changes is never delivered to again
+func (w *Watcher) Close() error {
+	return w.fs.Close()
+}
+
+// loop debounces relevant events into Changes until the watcher is closed
+func (w *Watcher) loop() {
+	timer := time.NewTimer(w.debounce)
+	timer.Stop()
+	for {
+		select {
+		case ev, ok := <-w.fs.Events:
+			if !ok {
+				timer.Stop()
+				return
+			}
+			if w.relevant(ev) {
+				timer.Reset(w.debounce)
+			}
+		case _, ok := <-w.fs.Errors:
+			if !ok {
+				timer.Stop()
+				return
+			}
+		case <-timer.C:
+			w.sync()
+			select {
+			case w.changes <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+// relevant reports whether ev can change the scan result. In a git dir only
+// HEAD (a checkout) and worktrees (a linked worktree added) count, so commits
+// and fetches don't trigger rescans; elsewhere any entry added, removed or
+// renamed does.
+func (w *Watcher) relevant(ev fsnotify.Event) bool {
+	if !ev.Has(fsnotify.Create) && !ev.Has(fsnotify.Remove) && !ev.Has(fsnotify.Rename) {
+		return false
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.gitDirs[filepath.Dir(ev.Name)] {
+		base := filepath.Base(ev.Name)
+		return base == "HEAD" || base == "worktrees"
+	}
+	return true
+}
+
+// sync watches the directories the current scan dirs need and stops
+// watching the rest. Directories that can't be watched are skipped. The walk
+// runs unlocked, so relevant isn't held up by it on big trees.
+func (w *Watcher) sync() {
+	w.mu.Lock()
+	scanDirs, gen := w.scanDirs, w.dirsGen
+	w.mu.Unlock()
+
+	want := make(map[string]bool)
+	gitDirs := make(map[string]bool)
+	for _, dir := range scanDirs {
+		dir = expandHome(dir)
+		if abs, err := filepath.Abs(dir); err == nil {
+			dir = abs
+		}
+		collectWatchDirs(dir, want, gitDirs, 0, 2)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if gen != w.dirsGen {
+		return // SetScanDirs changed them meanwhile and syncs again
+	}
+	for dir := range w.watched {
+		if !want[dir] {
+			_ = w.fs.Remove(dir)
+			delete(w.watched, dir)
+		}
+	}
+	for dir := range want {
+		if !w.watched[dir] && w.fs.Add(dir) == nil {
+			w.watched[dir] = true
+		}
+	}
+	w.gitDirs = gitDirs
+}
+
+// collectWatchDirs adds dir and the directories below it that ScanWorktrees
+// would walk to want, down to the same depth. A repo contributes its git dir,
+// the git dir's worktrees directory, and each linked worktree's git dir (for
+// its HEAD) instead of its contents.
+func collectWatchDirs(dir string, want, gitDirs map[string]bool, depth, maxDepth int) {
+	info, err := os.Stat(dir)
+	if err != nil || !info.IsDir() {
+		return
+	}
+	gitDir := resolveGitDir(dir)
+	if gitDir != "" {
+		addGitWatchDirs(gitDir, want, gitDirs)
+	}
+	// Like ScanWorktrees, don't descend into repos, except a scan dir that
+	// may be a monorepo root
+	if depth > maxDepth || (gitDir != "" && depth > 0) {
+		return
+	}
+	want[dir] = true
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") || name == "node_modules" {
+			continue
+		}
+		if entry.IsDir() || entry.Type()&os.ModeSymlink != 0 {
+			collectWatchDirs(filepath.Join(dir, name), want, gitDirs, depth+1, maxDepth)
+		}
+	}
+}
+
+// addGitWatchDirs adds a repo's git dir and its linked worktrees' git dirs
+func addGitWatchDirs(gitDir string, want, gitDirs map[string]bool) {
+	want[gitDir] = true
+	gitDirs[gitDir] = true
+
+	linked := filepath.Join(gitDir, "worktrees")
+	entries, err := os.ReadDir(linked)
+	if err != nil {
+		return
+	}
+	want[linked] = true
+	for _, entry := range entries {
+		if entry.IsDir() {
+			wtGitDir := filepath.Join(linked, entry.Name())
+			want[wtGitDir] = true
+			gitDirs[wtGitDir] = true
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatcherReportsWorktreeChanges(t *testing.T) {
+	scan := t.TempDir()
+	w, err := NewWatcher([]string{scan}, 20*time.Millisecond)
+	if err != nil {
+		t.Skipf("watching unavailable: %v", err)
+	}
+	defer w.Close()
+
+	expect := func(what string, want bool) {
+		t.Helper()
+		select {
+		case <-w.Changes():
+			if !want {
+				t.Errorf("%s: unexpected change", what)
+			}
+		case <-time.After(500 * time.Millisecond):
+			if want {
+				t.Errorf("%s: no change reported", what)
+			}
+		}
+	}
+
+	// A new repo in the scan dir
+	gitDir := filepath.Join(scan, "app", ".git")
+	if err := os.MkdirAll(gitDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	expect("repo added", true)
+
+	// Git bookkeeping in the repo's git dir is ignored...
+	if err := os.WriteFile(filepath.Join(gitDir, "index"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	expect("index written", false)
+
+	// ...but a checkout and a linked worktree are not
+	if err := os.WriteFile(filepath.Join(gitDir, "HEAD"), []byte("ref: refs/heads/main\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	expect("HEAD written", true)
+	if err := os.MkdirAll(filepath.Join(gitDir, "worktrees", "feature"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	expect("worktree registered", true)
+
+	// Repos aren't descended into
+	if err := os.Mkdir(filepath.Join(scan, "app", "src"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	expect("dir inside a repo", false)
+}
@@ -22,7 +22,8 @@ type Worktree struct {
 }
 
 // ScanWorktrees discovers git repositories within the given scan directories.
-// Scans up to 2 levels deep. For each scan dir:
+// Symlinked directories are followed, and repos are deduplicated by their
+// resolved path. Scans up to 2 levels deep. For each scan dir:
 //   - Scans children recursively for directories containing .git
 //   - If the scan dir itself is a git repo but contains no child git repos,
 //     it is treated as a standalone worktree
@@ -45,13 +46,19 @@ func ScanWorktrees(scanDirs []string) []Worktree {
 			continue
 		}
 
+		// Dedupe by physical location so symlinked scan dirs don't produce duplicates
+		realPath, ok := resolvePath(absPath)
+		if !ok {
+			continue
+		}
+
 		// Scan children recursively for git repos (up to depth 2)
 		beforeCount := len(worktrees)
 		collectGitRepos(absPath, "", &worktrees, seen, 0, 2)
 
 		// If nothing found inside AND the dir itself is a git repo, add it as a standalone worktree
-		if len(worktrees) == beforeCount && isGitRepo(absPath) && !seen[absPath] {
-			seen[absPath] = true
+		if len(worktrees) == beforeCount && isGitRepo(absPath) && !seen[realPath] {
+			seen[realPath] = true
 			wt := Worktree{
 				Name:         filepath.Base(absPath),
 				Path:         absPath,
@@ -91,9 +98,6 @@ func collectGitRepos(dir, prefix string, worktrees *[]Worktree, seen map[string]
 	}
 
 	for _, entry := range entries {
-		if !entry.IsDir() {
-			continue
-		}
 		name := entry.Name()
 		if strings.HasPrefix(name, ".") || name == "node_modules" {
 			continue
@@ -105,14 +109,31 @@ func collectGitRepos(dir, prefix string, worktrees *[]Worktree, seen map[string]
 			absPath = childPath
 		}
 
+		// DirEntry reports symlinks as non-directories; follow them explicitly.
+		// Broken links and loops fail to resolve and are skipped.
+		if !entry.IsDir() {
+			if entry.Type()&os.ModeSymlink == 0 {
+				continue
+			}
+			info, err := os.Stat(absPath)
+			if err != nil || !info.IsDir() {
+				continue
+			}
+		}
+
+		realPath, ok := resolvePath(absPath)
+		if !ok {
+			continue
+		}
+
 		displayName := name
 		if prefix != "" {
 			displayName = prefix + "/" + name
 		}
 
 		if isGitRepo(absPath) {
-			if !seen[absPath] {
-				seen[absPath] = true
+			if !seen[realPath] {
+				seen[realPath] = true
 				wt := Worktree{
 					Name:         displayName,
 					Path:         absPath,
@@ -125,30 +146,142 @@ func collectGitRepos(dir, prefix string, worktrees *[]Worktree, seen map[string]
 			continue // don't recurse into git repos
 		}
 
-		// Not a git repo — recurse deeper
+		// Not a git repo — recurse deeper. Symlinks back to an ancestor are
+		// bounded by maxDepth, and repeated repos are caught by seen.
 		collectGitRepos(absPath, displayName, worktrees, seen, depth+1, maxDepth)
 	}
 }
 
+// resolvePath returns the symlink-free absolute path for dir.
+// Returns false for broken links or symlink loops so callers can skip the entry.
+func resolvePath(dir string) (string, bool) {
+	real, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", false
+	}
+	return real, true
+}
+
 // isGitRepo checks if a directory contains .git (file or directory)
 func isGitRepo(dir string) bool {
 	_, err := os.Stat(filepath.Join(dir, ".git"))
 	return err == nil
 }
 
-// detectBranch runs "git branch --show-current" to get the current branch
+// detectBranch returns a label for the checked-out ref: the branch name, or
+// the short commit SHA plus "(detached)" for a detached HEAD. An operation in
+// progress replaces the detached marker, e.g. "feature (rebasing)" or
+// "a1b2c3d (bisecting)". Read from the git dir without spawning git; falls
+// back to git when HEAD can't be read, and to "unknown" on any error.
 func detectBranch(dir string) string {
-	cmd := exec.Command("git", "branch", "--show-current")
+	gitDir := resolveGitDir(dir)
+	head, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if gitDir == "" || err != nil {
+		return detectBranchWithGit(dir)
+	}
+
+	ref := strings.TrimSpace(string(head))
+	branch, detached := strings.CutPrefix(ref, "ref: refs/heads/")
+	detached = !detached
+	if detached {
+		branch = shortSHA(ref)
+	}
+
+	op, opBranch := gitOperation(gitDir)
+	if opBranch != "" {
+		branch = opBranch // a rebase detaches HEAD; show the branch being rebased
+	}
+	switch {
+	case op != "":
+		return branch + " (" + op + ")"
+	case detached:
+		return branch + " (detached)"
+	}
+	return branch
+}
+
+// detectBranchWithGit is detectBranch via the git CLI, for layouts where
+// HEAD can't be read directly
+func detectBranchWithGit(dir string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", "branch", "--show-current")
 	cmd.Dir = dir
 	out, err := cmd.Output()
 	if err != nil {
 		return "unknown"
 	}
-	branch := strings.TrimSpace(string(out))
-	if branch == "" {
+	if branch := strings.TrimSpace(string(out)); branch != "" {
+		return branch
+	}
+	cmd = exec.CommandContext(ctx, "git", "rev-parse", "--short", "HEAD")
+	cmd.Dir = dir
+	if out, err = cmd.Output(); err != nil {
 		return "detached"
 	}
-	return branch
+	return strings.TrimSpace(string(out)) + " (detached)"
+}
+
+// resolveGitDir returns the git dir of the repo at dir: dir/.git itself, or
+// for a linked worktree the directory its .git file points to; "" if neither
+func resolveGitDir(dir string) string {
+	gitPath := filepath.Join(dir, ".git")
+	info, err := os.Stat(gitPath)
+	if err != nil {
+		return ""
+	}
+	if info.IsDir() {
+		return gitPath
+	}
+	data, err := os.ReadFile(gitPath)
+	if err != nil {
+		return ""
+	}
+	gitdir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return ""
+	}
+	gitdir = strings.TrimSpace(gitdir)
+	if !filepath.IsAbs(gitdir) {
+		gitdir = filepath.Join(dir, gitdir)
+	}
+	return gitdir
+}
+
+// gitOperation reports an in-progress rebase, merge, cherry-pick, revert, or
+// bisect in gitDir. For a rebase it also returns the branch being rebased.
+func gitOperation(gitDir string) (op, branch string) {
+	for _, d := range []string{"rebase-merge", "rebase-apply"} {
+		if _, err := os.Stat(filepath.Join(gitDir, d)); err == nil {
+			if name, err := os.ReadFile(filepath.Join(gitDir, d, "head-name")); err == nil {
+				branch = strings.TrimPrefix(strings.TrimSpace(string(name)), "refs/heads/")
+				if branch == "detached HEAD" {
+					branch = ""
+				}
+			}
+			return "rebasing", branch
+		}
+	}
+	markers := []struct{ file, op string }{
+		{"MERGE_HEAD", "merging"},
+		{"CHERRY_PICK_HEAD", "cherry-picking"},
+		{"REVERT_HEAD", "reverting"},
+		{"BISECT_LOG", "bisecting"},
+	}
+	for _, m := range markers {
+		if _, err := os.Stat(filepath.Join(gitDir, m.file)); err == nil {
+			return m.op, ""
+		}
+	}
+	return "", ""
+}
+
+// shortSHA abbreviates a commit hash to git's default 7 characters
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
 }
 
 // detectWorktreeInfo checks if the directory is a git worktree (not a main repo).
@@ -246,17 +379,32 @@ func discoverLinkedWorktrees(mainRepoPath string, seen map[string]bool) []Worktr
 		return nil
 	}
 
+	mainReal, ok := resolvePath(mainRepoPath)
+	if !ok {
+		mainReal = mainRepoPath
+	}
+
 	entries := parseWorktreeListOutput(string(out))
 	var result []Worktree
 	for _, e := range entries {
-		if seen[e.Path] || e.Path == mainRepoPath {
+		realPath, ok := resolvePath(e.Path)
+		if !ok {
+			continue // worktree dir removed but not pruned
+		}
+		if seen[realPath] || realPath == mainReal {
 			continue
 		}
-		seen[e.Path] = true
+		seen[realPath] = true
+		// The porcelain output has no branch for a detached HEAD and doesn't
+		// mention rebases or merges, so prefer the richer label
+		branch := detectBranch(e.Path)
+		if branch == "unknown" && e.Branch != "" {
+			branch = e.Branch
+		}
 		wt := Worktree{
 			Name:         filepath.Base(e.Path),
 			Path:         e.Path,
-			Branch:       e.Branch,
+			Branch:       branch,
 			LastModified: detectLastCommit(e.Path),
 			IsWorktree:   true,
 			MainProject:  filepath.Base(mainRepoPath),
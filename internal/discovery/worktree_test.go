@@ -1,6 +1,8 @@
 package discovery
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -56,3 +58,83 @@ detached
 		t.Errorf("expected empty branch for detached HEAD, got %s", wts[0].Branch)
 	}
 }
+
+// TestScanWorktrees_SymlinkDedup verifies that a repo reachable both directly
+// and via a symlink is reported once, and that a symlink loop doesn't break the scan.
+func TestScanWorktrees_SymlinkDedup(t *testing.T) {
+	scan := t.TempDir()
+	repo := filepath.Join(scan, "app")
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(repo, filepath.Join(scan, "app-link")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(scan, "loop"), filepath.Join(scan, "loop")); err != nil {
+		t.Fatal(err)
+	}
+
+	wts := ScanWorktrees([]string{scan})
+	if len(wts) != 1 {
+		t.Fatalf("expected 1 worktree, got %d: %+v", len(wts), wts)
+	}
+}
+
+// TestScanWorktrees_SymlinkedRepo verifies that a repo only reachable via a symlink is found.
+func TestScanWorktrees_SymlinkedRepo(t *testing.T) {
+	elsewhere := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(elsewhere, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	scan := t.TempDir()
+	if err := os.Symlink(elsewhere, filepath.Join(scan, "linked")); err != nil {
+		t.Fatal(err)
+	}
+
+	wts := ScanWorktrees([]string{scan})
+	if len(wts) != 1 || wts[0].Name != "linked" {
+		t.Fatalf("expected linked repo, got %+v", wts)
+	}
+}
+
+// TestDetectBranch verifies branch labels read from the git dir: plain
+// branches, detached HEADs, and in-progress operations, for repos and linked worktrees.
+func TestDetectBranch(t *testing.T) {
+	repo := t.TempDir()
+	gitDir := filepath.Join(repo, ".git")
+	os.MkdirAll(gitDir, 0755)
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(gitDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("HEAD", "ref: refs/heads/feature/login\n")
+	if got := detectBranch(repo); got != "feature/login" {
+		t.Errorf("branch: got %q", got)
+	}
+
+	write("HEAD", "a1b2c3d4e5f60718293a4b5c6d7e8f9012345678\n")
+	if got := detectBranch(repo); got != "a1b2c3d (detached)" {
+		t.Errorf("detached: got %q", got)
+	}
+
+	os.MkdirAll(filepath.Join(gitDir, "rebase-merge"), 0755)
+	write("rebase-merge/head-name", "refs/heads/feature/login\n")
+	if got := detectBranch(repo); got != "feature/login (rebasing)" {
+		t.Errorf("rebase: got %q", got)
+	}
+	os.RemoveAll(filepath.Join(gitDir, "rebase-merge"))
+
+	// Linked worktree: .git file pointing at a per-worktree git dir
+	wtGitDir := filepath.Join(gitDir, "worktrees", "hotfix")
+	os.MkdirAll(wtGitDir, 0755)
+	os.WriteFile(filepath.Join(wtGitDir, "HEAD"), []byte("ref: refs/heads/hotfix\n"), 0644)
+	os.WriteFile(filepath.Join(wtGitDir, "MERGE_HEAD"), []byte("abc\n"), 0644)
+	wt := t.TempDir()
+	os.WriteFile(filepath.Join(wt, ".git"), []byte("gitdir: "+wtGitDir+"\n"), 0644)
+	if got := detectBranch(wt); got != "hotfix (merging)" {
+		t.Errorf("worktree merge: got %q", got)
+	}
+}
@@ -23,6 +23,7 @@ type GlobalConfig struct {
 	Branch       BranchSection        `toml:"branch"`
 	Agents       map[string]AgentConf `toml:"agents"`
 	Hooks        HooksConfig          `toml:"hooks"`
+	CleanLogs    bool                 `toml:"clean_logs"` // keep a sanitized <session>.clean.log next to each raw agent log
 }
 
 type AgentConf struct {
@@ -32,6 +33,7 @@ type AgentConf struct {
 	Detect      string   `toml:"detect"`
 	Interactive bool     `toml:"interactive"`
 	ResumeFlag  string   `toml:"resume_flag"`
+	NoPTY       bool     `toml:"no_pty"` // run on plain pipes instead of a pseudo-terminal (no input)
 }
 
 // LoadGlobalConfig reads config.toml from the given config directory.
@@ -12,6 +12,7 @@ func TestLoadGlobalConfig(t *testing.T) {
 default_agent = "claude"
 mode = "supervised"
 scan_dirs = ["~/x/simplx", "~/x/other"]
+clean_logs = true
 
 [branch]
 template = "{type}/{taskId}/{slug}"
@@ -31,6 +32,7 @@ name = "Codex CLI"
 command = "codex"
 args = []
 detect = "which codex"
+no_pty = true
 `
 	os.WriteFile(filepath.Join(dir, "config.toml"), []byte(tomlContent), 0o644)
 
@@ -45,6 +47,9 @@ detect = "which codex"
 	if len(cfg.ScanDirs) != 2 {
 		t.Fatalf("scan_dirs count = %d, want 2", len(cfg.ScanDirs))
 	}
+	if !cfg.CleanLogs {
+		t.Error("clean_logs should be true")
+	}
 	if cfg.ScanDirs[0] != "~/x/simplx" {
 		t.Errorf("scan_dirs[0] = %q, want %q", cfg.ScanDirs[0], "~/x/simplx")
 	}
@@ -61,6 +66,9 @@ detect = "which codex"
 	if claude.ResumeFlag != "--resume" {
 		t.Errorf("claude resume_flag = %q, want %q", claude.ResumeFlag, "--resume")
 	}
+	if claude.NoPTY || !cfg.Agents["codex"].NoPTY {
+		t.Errorf("no_pty: claude %v, codex %v; want false, true", claude.NoPTY, cfg.Agents["codex"].NoPTY)
+	}
 }
 
 func TestLoadGlobalConfigDefaults(t *testing.T) {
@@ -40,7 +40,7 @@ func TestIntegration_ReadPTY_Scrollback(t *testing.T) {
 	// Run readPTY in goroutine (same as production code)
 	done := make(chan struct{})
 	go func() {
-		readPTY(ptyFile, logFile, vterm, sc, stop)
+		readPTY(ptyFile, logFile, nil, vterm, sc, stop)
 		close(done)
 	}()
 
@@ -136,7 +136,7 @@ done`
 
 	done := make(chan struct{})
 	go func() {
-		readPTY(ptyFile, logFile, vterm, sc, stop)
+		readPTY(ptyFile, logFile, nil, vterm, sc, stop)
 		close(done)
 	}()
 
@@ -204,7 +204,7 @@ func TestIntegration_ReadPTY_BulkOutput(t *testing.T) {
 
 	done := make(chan struct{})
 	go func() {
-		readPTY(ptyFile, logFile, vterm, sc, stop)
+		readPTY(ptyFile, logFile, nil, vterm, sc, stop)
 		close(done)
 	}()
 
@@ -239,3 +239,105 @@ func TestIntegration_ReadPTY_BulkOutput(t *testing.T) {
 		t.Errorf("%d out of %d lines missing spaces", spaceless, sbLen)
 	}
 }
+
+// TestIntegration_PTYStartsAtRequestedSize checks that a process reading the
+// terminal size at startup sees SessionInfo's size, not the 80x24 default
+func TestIntegration_PTYStartsAtRequestedSize(t *testing.T) {
+	rows, cols := SessionInfo{Rows: 40, Cols: 132}.ptySize()
+	cmd := exec.Command("stty", "size")
+	ptyFile, err := startWithPTY(cmd, rows, cols)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ptyFile.Close()
+
+	out := make(chan string, 1)
+	go func() {
+		var b strings.Builder
+		buf := make([]byte, 256)
+		for {
+			n, err := ptyFile.Read(buf)
+			b.Write(buf[:n])
+			if err != nil || strings.Contains(b.String(), "\n") {
+				break
+			}
+		}
+		out <- b.String()
+	}()
+	_ = cmd.Wait()
+
+	select {
+	case got := <-out:
+		if strings.TrimSpace(got) != "40 132" {
+			t.Errorf("stty size = %q, want \"40 132\"", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no output from stty")
+	}
+
+	if r, c := (SessionInfo{}).ptySize(); r != defaultPTYRows || c != defaultPTYCols {
+		t.Errorf("unset size = %dx%d, want the defaults", r, c)
+	}
+}
+
+// TestIntegration_NoPTYUsesPlainPipes starts a session without a terminal and
+// checks the child sees a pipe, output still reaches the VTerm line by line,
+// and there is no input writer
+func TestIntegration_NoPTYUsesPlainPipes(t *testing.T) {
+	pm := NewProcessManager(t.TempDir(), t.TempDir())
+	rp, err := pm.Start(SessionInfo{
+		Name:    "piped",
+		Command: "sh",
+		Args:    []string{"-c", "if [ -t 1 ]; then echo tty; else echo pipe; fi; echo second >&2"},
+		WorkDir: t.TempDir(),
+		NoPTY:   true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pm.Stop("piped")
+	<-rp.done
+
+	lines := strings.Split(rp.VTerm.Content(), "\n")
+	if len(lines) < 2 || lines[0] != "pipe" || lines[1] != "second" {
+		t.Errorf("screen = %q, want \"pipe\" then \"second\" at column 0", lines)
+	}
+	if rp.InputWriter() != nil {
+		t.Error("a NoPTY session has no input")
+	}
+}
+
+// TestIntegration_CleanLog checks that with CleanLogs set a session keeps the
+// raw output in its .log and a sanitized copy in its .clean.log
+func TestIntegration_CleanLog(t *testing.T) {
+	logsDir := t.TempDir()
+	pm := NewProcessManager(t.TempDir(), logsDir)
+	pm.CleanLogs = true
+	rp, err := pm.Start(SessionInfo{
+		Name:    "colored",
+		Command: "printf",
+		Args:    []string{`\033]0;title\007building 10%%\rbuilding 100%%\n\033[32mready\033[0m\n`},
+		WorkDir: t.TempDir(),
+		NoPTY:   true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pm.Stop("colored")
+	<-rp.done
+
+	raw, err := os.ReadFile(filepath.Join(logsDir, "colored.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(raw), "\x1b]0;title\abuilding 10%\r") {
+		t.Errorf("raw log should keep every byte, got %q", raw)
+	}
+	clean, err := os.ReadFile(filepath.Join(logsDir, "colored.clean.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(clean), "building 100%\n\x1b[32mready\x1b[0m\n"; got != want {
+		t.Errorf("clean log = %q, want %q", got, want)
+	}
+}
@@ -1,6 +1,7 @@
 package process
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"os"
@@ -50,16 +51,21 @@ func (pm *ProcessManager) StopProcessTunnel(name string) error {
 }
 
 // readPTY reads from PTY master and writes to logFile + VTerm via ScrollCapture.
-// logFile gets raw output for disk persistence.
+// logFile gets every byte of raw output, written before anything else sees it,
+// so the file on disk stays complete however much the in-memory history drops.
+// clean, if not nil, gets a second copy (a sanitizingWriter for .clean.log).
 // ScrollCapture feeds data to VTerm in sub-chunks and captures scrolled-off
 // lines as rendered text to the SegmentedLog history.
-func readPTY(ptyFile *os.File, logFile *os.File, vterm *VTermScreen, sc *ScrollCapture, stop <-chan struct{}) {
+func readPTY(ptyFile *os.File, logFile *os.File, clean io.Writer, vterm *VTermScreen, sc *ScrollCapture, stop <-chan struct{}) {
 	buf := make([]byte, 4096)
 	for {
 		n, err := ptyFile.Read(buf)
 		if n > 0 {
 			data := buf[:n]
 			logFile.Write(data)
+			if clean != nil {
+				clean.Write(data)
+			}
 			sc.ProcessChunk(vterm, data)
 		}
 		if err != nil {
@@ -74,21 +80,54 @@ func readPTY(ptyFile *os.File, logFile *os.File, vterm *VTermScreen, sc *ScrollC
 	}
 }
 
+// readPipe is readPTY for processes started with startWithPipes. Without a
+// terminal's output processing a bare "\n" doesn't return the cursor, so line
+// feeds are expanded to "\r\n" for the VTerm; logFile and clean get the raw bytes.
+func readPipe(pipe *os.File, logFile *os.File, clean io.Writer, vterm *VTermScreen, sc *ScrollCapture, stop <-chan struct{}) {
+	buf := make([]byte, 4096)
+	for {
+		n, err := pipe.Read(buf)
+		if n > 0 {
+			data := buf[:n]
+			logFile.Write(data)
+			if clean != nil {
+				clean.Write(data)
+			}
+			sc.ProcessChunk(vterm, bytes.ReplaceAll(data, []byte("\n"), []byte("\r\n")))
+		}
+		if err != nil {
+			return
+		}
+		select {
+		case <-stop:
+			return
+		default:
+		}
+	}
+}
+
 // sanitizingWriter wraps an io.Writer and sanitizes raw PTY data before writing.
 type sanitizingWriter struct {
-	w io.Writer
+	w     io.Writer
+	carry []byte // a rune cut off at the end of the last write
 }
 
-func (sw sanitizingWriter) Write(p []byte) (int, error) {
+func (sw *sanitizingWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	if len(sw.carry) > 0 {
+		p = append(sw.carry, p...)
+	}
+	p, rest := SplitIncompleteRune(p)
+	sw.carry = bytes.Clone(rest) // rest may alias the caller's buffer
 	_, err := sw.w.Write(sanitizeForLog(p))
-	return len(p), err
+	return n, err
 }
 
 // tailFile reads from a log file starting at offset and writes new content to w.
 // Polls the file for new data until stop is closed.
 // Data is sanitized through sanitizeForLog before writing.
 func tailFile(path string, w io.Writer, startOffset int64, stop <-chan struct{}) {
-	sw := sanitizingWriter{w: w}
+	sw := &sanitizingWriter{w: w}
 
 	f, err := os.Open(path)
 	if err != nil {
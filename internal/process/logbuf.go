@@ -4,11 +4,19 @@ import (
 	"bytes"
 	"strings"
 	"sync"
+	"time"
 )
 
 // DefaultMaxLines is the maximum number of lines kept in the ring buffer
 const DefaultMaxLines = 10000
 
+// Activity tracking: line counts per ActivityInterval over the last
+// ActivityBuckets intervals (one minute in total)
+const (
+	ActivityBuckets  = 12
+	ActivityInterval = 5 * time.Second
+)
+
 // LogBuffer is a thread-safe ring buffer for log lines.
 // It implements io.Writer so it can capture stdout/stderr from a process.
 type LogBuffer struct {
@@ -17,7 +25,12 @@ type LogBuffer struct {
 	maxLines int
 	total    int
 	subs     []chan string
-	partial  string // incomplete line from last Write call
+	partial  string               // incomplete line from last Write call
+	activity [ActivityBuckets]int // ring of line counts, indexed by slot % ActivityBuckets
+	lastSlot int64                // most recent slot written to activity
+	mark     int                  // value of total when the mark was set, -1 = no mark
+	lastLine time.Time            // when the most recent line was appended
+	appended int                  // lines ever appended; unlike total, never reduced by RemoveLastLines
 }
 
 // NewLogBuffer creates a new log buffer with the given max line capacity
@@ -28,6 +41,7 @@ func NewLogBuffer(maxLines int) *LogBuffer {
 	return &LogBuffer{
 		lines:    make([]string, 0, 256),
 		maxLines: maxLines,
+		mark:     -1,
 	}
 }
 
@@ -73,6 +87,9 @@ func (lb *LogBuffer) appendLine(line string) {
 	}
 	lb.lines = append(lb.lines, line)
 	lb.total++
+	lb.appended++
+	lb.lastLine = time.Now()
+	lb.recordActivity(lb.lastLine)
 
 	for _, ch := range lb.subs {
 		select {
@@ -83,6 +100,54 @@ func (lb *LogBuffer) appendLine(line string) {
 	}
 }
 
+// activitySlot returns the activity interval index for t
+func activitySlot(t time.Time) int64 {
+	return t.UnixNano() / int64(ActivityInterval)
+}
+
+// recordActivity counts one line in the bucket for now. Must be called with lock held.
+func (lb *LogBuffer) recordActivity(now time.Time) {
+	slot := activitySlot(now)
+	if slot > lb.lastSlot {
+		// Zero buckets for the intervals that passed without output
+		gap := slot - lb.lastSlot
+		if gap > ActivityBuckets {
+			gap = ActivityBuckets
+		}
+		for s := slot - gap + 1; s <= slot; s++ {
+			lb.activity[s%ActivityBuckets] = 0
+		}
+		lb.lastSlot = slot
+	}
+	if slot > lb.lastSlot-ActivityBuckets {
+		lb.activity[slot%ActivityBuckets]++
+	}
+}
+
+// Activity returns line counts per ActivityInterval for the last
+// ActivityBuckets intervals ending at now, oldest first
+func (lb *LogBuffer) Activity(now time.Time) []int {
+	lb.mu.RLock()
+	defer lb.mu.RUnlock()
+	slot := activitySlot(now)
+	out := make([]int, ActivityBuckets)
+	for i := range out {
+		s := slot - int64(ActivityBuckets-1-i)
+		if s <= lb.lastSlot && s > lb.lastSlot-ActivityBuckets {
+			out[i] = lb.activity[s%ActivityBuckets]
+		}
+	}
+	return out
+}
+
+// LastLineAt returns when the most recent line was appended, or the zero time
+// if nothing has been written yet
+func (lb *LogBuffer) LastLineAt() time.Time {
+	lb.mu.RLock()
+	defer lb.mu.RUnlock()
+	return lb.lastLine
+}
+
 // Lines returns a copy of all buffered lines, including any partial line
 func (lb *LogBuffer) Lines() []string {
 	lb.mu.RLock()
@@ -157,6 +222,81 @@ func (lb *LogBuffer) Content() string {
 	return buf.String()
 }
 
+// SetMark bookmarks the current end of the buffer; LinesSinceMark and
+// ContentSinceMark then only return lines appended afterwards
+func (lb *LogBuffer) SetMark() {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	lb.mark = lb.total
+}
+
+// ClearMark removes the bookmark so the full buffer is shown again
+func (lb *LogBuffer) ClearMark() {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	lb.mark = -1
+}
+
+// HasMark reports whether a bookmark is set
+func (lb *LogBuffer) HasMark() bool {
+	lb.mu.RLock()
+	defer lb.mu.RUnlock()
+	return lb.mark >= 0
+}
+
+// LinesSinceMark returns the lines appended after the mark (including any
+// partial line), or all lines when no mark is set
+func (lb *LogBuffer) LinesSinceMark() []string {
+	lb.mu.RLock()
+	defer lb.mu.RUnlock()
+	start := lb.markStart()
+	out := make([]string, len(lb.lines)-start)
+	copy(out, lb.lines[start:])
+	if lb.partial != "" {
+		out = append(out, lb.partial)
+	}
+	return out
+}
+
+// ContentSinceMark is Content limited to the lines after the mark
+func (lb *LogBuffer) ContentSinceMark() string {
+	return strings.Join(lb.LinesSinceMark(), "\n")
+}
+
+// MarkStart returns the buffer index of the first line LinesSinceMark
+// returns: 0 without a mark
+func (lb *LogBuffer) MarkStart() int {
+	lb.mu.RLock()
+	defer lb.mu.RUnlock()
+	return lb.markStart()
+}
+
+// markStart returns the index in lines of the first line after the mark.
+// total counts every line ever appended, so lines[0] is line number
+// total-len(lines); lines evicted since the mark are simply gone.
+// Must be called with lock held.
+func (lb *LogBuffer) markStart() int {
+	if lb.mark < 0 {
+		return 0
+	}
+	start := lb.mark - (lb.total - len(lb.lines))
+	if start < 0 {
+		return 0
+	}
+	if start > len(lb.lines) {
+		return len(lb.lines)
+	}
+	return start
+}
+
+// Appended returns how many lines were ever appended, including ones since
+// evicted or erased by a terminal redraw. It only grows, so it suits a counter.
+func (lb *LogBuffer) Appended() int {
+	lb.mu.RLock()
+	defer lb.mu.RUnlock()
+	return lb.appended
+}
+
 // Len returns the number of lines currently in the buffer
 func (lb *LogBuffer) Len() int {
 	lb.mu.RLock()
@@ -175,6 +315,10 @@ func (lb *LogBuffer) RemoveLastLines(n int) {
 		remove = len(lb.lines)
 	}
 	lb.lines = lb.lines[:len(lb.lines)-remove]
+	lb.total -= remove
+	if lb.mark > lb.total {
+		lb.mark = lb.total
+	}
 }
 
 // ClearPartial discards the current partial (incomplete) line.
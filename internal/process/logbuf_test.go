@@ -0,0 +1,107 @@
+package process
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLogBufferActivity(t *testing.T) {
+	lb := NewLogBuffer(100)
+	base := time.Unix(0, 0).Add(1000 * ActivityInterval)
+
+	lb.recordActivity(base)
+	lb.recordActivity(base)
+	lb.recordActivity(base.Add(2 * ActivityInterval))
+
+	got := lb.Activity(base.Add(3 * ActivityInterval))
+	want := make([]int, ActivityBuckets)
+	want[ActivityBuckets-4] = 2
+	want[ActivityBuckets-2] = 1
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Activity = %v, want %v", got, want)
+	}
+}
+
+func TestLogBufferActivity_ExpiresOldBuckets(t *testing.T) {
+	lb := NewLogBuffer(100)
+	base := time.Unix(0, 0).Add(1000 * ActivityInterval)
+
+	lb.recordActivity(base)
+	// A full window later, the old count must not reappear in a reused bucket
+	lb.recordActivity(base.Add(ActivityBuckets * ActivityInterval))
+
+	got := lb.Activity(base.Add(ActivityBuckets * ActivityInterval))
+	want := make([]int, ActivityBuckets)
+	want[ActivityBuckets-1] = 1
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Activity = %v, want %v", got, want)
+	}
+
+	if idle := lb.Activity(base.Add(3 * ActivityBuckets * ActivityInterval)); !reflect.DeepEqual(idle, make([]int, ActivityBuckets)) {
+		t.Errorf("expected idle activity, got %v", idle)
+	}
+}
+
+func TestLogBufferMark(t *testing.T) {
+	lb := NewLogBuffer(5)
+	lb.Write([]byte("a\nb\n"))
+
+	if lb.HasMark() {
+		t.Fatal("new buffer should have no mark")
+	}
+	if got := lb.LinesSinceMark(); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("without mark = %v, want all lines", got)
+	}
+
+	lb.SetMark()
+	if got := lb.LinesSinceMark(); len(got) != 0 {
+		t.Errorf("right after mark = %v, want none", got)
+	}
+
+	lb.Write([]byte("c\nd\npartial"))
+	if got := lb.ContentSinceMark(); got != "c\nd\npartial" {
+		t.Errorf("ContentSinceMark = %q", got)
+	}
+
+	// Evicting pre-mark lines must not shift the window
+	lb.Flush()
+	lb.Write([]byte("e\nf\n"))
+	if got := lb.LinesSinceMark(); !reflect.DeepEqual(got, []string{"c", "d", "partial", "e", "f"}) {
+		t.Errorf("after eviction = %v", got)
+	}
+
+	lb.ClearMark()
+	if got := lb.LinesSinceMark(); len(got) != 5 {
+		t.Errorf("after ClearMark = %v, want the full buffer", got)
+	}
+}
+
+func TestLogBufferMark_RemoveLastLines(t *testing.T) {
+	lb := NewLogBuffer(100)
+	lb.Write([]byte("a\nb\n"))
+	lb.SetMark()
+	lb.Write([]byte("c\n"))
+	lb.RemoveLastLines(2)
+	lb.Write([]byte("x\n"))
+
+	if got := lb.LinesSinceMark(); !reflect.DeepEqual(got, []string{"x"}) {
+		t.Errorf("LinesSinceMark = %v, want [x]", got)
+	}
+}
+
+func TestLogBufferLastLineAt(t *testing.T) {
+	lb := NewLogBuffer(100)
+	if !lb.LastLineAt().IsZero() {
+		t.Fatal("empty buffer should have zero LastLineAt")
+	}
+	before := time.Now()
+	lb.Write([]byte("partial"))
+	if !lb.LastLineAt().IsZero() {
+		t.Error("a partial line should not count as output")
+	}
+	lb.Write([]byte("\n"))
+	if got := lb.LastLineAt(); got.Before(before) {
+		t.Errorf("LastLineAt = %v, want >= %v", got, before)
+	}
+}
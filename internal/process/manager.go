@@ -92,6 +92,7 @@ type ProcessManager struct {
 	logsDir     string
 	pnpmPath    string
 	OnExit      func(key string, exitCode int) // called from goroutine when a process exits
+	CleanLogs   bool                           // also keep a sanitized <name>.clean.log (PTY and pipe sessions)
 }
 
 // NewProcessManager creates a new manager.
@@ -116,6 +117,25 @@ func (pm *ProcessManager) logFilePath(name string) string {
 	return filepath.Join(pm.logsDir, safe+".log")
 }
 
+// cleanLogFilePath returns the path of a session's sanitized log copy
+func (pm *ProcessManager) cleanLogFilePath(name string) string {
+	return strings.TrimSuffix(pm.logFilePath(name), ".log") + ".clean.log"
+}
+
+// createCleanLog opens the sanitized log copy when CleanLogs is set. Returns
+// nil when it is off or the file can't be created; the raw log is unaffected.
+func (pm *ProcessManager) createCleanLog(name string) *os.File {
+	if !pm.CleanLogs {
+		return nil
+	}
+	f, err := os.Create(pm.cleanLogFilePath(name))
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "warning: failed to create clean log for %q: %v\n", name, err)
+		return nil
+	}
+	return f
+}
+
 // Start spawns a new process based on the given SessionInfo
 func (pm *ProcessManager) Start(info SessionInfo) (*RunningProcess, error) {
 	pm.mu.Lock()
@@ -129,15 +149,18 @@ func (pm *ProcessManager) Start(info SessionInfo) (*RunningProcess, error) {
 	logBuf := NewSegmentedLog(scrollbackDir, DefaultMaxLines)
 	logBuf.Reset() // Clear stale scrollback from previous sessions
 
-	// Try tmux first, fall back to PTY+VTerm+ScrollCapture
-	if IsTmuxAvailable() {
+	rows, cols := info.ptySize()
+
+	// Try tmux first, fall back to PTY+VTerm+ScrollCapture. tmux is a terminal
+	// too, so NoPTY sessions skip it.
+	if IsTmuxAvailable() && !info.NoPTY {
 		// Create log file for pipe-pane output
 		if err := os.MkdirAll(pm.logsDir, 0o755); err == nil {
 			logPath := pm.logFilePath(info.Name)
 			if logFile, err := os.Create(logPath); err == nil {
 				logFile.Close() // pipe-pane will append to this file
 
-				ts, err := StartTmuxSession(info.Name, int(defaultPTYRows), int(defaultPTYCols),
+				ts, err := StartTmuxSession(info.Name, int(rows), int(cols),
 					info.Command, info.Args, info.WorkDir, info.ExtraEnv, logPath, logBuf)
 				if err == nil {
 					info.StartedAt = time.Now().Unix()
@@ -178,14 +201,21 @@ func (pm *ProcessManager) Start(info SessionInfo) (*RunningProcess, error) {
 	cmd.Dir = info.WorkDir
 	cmd.Env = append(os.Environ(), info.ExtraEnv...)
 
-	// Start with PTY so child process sees a real TTY (enables interactive prompts)
-	ptyFile, err := startWithPTY(cmd, defaultPTYRows, defaultPTYCols)
+	// Start with PTY so child process sees a real TTY (enables interactive prompts).
+	// NoPTY sessions get a plain pipe and no input.
+	var ptyFile, output *os.File
+	if info.NoPTY {
+		output, err = startWithPipes(cmd)
+	} else {
+		ptyFile, err = startWithPTY(cmd, rows, cols)
+		output = ptyFile
+	}
 	if err != nil {
 		logFile.Close()
 		os.Remove(logPath)
 		return nil, fmt.Errorf("failed to start %q: %w", info.Name, err)
 	}
-	vterm := NewVTermScreen(int(defaultPTYRows), int(defaultPTYCols))
+	vterm := NewVTermScreen(int(rows), int(cols))
 
 	info.PID = cmd.Process.Pid
 	info.StartedAt = time.Now().Unix()
@@ -194,9 +224,14 @@ func (pm *ProcessManager) Start(info SessionInfo) (*RunningProcess, error) {
 		_, _ = fmt.Fprintf(os.Stderr, "warning: failed to save session %q: %v\n", info.Name, err)
 	}
 
-	scrollCap := NewScrollCapture(int(defaultPTYRows), logBuf)
+	scrollCap := NewScrollCapture(int(rows), logBuf)
 	tailStop := make(chan struct{})
 	done := make(chan struct{})
+	cleanLog := pm.createCleanLog(info.Name)
+	var clean io.Writer
+	if cleanLog != nil {
+		clean = &sanitizingWriter{w: cleanLog}
+	}
 
 	rp := &RunningProcess{
 		Info:      info,
@@ -213,17 +248,21 @@ func (pm *ProcessManager) Start(info SessionInfo) (*RunningProcess, error) {
 	}
 	pm.processes[info.Name] = rp
 
-	// Read PTY output into logFile + VTerm (via scroll capture)
-	go readPTY(ptyFile, logFile, vterm, scrollCap, tailStop)
+	// Read output into logFile (+ clean copy) + VTerm (via scroll capture)
+	if info.NoPTY {
+		go readPipe(output, logFile, clean, vterm, scrollCap, tailStop)
+	} else {
+		go readPTY(ptyFile, logFile, clean, vterm, scrollCap, tailStop)
+	}
 
 	// Wait for process exit
-	go pm.waitForExit(info.Name, cmd, logFile, done, tailStop, ptyFile)
+	go pm.waitForExit(info.Name, cmd, logFile, cleanLog, done, tailStop, output)
 
 	return rp, nil
 }
 
 // waitForExit waits for the process to exit and updates its status.
-func (pm *ProcessManager) waitForExit(name string, cmd *exec.Cmd, logFile *os.File, done, tailStop chan struct{}, ptyFile *os.File) {
+func (pm *ProcessManager) waitForExit(name string, cmd *exec.Cmd, logFile, cleanLog *os.File, done, tailStop chan struct{}, ptyFile *os.File) {
 	err := cmd.Wait()
 
 	// Give PTY reader goroutine a moment to catch up on remaining output
@@ -233,6 +272,9 @@ func (pm *ProcessManager) waitForExit(name string, cmd *exec.Cmd, logFile *os.Fi
 		ptyFile.Close()
 	}
 	logFile.Close()
+	if cleanLog != nil {
+		cleanLog.Close()
+	}
 	close(done)
 
 	exitCode := 0
@@ -406,11 +448,13 @@ func (pm *ProcessManager) ResizePTY(name string, rows, cols uint16) error {
 		rp.tmux.Resize(int(rows), int(cols))
 		return nil
 	}
-	if rp.PtyFile == nil {
+	if rp.PtyFile == nil && rp.VTerm == nil {
 		return fmt.Errorf("process %q has no PTY", name)
 	}
-	if err := resizePTY(rp.PtyFile, rows, cols); err != nil {
-		return err
+	if rp.PtyFile != nil {
+		if err := resizePTY(rp.PtyFile, rows, cols); err != nil {
+			return err
+		}
 	}
 	if rp.VTerm != nil {
 		rp.VTerm.Resize(int(rows), int(cols))
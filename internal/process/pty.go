@@ -18,6 +18,19 @@ const (
 	DefaultPTYCols = defaultPTYCols
 )
 
+// ptySize returns the terminal size to start info with, falling back to the
+// defaults for unset dimensions
+func (info SessionInfo) ptySize() (rows, cols uint16) {
+	rows, cols = defaultPTYRows, defaultPTYCols
+	if info.Rows > 0 {
+		rows = uint16(info.Rows)
+	}
+	if info.Cols > 0 {
+		cols = uint16(info.Cols)
+	}
+	return rows, cols
+}
+
 // startWithPTY starts a process with a pseudo-terminal.
 // Returns the PTY master fd for reading output and writing input.
 //
@@ -58,6 +71,32 @@ func startWithPTY(cmd *exec.Cmd, rows, cols uint16) (*os.File, error) {
 	return ptmx, nil
 }
 
+// startWithPipes starts a process without a terminal: stdout and stderr share
+// one plain pipe, stdin is /dev/null. For tools that buffer differently or draw
+// a TUI when they see a TTY. Returns the pipe's read end.
+func startWithPipes(cmd *exec.Cmd) (*os.File, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+
+	cmd.Stdout = w
+	cmd.Stderr = w
+
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setpgid: true,
+	}
+
+	if err := cmd.Start(); err != nil {
+		r.Close()
+		w.Close()
+		return nil, err
+	}
+
+	w.Close() // Child inherited its own copy; EOF once it exits
+	return r, nil
+}
+
 // StartDaemon starts a process whose stdout/stderr go to logFile directly.
 // The child fully survives parent exit:
 //   - stdout/stderr → file (no EIO when parent exits)
@@ -1,6 +1,9 @@
 package process
 
-import "bytes"
+import (
+	"bytes"
+	"unicode/utf8"
+)
 
 // sanitizeForLog strips terminal control sequences that don't render well
 // in a scrollback log viewer. Keeps SGR (color/style) sequences intact.
@@ -14,6 +17,10 @@ import "bytes"
 //   - OSC sequences (ESC ] ... BEL/ST) are stripped.
 //   - Standalone \r → overwrites current line (truncates back to last \n).
 //   - \r\n → \n.
+//   - Invalid UTF-8 (latin-1 text, binary output) → U+FFFD, one per bad
+//     byte, so the renderer's width math never sees malformed input. Callers
+//     sanitizing a stream in chunks hold back a rune cut off at the end of a
+//     chunk with SplitIncompleteRune.
 //
 // SanitizeForLog is the exported version for use by external packages (devdash).
 func SanitizeForLog(data []byte) []byte {
@@ -117,12 +124,39 @@ func sanitizeForLog(data []byte) []byte {
 			continue
 		}
 
-		out = append(out, b)
-		i++
+		if b < utf8.RuneSelf {
+			out = append(out, b)
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRune(data[i:])
+		if r == utf8.RuneError && size == 1 {
+			out = append(out, "\uFFFD"...)
+		} else {
+			out = append(out, data[i:i+size]...)
+		}
+		i += size
 	}
 	return out
 }
 
+// SplitIncompleteRune splits off a UTF-8 rune cut off at the end of p, so a
+// stream read in chunks can carry it over to the next one instead of having
+// it replaced as invalid. rest is empty when p ends on a rune boundary.
+func SplitIncompleteRune(p []byte) (complete, rest []byte) {
+	// A rune is at most utf8.UTFMax bytes; look back for its first byte
+	for k := 1; k < utf8.UTFMax && k <= len(p); k++ {
+		b := p[len(p)-k]
+		if utf8.RuneStart(b) {
+			if b >= utf8.RuneSelf && !utf8.FullRune(p[len(p)-k:]) {
+				return p[:len(p)-k], p[len(p)-k:]
+			}
+			break
+		}
+	}
+	return p, nil
+}
+
 // truncateToLineStart truncates buf back to the position just after the last \n.
 // If no \n exists, truncates to empty.
 func truncateToLineStart(buf []byte) []byte {
@@ -1,8 +1,12 @@
 package process
 
 import (
+	"reflect"
 	"strings"
 	"testing"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/x/ansi"
 )
 
 func TestSanitize_CursorForwardToSpaces(t *testing.T) {
@@ -167,3 +171,61 @@ func TestParseCSIParam(t *testing.T) {
 		}
 	}
 }
+
+func TestSanitize_InvalidUTF8(t *testing.T) {
+	// Latin-1 "café", a stray continuation byte, and a truncated rune mid-line
+	input := []byte("caf\xe9 \x80ok \xe2\x82x\n")
+	got := string(sanitizeForLog(input))
+	want := "caf� �ok ��x\n"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if !utf8.ValidString(got) {
+		t.Errorf("output is not valid UTF-8: %q", got)
+	}
+	line := strings.TrimSuffix(got, "\n")
+	if w := ansi.StringWidth(line); w != utf8.RuneCountInString(line) {
+		t.Errorf("width = %d, want %d", w, utf8.RuneCountInString(line))
+	}
+	for _, row := range strings.Split(ansi.Wordwrap(line, 4, ""), "\n") {
+		if w := ansi.StringWidth(row); w > 4 {
+			t.Errorf("wrapped row %q is %d wide, want at most 4", row, w)
+		}
+	}
+}
+
+func TestSanitize_RuneSplitAcrossChunks(t *testing.T) {
+	// "€" is e2 82 ac; a read boundary inside it must not garble it
+	lb := NewLogBuffer(10)
+	sw := sanitizingWriter{w: lb}
+	sw.Write([]byte("price: \xe2\x82"))
+	sw.Write([]byte("\xac5\n"))
+	// A rune whose rest never comes is replaced by the next write
+	sw.Write([]byte("cut \xe2"))
+	sw.Write([]byte("\n"))
+
+	want := []string{"price: €5", "cut �"}
+	if got := lb.Lines(); !reflect.DeepEqual(got, want) {
+		t.Errorf("lines = %q, want %q", got, want)
+	}
+}
+
+func TestSplitIncompleteRune(t *testing.T) {
+	tests := []struct {
+		in, complete, rest string
+	}{
+		{"abc", "abc", ""},
+		{"a€", "a€", ""},
+		{"a\xe2\x82", "a", "\xe2\x82"},
+		{"a\xe2", "a", "\xe2"},
+		{"a\xf0\x9f\x98", "a", "\xf0\x9f\x98"},
+		{"a\x80", "a\x80", ""}, // stray continuation byte: invalid, not cut off
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		complete, rest := SplitIncompleteRune([]byte(tt.in))
+		if string(complete) != tt.complete || string(rest) != tt.rest {
+			t.Errorf("SplitIncompleteRune(%q) = %q, %q; want %q, %q", tt.in, complete, rest, tt.complete, tt.rest)
+		}
+	}
+}
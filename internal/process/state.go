@@ -20,6 +20,13 @@ type SessionInfo struct {
 	WtName    string   `json:"wt_name"`
 	WtPath    string   `json:"wt_path"`
 	StartedAt int64    `json:"started_at"`
+	// NoPTY starts the process on plain pipes instead of a pseudo-terminal,
+	// for tools that misbehave under one. There is no input in this mode.
+	NoPTY bool `json:"no_pty,omitempty"`
+	// Rows and Cols size the terminal from the first byte (0 = 24x80). Not
+	// persisted: a reconnected process keeps whatever size it has.
+	Rows int `json:"-"`
+	Cols int `json:"-"`
 }
 
 // sessionFilePath returns the full path for a session JSON file
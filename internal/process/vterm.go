@@ -5,6 +5,7 @@ import (
 
 	"github.com/charmbracelet/x/ansi"
 	"github.com/charmbracelet/x/vt"
+	"golang.org/x/text/unicode/norm"
 )
 
 // VTermScreen wraps charmbracelet/x/vt to provide a thread-safe virtual terminal screen.
@@ -32,8 +33,18 @@ func NewVTermScreen(rows, cols int) *VTermScreen {
 
 // Write processes raw terminal output through the terminal emulator.
 // Implements io.Writer. Called from the PTY reader goroutine.
+//
+// Output is NFC-normalized first: the emulator places an ASCII character in
+// its cell immediately, so a combining mark that follows it ("e" + U+0301)
+// would land in a cell of its own and be lost. Composed ("é") it is one cell.
 func (s *VTermScreen) Write(p []byte) (int, error) {
-	return s.emu.Write(p)
+	if norm.NFC.IsNormal(p) {
+		return s.emu.Write(p)
+	}
+	if _, err := s.emu.Write(norm.NFC.Bytes(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
 }
 
 // Content returns the current screen content as plain text (no ANSI codes).
@@ -44,6 +55,25 @@ func (s *VTermScreen) Content() string {
 	return trimScreen(plain)
 }
 
+// RawContent returns the full screen as plain text, always exactly rows lines.
+// Unlike Content, trailing blank rows are kept so a full-screen app (pager,
+// editor) occupies a stable height; used for interactive rendering.
+// Trailing whitespace is still trimmed from each line.
+func (s *VTermScreen) RawContent() string {
+	plain := ansi.Strip(s.emu.Render())
+	lines := strings.Split(plain, "\n")
+	if len(lines) > s.rows {
+		lines = lines[:s.rows]
+	}
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \r")
+	}
+	for len(lines) < s.rows {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
 // Render returns the current screen content with ANSI escape codes preserved.
 // Trims trailing whitespace from each line and trailing empty lines.
 func (s *VTermScreen) Render() string {
@@ -86,11 +116,12 @@ func (s *VTermScreen) Resize(rows, cols int) {
 	s.emu.Resize(cols, rows)
 }
 
-// trimScreen trims trailing whitespace from each line and removes trailing empty lines.
+// trimScreen trims trailing whitespace (and the \r of the emulator's \r\n row
+// endings) from each line and removes trailing empty lines.
 func trimScreen(content string) string {
 	lines := strings.Split(content, "\n")
 	for i, line := range lines {
-		lines[i] = strings.TrimRight(line, " ")
+		lines[i] = strings.TrimRight(line, " \r")
 	}
 	for len(lines) > 0 && lines[len(lines)-1] == "" {
 		lines = lines[:len(lines)-1]
@@ -126,3 +126,45 @@ func TestVTermScreen_ThreadSafety(t *testing.T) {
 	wg.Wait()
 	// If we reach here without panic/race, the test passes
 }
+
+func TestVTermScreen_RawContentKeepsScreenHeight(t *testing.T) {
+	screen := NewVTermScreen(5, 20)
+	screen.Write([]byte("top\r\nsecond"))
+
+	raw := screen.RawContent()
+	lines := strings.Split(raw, "\n")
+	if len(lines) != 5 {
+		t.Fatalf("RawContent() has %d lines, want 5: %q", len(lines), raw)
+	}
+	if lines[0] != "top" || lines[1] != "second" || lines[4] != "" {
+		t.Errorf("unexpected rows %q", lines)
+	}
+	if got := screen.Content(); got != "top\nsecond" {
+		t.Errorf("Content() should still trim trailing rows, got %q", got)
+	}
+
+	screen.Resize(3, 20)
+	if n := strings.Count(screen.RawContent(), "\n") + 1; n != 3 {
+		t.Errorf("after resize RawContent() has %d lines, want 3", n)
+	}
+}
+
+func TestVTermScreen_WideAndCombiningCharacters(t *testing.T) {
+	screen := NewVTermScreen(4, 20)
+	// CSI 5 G moves to column 5: right after the two double-width glyphs
+	screen.Write([]byte("日本\x1b[5GX|\r\n🎉 ok\r\ncafe\u0301|\r\nｱｲ|"))
+
+	want := []string{"日本X|", "🎉 ok", "caf\u00e9|", "ｱｲ|"}
+	got := screen.PlainLines()
+	if len(got) != len(want) {
+		t.Fatalf("PlainLines() = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("row %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+	if c := screen.Content(); c != strings.Join(want, "\n") {
+		t.Errorf("Content() = %q", c)
+	}
+}
@@ -1,18 +1,25 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 
 	"github.com/kimaguri/simplx-toolkit/internal/config"
 	"github.com/kimaguri/simplx-toolkit/internal/discovery"
 	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+	"github.com/kimaguri/simplx-toolkit/internal/process"
 )
 
 // viewState tracks the current main view
@@ -21,6 +28,8 @@ type viewState int
 const (
 	viewDashboard viewState = iota
 	viewLogFull
+	viewOverview
+	viewSplit
 )
 
 // overlayState tracks the current overlay (popup) on top of the dashboard
@@ -32,6 +41,12 @@ const (
 	overlayConfirm
 	overlaySettings
 	overlayTunnel
+	overlayGlobalSearch
+	overlayAttach
+	overlayScanning
+	overlayTags
+	overlayLaunchError
+	overlaySignal
 )
 
 // interactiveExitWindow is the max delay between two Esc presses to exit interactive mode
@@ -40,6 +55,26 @@ const interactiveExitWindow = 500 * time.Millisecond
 // ProcessStatusMsg is sent periodically to refresh process list statuses
 type ProcessStatusMsg struct{}
 
+// statusTickInterval matches the activity bucket width so sparklines advance once per bucket
+const statusTickInterval = process.ActivityInterval
+
+// stopProgressMsg redraws the "stopping (Ns)" countdown while a stop is pending
+type stopProgressMsg struct{}
+
+// stopProgressTick schedules the next stopProgressMsg
+func stopProgressTick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return stopProgressMsg{}
+	})
+}
+
+// statusTick schedules the next ProcessStatusMsg
+func statusTick() tea.Cmd {
+	return tea.Tick(statusTickInterval, func(time.Time) tea.Msg {
+		return ProcessStatusMsg{}
+	})
+}
+
 // App is the root tea.Model for the TUI application
 type App struct {
 	pm            *devdash.ProcessManager
@@ -48,16 +83,31 @@ type App struct {
 	overlay       overlayState
 	dashboard     dashboardModel
 	logView       logViewModel
+	overview      overviewModel
 	launcher      launcherModel
 	confirm       confirmModel
 	settings      settingsModel
 	tunnelOvl     tunnelOverlayModel
+	globalSearch  globalSearchModel
+	attach        attachModel
+	scanning      scanningModel
+	tags          tagsModel
+	launchErr     launchErrorModel
+	signalMenu    signalMenuModel
+	split         splitModel
 	width         int
 	height        int
 	worktrees      []discovery.Worktree
+	scanGen        int                // id of the latest discovery run
+	worktreesGen   int                // scanGen of the run worktrees came from; older runs finishing later are dropped
+	watcher        *discovery.Watcher // scan dirs watched for worktree changes; nil = off
 	pendingLaunch  *LaunchRequestMsg // stored while waiting for deps install confirmation
 	pendingTunnel  string            // process name waiting for cloudflared install
+	pendingRestart *devdash.SessionInfo // re-detected launch config waiting for the restart-changed confirmation
 	pendingInstall string            // install process name → auto-launch main process on exit
+	launchQueue    []LaunchRequestMsg // profile launches still to start, one at a time
+	profileName    string             // profile passed to StartProfile, for feedback
+	profileSkipped int                // profile entries skipped with a warning
 	lastEsc        time.Time         // last Esc inside interactive mode; stale values are harmless because the window check is monotonic
 }
 
@@ -66,6 +116,16 @@ func NewApp(cfg *config.LocalConfig, pm *devdash.ProcessManager) App {
 	wts := discovery.ScanWorktrees(cfg.ScanDirs)
 
 	dash := newDashboardModel()
+	dash.wrapNames = cfg.WrapSessionNames
+	dash.wrapIndent = cfg.WrapIndent
+	dash.timeFmt = parseTimeFormat(cfg.TimeFormat)
+	dash.listRatio = cfg.ListRatio
+	dash.dense = cfg.DenseList
+	dash.idleAfter = cfg.IdleAfter()
+	dash.stickySearch = cfg.StickySearch
+	dash.tags = cfg.SessionTags
+	dash.clock = pm.Clock()
+	dash.runner = pm.Runner()
 	procs := pm.List()
 	dash.SetProcesses(procs)
 
@@ -89,7 +149,9 @@ func NewApp(cfg *config.LocalConfig, pm *devdash.ProcessManager) App {
 		view:      viewDashboard,
 		overlay:   overlay,
 		dashboard: dash,
+		overview:  newOverviewModel(parseTimeFormat(cfg.TimeFormat), pm.Clock(), pm.Runner()),
 		settings:  settings,
+		scanning:  newScanningModel(),
 		worktrees: wts,
 	}
 
@@ -98,13 +160,23 @@ func NewApp(cfg *config.LocalConfig, pm *devdash.ProcessManager) App {
 
 // Init implements tea.Model
 func (a App) Init() tea.Cmd {
-	var cmds []tea.Cmd
+	cmds := []tea.Cmd{statusTick(), waitServerReady(a.pm), startWorktreeWatcher(a.cfg)}
 
 	cmd := a.dashboard.SubscribeToSelected()
+	if a.view == viewLogFull {
+		cmd = a.logView.Subscribe()
+	}
 	if cmd != nil {
 		cmds = append(cmds, cmd)
 	}
 
+	if a.profileName != "" {
+		cmds = append(cmds, launchNext)
+		if a.profileSkipped > 0 {
+			cmds = append(cmds, feedbackCmd(fmt.Sprintf("[Profile %s: %d skipped, see terminal after quit]", a.profileName, a.profileSkipped)))
+		}
+	}
+
 	return tea.Batch(cmds...)
 }
 
@@ -123,34 +195,25 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		a.launcher.SetSize(msg.Width, msg.Height)
 		a.confirm.SetSize(msg.Width, msg.Height)
+		a.scanning.SetSize(msg.Width, msg.Height)
 		a.settings.SetSize(msg.Width, msg.Height)
 		a.tunnelOvl.SetSize(msg.Width, msg.Height)
+		a.globalSearch.SetSize(msg.Width, msg.Height)
+		a.attach.SetSize(msg.Width, msg.Height)
+		a.tags.SetSize(msg.Width, msg.Height)
+		a.launchErr.SetSize(msg.Width, msg.Height)
+		a.signalMenu.SetSize(msg.Width, msg.Height)
+		a.overview.SetSize(msg.Width, msg.Height)
 
 		if a.view == viewLogFull {
 			a.logView.SetSize(msg.Width, msg.Height)
 		}
-
-		// Resize PTY to match the log viewport width (not full terminal width).
-		// In dashboard view, the log panel is ~2/3 of the terminal;
-		// in fullscreen log view, it's the full width.
-		var ptyCols uint16
-		if a.view == viewLogFull {
-			ptyCols = uint16(msg.Width)
-		} else {
-			_, rightW := a.dashboard.panelWidths()
-			ptyCols = uint16(rightW - 2) // subtract borders
-		}
-		ptyRows := uint16(msg.Height - 2)
-		if ptyRows < 1 {
-			ptyRows = 1
-		}
-		if ptyCols < 1 {
-			ptyCols = 1
-		}
-		for _, rp := range a.pm.List() {
-			_ = a.pm.ResizePTY(rp.Info.Name, ptyRows, ptyCols)
+		if a.view == viewSplit {
+			a.split.SetSize(msg.Width, msg.Height)
 		}
 
+		a.resizePTYs()
+
 		switch a.view {
 		case viewDashboard:
 			var cmd tea.Cmd
@@ -174,33 +237,74 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.Confirmed {
 			switch msg.Action {
 			case "kill":
-				return a, a.killProcess(msg.Target)
+				return a, tea.Batch(a.killProcess(msg.Target), stopProgressTick())
 			case "restart":
-				return a, a.restartProcess(msg.Target)
+				return a, changedLaunchConfigCmd(a.pm, a.cfg, a.worktrees, msg.Target)
+			case "restart-changed":
+				if a.pendingRestart != nil {
+					next := *a.pendingRestart
+					a.pendingRestart = nil
+					return a, tea.Batch(a.restartProcessWith(msg.Target, next), stopProgressTick())
+				}
+			case "restart-errored":
+				restarts := []tea.Cmd{stopProgressTick()}
+				for _, name := range strings.Split(msg.Target, "\n") {
+					restarts = append(restarts, a.restartProcess(name))
+				}
+				return a, tea.Batch(restarts...)
 			case "install-deps":
 				pmBin := "npm"
 				if a.pendingLaunch != nil && a.pendingLaunch.PackageManager != "" {
 					pmBin = a.pendingLaunch.PackageManager
 				}
-				pmPath := resolveBinary(pmBin)
-				installName := fmt.Sprintf("install/%s", filepath.Base(msg.Target))
+				pmPath := resolveBinary(a.pm.Runner(), pmBin)
+				installName := installSessionPrefix + filepath.Base(msg.Target)
 				a.pendingInstall = installName
 				return a, a.startInstallProcess(msg.Target, pmPath)
+			case "adopt-session":
+				a.pendingLaunch = nil
+				return a, tea.Batch(adoptSessionCmd(a.pm, msg.Target), launchNext)
+			case "kill-port-holders":
+				if a.pendingLaunch != nil {
+					req := *a.pendingLaunch
+					a.pendingLaunch = nil
+					return a, killPortHoldersCmd(a.pm, msg.Target, req)
+				}
 			case "stop-tunnel":
 				return a, stopTunnelCmd(a.pm, msg.Target)
+			case "save-project-launch":
+				return a, a.saveProjectLaunch(msg.Target)
 			case "install-cloudflared":
 				a.dashboard.tunnelFeedback = "Installing cloudflared..."
-				return a, installCloudflaredCmd()
+				return a, installCloudflaredCmd(a.pm.Runner())
 			}
 		} else if msg.Action == "install-cloudflared" {
 			a.pendingTunnel = ""
 			return a, nil
+		} else if msg.Action == "restart-changed" {
+			a.pendingRestart = nil
+			if msg.Cancelled {
+				return a, nil
+			}
+			// Declined — restart with the configuration it was launched with
+			return a, tea.Batch(a.restartProcess(msg.Target), stopProgressTick())
+		} else if msg.Action == "kill-port-holders" {
+			// Declined — launch anyway (some dev servers pick another port)
+			if a.pendingLaunch != nil {
+				req := *a.pendingLaunch
+				a.pendingLaunch = nil
+				return a, func() tea.Msg { return req }
+			}
+		} else if msg.Action == "adopt-session" {
+			// Declined — a second copy would fail to start, so don't launch
+			a.pendingLaunch = nil
+			return a, tea.Batch(feedbackCmd("[Not launched: "+msg.Target+" is already running]"), launchNext)
 		} else if msg.Action == "install-deps" {
 			// User declined install — launch anyway
 			if a.pendingLaunch != nil {
 				req := *a.pendingLaunch
 				a.pendingLaunch = nil
-				return a, a.launchProcess(req)
+				return a, tea.Batch(a.launchProcess(req), launchNext)
 			}
 		}
 		return a, nil
@@ -211,24 +315,64 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			a.cfg.ScanDirs = msg.scanDirs
 			_ = config.SaveConfig(a.cfg)
 		}
-		// Always rescan on settings close
-		a.worktrees = discovery.ScanWorktrees(a.cfg.ScanDirs)
-		return a, nil
+		// Always rescan on settings close, in the background
+		refresh := a.discover(scanRequest{purpose: scanRefresh, scanDirs: a.cfg.ScanDirs})
+		return a, tea.Batch(refresh, a.watchScanDirs())
+
+	case watcherStartedMsg:
+		return a.handleWatcherStarted(msg)
+
+	case worktreesChangedMsg:
+		return a.refreshWorktrees()
 
 	case rescanRequestMsg:
 		// Rescan worktrees and update settings with results
-		a.worktrees = discovery.ScanWorktrees(a.settings.scanDirs)
-		a.settings.totalFound = len(a.worktrees)
-		a.settings.worktreeCounts = countWorktreesPerDir(a.settings.scanDirs, a.worktrees)
-		return a, nil
+		return a.scan(scanRequest{purpose: scanRescan, scanDirs: a.settings.scanDirs})
+
+	case discoveryDoneMsg:
+		return a.handleDiscoveryDone(msg)
 
 	case LaunchRequestMsg:
-		a.overlay = overlayNone
+		if msg.fromProfile {
+			// A profile launch back from a check waits, at the front of the
+			// queue, for an overlay opened meanwhile
+			if a.overlay != overlayNone {
+				a.launchQueue = append([]LaunchRequestMsg{msg}, a.launchQueue...)
+				return a, launchNextLater()
+			}
+		} else {
+			a.overlay = overlayNone
+		}
 
-		// Save port override for next time
-		key := config.PortKey(msg.Worktree.Name, msg.Project.Name)
-		a.cfg.SetPort(key, msg.Port)
-		_ = config.SaveConfig(a.cfg)
+		// Save port override and project for next time (duplicates keep the
+		// original's port, profiles pass theirs to this launch only)
+		if msg.SessionName == "" {
+			if !msg.fromProfile {
+				a.cfg.SetPort(config.PortKey(msg.Worktree.Name, msg.Project.Name), msg.Port)
+			}
+			a.cfg.SetLastProject(msg.Worktree.Name, msg.Project.Name)
+			_ = config.SaveConfig(a.cfg)
+		}
+
+		// Offer to reconnect instead when the session already runs, e.g.
+		// under another devdash
+		if !msg.elsewhereChecked {
+			msg.elsewhereChecked = true
+			if other, ok := a.pm.RunningElsewhere(launchSessionName(msg)); ok {
+				a.pendingLaunch = &msg
+				a.confirm = newConfirmModel(runningElsewherePrompt(other), "adopt-session", other.Name)
+				a.confirm.SetSize(a.width, a.height)
+				a.overlay = overlayConfirm
+				return a, nil
+			}
+		}
+
+		// Offer to kill whatever already listens on the port, once the
+		// lookup comes back
+		if !msg.portChecked {
+			msg.portChecked = true
+			return a, checkPortHoldersCmd(a.pm, msg)
+		}
 
 		// Check if node_modules is missing (skip for Encore projects)
 		if !msg.Project.IsEncore && !hasDeps(msg.Worktree.Path) {
@@ -237,13 +381,52 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if pm == "" {
 				pm = "npm"
 			}
-			confirmMsg := fmt.Sprintf("node_modules not found in %s.\nRun %s install?", msg.Worktree.Name, pm)
+			install := strings.Join(config.InstallArgs(pm, msg.Worktree.Path, a.cfg.FrozenInstall), " ")
+			confirmMsg := fmt.Sprintf("node_modules not found in %s.\nRun %s %s?", msg.Worktree.Name, pm, install)
 			a.confirm = newConfirmModel(confirmMsg, "install-deps", msg.Worktree.Path)
 			a.confirm.SetSize(a.width, a.height)
 			a.overlay = overlayConfirm
 			return a, nil
 		}
-		return a, a.launchProcess(msg)
+		return a, tea.Batch(a.launchProcess(msg), launchNext)
+
+	case launchConfigCheckedMsg:
+		if len(msg.diff) == 0 {
+			return a, tea.Batch(a.restartProcess(msg.name), stopProgressTick())
+		}
+		text := fmt.Sprintf("Project config for %q changed since launch:\n\n%s\n\nRestart with the new command? (No keeps the existing one)", msg.name, strings.Join(msg.diff, "\n"))
+		a.pendingRestart = &msg.next
+		a.confirm = newConfirmModel(text, "restart-changed", msg.name)
+		a.confirm.SetSize(a.width, a.height)
+		a.overlay = overlayConfirm
+		return a, nil
+
+	case portHoldersMsg:
+		req := msg.req
+		pids := joinPIDs(msg.holders)
+		if pids == "" {
+			// Only devdash's own sessions: those are stopped from the dashboard
+			return a, tea.Batch(feedbackCmd(fmt.Sprintf("[Port %d is in use by session %s]", req.Port, msg.holders[0].Session)),
+				func() tea.Msg { return req })
+		}
+		a.pendingLaunch = &req
+		a.confirm = newConfirmModel(portHoldersPrompt(req.Port, msg.holders), "kill-port-holders", pids)
+		a.confirm.SetSize(a.width, a.height)
+		a.overlay = overlayConfirm
+		return a, nil
+
+	case launchNextMsg:
+		// Wait while a dependency install prompt or install is in flight
+		if len(a.launchQueue) == 0 || a.pendingLaunch != nil || a.pendingInstall != "" {
+			return a, nil
+		}
+		// and while the user has an overlay open, which a launch would close
+		if a.overlay != overlayNone {
+			return a, launchNextLater()
+		}
+		req := a.launchQueue[0]
+		a.launchQueue = a.launchQueue[1:]
+		return a, func() tea.Msg { return req }
 
 	case installDoneMsg:
 		a.pendingInstall = ""
@@ -253,24 +436,32 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		if msg.err != "" {
 			a.pendingLaunch = nil
-			return a, func() tea.Msg {
+			return a, tea.Batch(func() tea.Msg {
 				return processErrorMsg{name: msg.name, err: msg.err}
-			}
+			}, launchNext)
 		}
 		if a.pendingLaunch != nil {
 			req := *a.pendingLaunch
 			a.pendingLaunch = nil
-			return a, a.launchProcess(req)
+			return a, tea.Batch(a.launchProcess(req), launchNext)
 		}
 		return a, nil
 
+	case sessionAdoptedMsg:
+		if msg.err != nil {
+			return a, feedbackCmd(fmt.Sprintf("[Reconnect failed: %v]", msg.err))
+		}
+		a.dashboard.SetProcesses(a.pm.List())
+		a.dashboard.selectByName(msg.name)
+		return a, tea.Batch(a.dashboard.SubscribeToSelected(), feedbackCmd("[Reconnected to "+msg.name+"]"))
+
 	case cancelLauncherMsg:
 		a.overlay = overlayNone
 		return a, nil
 
-	case ClipboardFeedbackMsg, ClearClipboardFeedbackMsg:
+	case ClipboardFeedbackMsg, ClearClipboardFeedbackMsg, searchDebounceMsg:
 		switch a.view {
-		case viewDashboard:
+		case viewDashboard, viewOverview, viewSplit:
 			var cmd tea.Cmd
 			a.dashboard, cmd = a.dashboard.Update(msg)
 			if cmd != nil {
@@ -285,6 +476,16 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return a, tea.Batch(cmds...)
 
+	case tea.BlurMsg:
+		a.dashboard.paused = true
+		a.logView.paused = true
+		a.split.panes[0].paused = true
+		a.split.panes[1].paused = true
+		return a, nil
+
+	case tea.FocusMsg:
+		return a.resumeRendering(), nil
+
 	case LogLineMsg:
 		switch a.view {
 		case viewDashboard:
@@ -299,17 +500,20 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if cmd != nil {
 				cmds = append(cmds, cmd)
 			}
+		case viewSplit:
+			var cmd tea.Cmd
+			a.split, cmd = a.split.Update(msg)
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
 		}
 		return a, tea.Batch(cmds...)
 
 	case processLaunchedMsg:
-		// Resize PTY of the new process to match current terminal size
-		if a.width > 0 && a.height > 0 {
-			ptyRows := uint16(a.height - 2)
-			if ptyRows < 1 {
-				ptyRows = 1
-			}
-			_ = a.pm.ResizePTY(msg.name, ptyRows, uint16(a.width))
+		// Size the new process's PTY to where it's shown; the size is kept
+		// for restarts, so it must be the panel's, not the terminal's
+		if rows, cols := a.ptySize(); rows > 0 {
+			_ = a.pm.ResizePTY(msg.name, rows, cols)
 		}
 		a.dashboard.SetProcesses(a.pm.List())
 		cmd := a.dashboard.SubscribeToSelected()
@@ -334,6 +538,31 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case processErrorMsg:
 		a.dashboard.SetProcesses(a.pm.List())
+		if msg.start {
+			a.launchErr = newLaunchErrorModel(msg.name, msg.err, msg.command, a.pm.Runner())
+			a.launchErr.SetSize(a.width, a.height)
+			a.overlay = overlayLaunchError
+		}
+		return a, nil
+
+	case launchErrorClosedMsg:
+		a.overlay = overlayNone
+		return a, nil
+
+	case launchCommandCopiedMsg:
+		a.launchErr.copied = true
+		return a, feedbackCmd("[Command copied]")
+
+	case signalChosenMsg:
+		a.overlay = overlayNone
+		sig := devdash.Signals[msg.index]
+		if err := a.pm.Signal(msg.session, sig.Signal); err != nil {
+			return a, feedbackCmd(fmt.Sprintf("[%s failed: %v]", sig.Name, err))
+		}
+		return a, feedbackCmd(fmt.Sprintf("[Sent %s to %s]", sig.Name, msg.session))
+
+	case signalCancelledMsg:
+		a.overlay = overlayNone
 		return a, nil
 
 	case tunnelStartedMsg:
@@ -342,9 +571,18 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if a.overlay == overlayTunnel {
 			a.tunnelOvl.phase = tunnelPhaseActive
 			a.tunnelOvl.url = msg.url
+			a.tunnelOvl.copied = a.cfg.TunnelAutoCopy
+		}
+		if a.cfg.TunnelAutoCopy {
+			return a, copyTunnelURL(a.pm.Runner(), msg.url)
 		}
 		return a, nil
 
+	case tunnelDockMsg:
+		a.overlay = overlayNone
+		a.dashboard.dockTunnel(msg.name)
+		return a, feedbackCmd("[Tunnel URL pinned — U to unpin]")
+
 	case tunnelStoppedMsg:
 		a.dashboard.SetProcesses(a.pm.List())
 		return a, func() tea.Msg {
@@ -367,6 +605,126 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.overlay = overlayNone
 		return a, nil
 
+	case ProcessStatusMsg:
+		prev := a.dashboard.logSubName
+		removed := a.pm.RemoveExpired(a.pm.Clock().Now())
+		a.dashboard.SetProcesses(a.pm.List())
+		cmds := []tea.Cmd{statusTick()}
+		if len(removed) > 0 {
+			sort.Strings(removed)
+			cmds = append(cmds, feedbackCmd("[Removed exited: "+strings.Join(removed, ", ")+"]"))
+		}
+		if a.view == viewOverview {
+			a.overview.SetProcesses(a.dashboard.processes)
+			return a, tea.Batch(append(cmds, a.overview.sampleCmd())...)
+		}
+		// Follow the selection when the status filter hid the selected
+		// session or it was auto-removed
+		if sel := a.dashboard.SelectedProcess(); a.view == viewDashboard && sel != nil && prev != "" && sel.Info.Name != prev {
+			cmds = append(cmds, a.dashboard.SubscribeToSelected())
+		}
+		return a, tea.Batch(cmds...)
+
+	case serverReadyMsg:
+		if a.cfg.ReadyBell {
+			ringBell()
+		}
+		return a, tea.Batch(waitServerReady(a.pm), readyFlashTimeout())
+
+	case readyFlashDoneMsg:
+		// Nothing to update; re-rendering ends the flash
+		return a, nil
+
+	case usageSampledMsg:
+		a.overview.usage = msg.usage
+		return a, nil
+
+	case stopProgressMsg:
+		// Keep ticking while a stop is in progress so the countdown advances
+		for _, rp := range a.pm.List() {
+			if !rp.StopDeadline().IsZero() {
+				return a, stopProgressTick()
+			}
+		}
+		return a, nil
+
+	case spinner.TickMsg:
+		if a.overlay == overlayScanning {
+			var cmd tea.Cmd
+			a.scanning.spinner, cmd = a.scanning.spinner.Update(msg)
+			return a, cmd
+		}
+		if a.overlay != overlayGlobalSearch {
+			return a, nil
+		}
+		var cmd tea.Cmd
+		a.globalSearch, cmd = a.globalSearch.Update(msg, a.pm.List())
+		return a, cmd
+
+	case globalSearchResultMsg:
+		if a.overlay != overlayGlobalSearch {
+			return a, nil
+		}
+		var cmd tea.Cmd
+		a.globalSearch, cmd = a.globalSearch.Update(msg, a.pm.List())
+		return a, cmd
+
+	case editorFinishedMsg:
+		if msg.err != nil {
+			return a, feedbackCmd(fmt.Sprintf("[Editor error: %v]", msg.err))
+		}
+		return a, nil
+
+	case globalSearchClosedMsg:
+		a.overlay = overlayNone
+		return a, nil
+
+	case attachRequestMsg:
+		return a, attachCmd(a.pm, msg.info)
+
+	case attachCancelledMsg:
+		a.overlay = overlayNone
+		return a, nil
+
+	case attachFailedMsg:
+		// Keep the form open so the input can be corrected
+		a.attach.err = msg.err.Error()
+		return a, nil
+
+	case attachedMsg:
+		a.overlay = overlayNone
+		return a, tea.Batch(
+			func() tea.Msg { return processLaunchedMsg{name: msg.name} },
+			feedbackCmd(fmt.Sprintf("[Attached %s]", msg.name)),
+		)
+
+	case tagsSavedMsg:
+		a.overlay = overlayNone
+		a.cfg.SetTags(msg.session, msg.tags)
+		_ = config.SaveConfig(a.cfg)
+		a.dashboard.tags = a.cfg.SessionTags
+		a.dashboard.SetProcesses(a.pm.List())
+		return a, a.dashboard.SubscribeToSelected()
+
+	case tagsCancelledMsg:
+		a.overlay = overlayNone
+		return a, nil
+
+	case globalSearchJumpMsg:
+		a.overlay = overlayNone
+		rp := a.pm.Get(msg.session)
+		if rp == nil {
+			return a, nil
+		}
+		if a.view == viewLogFull {
+			a.logView.Unsubscribe()
+		}
+		a.view = viewDashboard
+		var cmd tea.Cmd
+		a, cmd = a.openLogView(rp)
+		a.logView.jumpToLine(msg.line)
+		return a, cmd
+
 	case cloudflaredMissingMsg:
 		a.overlay = overlayNone // close tunnel overlay
 		a.pendingTunnel = msg.name
@@ -389,10 +747,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.pendingTunnel = ""
 		a.dashboard.tunnelFeedback = ""
 		if name != "" {
-			a.tunnelOvl = newTunnelOverlay(name)
-			a.tunnelOvl.SetSize(a.width, a.height)
-			a.overlay = overlayTunnel
-			return a, startTunnelCmd(a.pm, name)
+			return a.openTunnel(name)
 		}
 		return a, nil
 
@@ -428,6 +783,10 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a.updateDashboardKeys(keyMsg)
 		case viewLogFull:
 			return a.updateLogViewKeys(keyMsg)
+		case viewOverview:
+			return a.updateOverviewKeys(keyMsg)
+		case viewSplit:
+			return a.updateSplitKeys(keyMsg)
 		}
 	}
 
@@ -453,6 +812,31 @@ func (a App) updateOverlay(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		var cmd tea.Cmd
 		a.tunnelOvl, cmd = a.tunnelOvl.Update(msg)
 		return a, cmd
+	case overlayGlobalSearch:
+		var cmd tea.Cmd
+		a.globalSearch, cmd = a.globalSearch.Update(msg, a.pm.List())
+		return a, cmd
+	case overlayAttach:
+		var cmd tea.Cmd
+		a.attach, cmd = a.attach.Update(msg)
+		return a, cmd
+	case overlayTags:
+		var cmd tea.Cmd
+		a.tags, cmd = a.tags.Update(msg)
+		return a, cmd
+	case overlayLaunchError:
+		var cmd tea.Cmd
+		a.launchErr, cmd = a.launchErr.Update(msg)
+		return a, cmd
+	case overlaySignal:
+		var cmd tea.Cmd
+		a.signalMenu, cmd = a.signalMenu.Update(msg)
+		return a, cmd
+	case overlayScanning:
+		if msg.String() == "esc" {
+			a.overlay = a.scanning.back
+		}
+		return a, nil
 	}
 	return a, nil
 }
@@ -464,25 +848,41 @@ func (a App) updateDashboardKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a.handleDashboardInteractiveKey(msg)
 	}
 
+	// Tab cycles focus in every other dashboard state, see focus.go
+	switch msg.String() {
+	case "tab":
+		a.dashboard.cycleFocus(1)
+		return a, nil
+	case "shift+tab":
+		a.dashboard.cycleFocus(-1)
+		return a, nil
+	}
+
 	// When selection is active, handle keys directly (bypass app-level switch to avoid k→kill conflict)
 	if a.dashboard.selection.isActive() {
 		key := msg.String()
 		switch key {
-		case "tab":
-			a.dashboard.selection.deactivate()
-			a.dashboard.refreshLogViewport()
-			a.dashboard.focus = focusList
-			return a, nil
 		case "y":
+			flushLog(a.dashboard.logBuf)
 			text := a.dashboard.selection.selectedText()
 			count := a.dashboard.selection.selectedLineCount()
 			a.dashboard.selection.deactivate()
 			a.dashboard.refreshLogViewport()
-			return a, copySelectedLines(text, count)
+			return a, copySelectedLines(a.pm.Runner(), text, count)
+		case "Y":
+			flushLog(a.dashboard.logBuf)
+			text, count := a.dashboard.selection.copyStay()
+			return a, copySelectedLines(a.pm.Runner(), text, count)
 		case "esc":
 			a.dashboard.selection.deactivate()
 			a.dashboard.refreshLogViewport()
 			return a, nil
+		case "o":
+			workDir := ""
+			if sel := a.dashboard.SelectedProcess(); sel != nil {
+				workDir = sel.Info.WorkDir
+			}
+			return a, openFileRefInLine(a.pm.Runner(), a.dashboard.selection.cursorLine(), workDir)
 		default:
 			action := a.dashboard.selection.handleKey(key, a.dashboard.logViewport.Height)
 			if action == selActionMoved {
@@ -491,6 +891,16 @@ func (a App) updateDashboardKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return a, nil
 		}
 	}
+	// Sticky search can be toggled mid-search, so it's checked before search gets the keys
+	if msg.String() == "S" && a.dashboard.search.mode != searchInput {
+		a.dashboard.stickySearch = !a.dashboard.stickySearch
+		a.cfg.StickySearch = a.dashboard.stickySearch
+		_ = config.SaveConfig(a.cfg)
+		if a.dashboard.stickySearch {
+			return a, feedbackCmd("[Sticky search on: the query follows session switches]")
+		}
+		return a, feedbackCmd("[Sticky search off]")
+	}
 	if a.dashboard.search.isActive() {
 		var cmd tea.Cmd
 		a.dashboard, cmd = a.dashboard.Update(msg)
@@ -503,20 +913,63 @@ func (a App) updateDashboardKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "n":
 		// Refresh worktrees before showing launcher
-		a.worktrees = discovery.ScanWorktrees(a.cfg.ScanDirs)
-		a.launcher = newLauncherModel(a.worktrees, a.cfg.PortOverrides)
-		a.launcher.SetSize(a.width, a.height)
-		a.overlay = overlayLauncher
-		return a, nil
+		return a.scan(scanRequest{purpose: scanLauncher, scanDirs: a.cfg.ScanDirs})
+
+	case "d":
+		sel := a.dashboard.SelectedProcess()
+		if sel == nil {
+			return a, nil
+		}
+		procs := a.pm.List()
+		return a.scan(scanRequest{
+			purpose:  scanDuplicate,
+			scanDirs: a.cfg.ScanDirs,
+			dup:      sel.Info,
+			dupName:  uniqueSessionName(procs, sel.Info.Name),
+			dupPort:  nextFreePort(procs, sel.Info.Port),
+		})
+
+	case "a":
+		a.attach = newAttachModel()
+		a.attach.SetSize(a.width, a.height)
+		a.overlay = overlayAttach
+		return a, textinput.Blink
+
+	case "T":
+		sel := a.dashboard.SelectedProcess()
+		if sel == nil {
+			return a, nil
+		}
+		a.tags = newTagsModel(sel.Info.Name, a.cfg.Tags(sel.Info.Name), a.cfg.AllTags())
+		a.tags.SetSize(a.width, a.height)
+		a.overlay = overlayTags
+		return a, textinput.Blink
+
+	case "f":
+		inUse := a.cfg.AllTags()
+		if len(inUse) == 0 && a.dashboard.tagFilter == "" {
+			return a, feedbackCmd("[No tagged sessions — T tags the selected one]")
+		}
+		a.dashboard.cycleTagFilter(inUse)
+		feedback := "[Tag filter off]"
+		if a.dashboard.tagFilter != "" {
+			feedback = "[Showing #" + a.dashboard.tagFilter + "]"
+		}
+		return a, tea.Batch(a.dashboard.SubscribeToSelected(), feedbackCmd(feedback))
+
+	case "h":
+		a.dashboard.cycleStatusFilter()
+		feedback := "[Showing all sessions]"
+		switch a.dashboard.statusFilter {
+		case showRunning:
+			feedback = "[Showing running sessions]"
+		case showNotRunning:
+			feedback = "[Showing stopped and errored sessions]"
+		}
+		return a, tea.Batch(a.dashboard.SubscribeToSelected(), feedbackCmd(feedback))
 
 	case "s":
-		a.worktrees = discovery.ScanWorktrees(a.cfg.ScanDirs)
-		a.settings = newSettingsModel(a.cfg.ScanDirs)
-		a.settings.totalFound = len(a.worktrees)
-		a.settings.worktreeCounts = countWorktreesPerDir(a.cfg.ScanDirs, a.worktrees)
-		a.settings.SetSize(a.width, a.height)
-		a.overlay = overlaySettings
-		return a, nil
+		return a.scan(scanRequest{purpose: scanSettings, scanDirs: a.cfg.ScanDirs})
 
 	case "k":
 		sel := a.dashboard.SelectedProcess()
@@ -528,6 +981,16 @@ func (a App) updateDashboardKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return a, nil
 
+	case "K":
+		sel := a.dashboard.SelectedProcess()
+		if sel == nil || sel.Status != devdash.StatusRunning {
+			return a, nil
+		}
+		a.signalMenu = newSignalMenuModel(sel.Info.Name)
+		a.signalMenu.SetSize(a.width, a.height)
+		a.overlay = overlaySignal
+		return a, nil
+
 	case "r":
 		sel := a.dashboard.SelectedProcess()
 		if sel != nil {
@@ -538,6 +1001,16 @@ func (a App) updateDashboardKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return a, nil
 
+	case "X":
+		names := erroredNames(a.pm.List())
+		if len(names) > 0 {
+			msg := fmt.Sprintf("Restart %d errored process(es)?\n\n  %s", len(names), strings.Join(names, "\n  "))
+			a.confirm = newConfirmModel(msg, "restart-errored", strings.Join(names, "\n"))
+			a.confirm.SetSize(a.width, a.height)
+			a.overlay = overlayConfirm
+		}
+		return a, nil
+
 	case "t":
 		sel := a.dashboard.SelectedProcess()
 		if sel == nil || sel.Status != devdash.StatusRunning {
@@ -550,41 +1023,91 @@ func (a App) updateDashboardKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			a.overlay = overlayConfirm
 			return a, nil
 		}
-		a.tunnelOvl = newTunnelOverlay(sel.Info.Name)
-		a.tunnelOvl.SetSize(a.width, a.height)
-		a.overlay = overlayTunnel
-		return a, startTunnelCmd(a.pm, sel.Info.Name)
+		return a.openTunnel(sel.Info.Name)
 
 	case "u":
 		sel := a.dashboard.SelectedProcess()
 		if sel != nil && sel.Tunnel != nil && sel.Tunnel.URL != "" {
-			return a, copyTunnelURL(sel.Tunnel.URL)
+			return a, copyTunnelURL(a.pm.Runner(), sel.Tunnel.URL)
+		}
+		return a, nil
+
+	case "U":
+		return a.toggleTunnelDock()
+
+	case "l":
+		if sel := a.dashboard.SelectedProcess(); sel != nil {
+			return a, copyLocalURL(a.pm.Runner(), sel)
 		}
 		return a, nil
 
+	case "L":
+		if sel := a.dashboard.SelectedProcess(); sel != nil {
+			return a, copyCurlCommand(a.pm.Runner(), sel)
+		}
+		return a, nil
+
+	case "O":
+		return a.showOverview()
+
+	case "V":
+		return a.showSplit()
+
+	case "R":
+		if sel := a.dashboard.SelectedProcess(); sel != nil {
+			return a, revealInFileManager(a.pm.Runner(), sel.Info)
+		}
+		return a, nil
+
+	case "w":
+		return a.toggleWrapIndent()
+
+	case "e", "E":
+		step := 1
+		if msg.String() == "E" {
+			step = -1
+		}
+		return a, a.dashboard.jumpToLevel(step)
+
+	case "b":
+		if sel := a.dashboard.SelectedProcess(); sel != nil {
+			return a, toggleBuildTimer(sel, a.pm.Clock().Now())
+		}
+		return a, nil
+
+	case "<", ">":
+		delta := listRatioStep
+		if msg.String() == "<" {
+			delta = -listRatioStep
+		}
+		a.cfg.ListRatio = a.dashboard.adjustListRatio(delta)
+		_ = config.SaveConfig(a.cfg)
+		a.resizePTYs()
+		return a, nil
+
+	case "D":
+		a.dashboard.dense = !a.dashboard.dense
+		a.cfg.DenseList = a.dashboard.dense
+		_ = config.SaveConfig(a.cfg)
+		return a, nil
+
+	case "W":
+		return a.confirmSaveProjectLaunch()
+
+	case "P":
+		a.pm.FlushAll()
+		return a, feedbackCmd("[Flushed partial lines of all sessions]")
+
+	case "F":
+		a.globalSearch = newGlobalSearchModel()
+		a.globalSearch.SetSize(a.width, a.height)
+		a.overlay = overlayGlobalSearch
+		return a, textinput.Blink
+
 	case "enter":
 		sel := a.dashboard.SelectedProcess()
 		if sel != nil {
-			a.dashboard.unsubscribeLogs()
-			a.logView = newLogViewModel(sel)
-			a.logView.SetSize(a.width, a.height)
-			a.view = viewLogFull
-
-			// Resize VTerm to full width for fullscreen log view
-			_ = a.pm.ResizePTY(sel.Info.Name, uint16(a.height-2), uint16(a.width))
-
-			var cmds []tea.Cmd
-			sizeMsg := tea.WindowSizeMsg{Width: a.width, Height: a.height}
-			var cmd tea.Cmd
-			a.logView, cmd = a.logView.Update(sizeMsg)
-			if cmd != nil {
-				cmds = append(cmds, cmd)
-			}
-			subCmd := a.logView.Subscribe()
-			if subCmd != nil {
-				cmds = append(cmds, subCmd)
-			}
-			return a, tea.Batch(cmds...)
+			return a.openLogView(sel)
 		}
 		return a, nil
 	}
@@ -594,6 +1117,101 @@ func (a App) updateDashboardKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return a, cmd
 }
 
+// resizePTYs resizes every process PTY to match the log viewport width (not the
+// full terminal width)
+func (a App) resizePTYs() {
+	ptyRows, ptyCols := a.ptySize()
+	for _, rp := range a.pm.List() {
+		_ = a.pm.ResizePTY(rp.Info.Name, ptyRows, ptyCols)
+	}
+}
+
+// ptySize returns the terminal size processes are shown at. In dashboard view,
+// the log panel is the right-hand split; in fullscreen log view, it's the full
+// width. 0x0 until the first WindowSizeMsg.
+func (a App) ptySize() (rows, cols uint16) {
+	if a.width == 0 || a.height == 0 {
+		return 0, 0
+	}
+	w := a.width
+	if a.view != viewLogFull {
+		_, rightW := a.dashboard.panelWidths()
+		w = rightW - 2 // subtract borders
+	}
+	return uint16(max(a.height-2, 1)), uint16(max(w, 1))
+}
+
+// resumeRendering ends the pause of an unfocused terminal and renders the log
+// lines that arrived meanwhile, once. A frozen visual selection stays frozen.
+func (a App) resumeRendering() App {
+	a.dashboard.paused = false
+	a.logView.paused = false
+	a.split.panes[0].paused = false
+	a.split.panes[1].paused = false
+	switch {
+	case a.view == viewLogFull && a.logView.ready && a.logView.logBuf != nil && !a.logView.selection.isActive():
+		a.logView.showNewLines()
+	case a.view == viewDashboard && a.dashboard.ready && a.dashboard.logBuf != nil && !a.dashboard.selection.isActive():
+		a.dashboard.showNewLines()
+	case a.view == viewSplit:
+		a.split.panes[0].showNewLines()
+		a.split.panes[1].showNewLines()
+	}
+	return a
+}
+
+// openLogView switches from the dashboard to the fullscreen log view of rp
+func (a App) openLogView(rp *devdash.RunningProcess) (App, tea.Cmd) {
+	a.dashboard.unsubscribeLogs()
+	a.logView = newLogViewModel(rp)
+	a.logView.wrapIndent = a.cfg.WrapIndent
+	a.logView.clock, a.logView.runner = a.pm.Clock(), a.pm.Runner()
+	a.logView.timeFmt = a.dashboard.timeFmt
+	a.logView.SetSize(a.width, a.height)
+	a.view = viewLogFull
+
+	// Resize VTerm to full width for fullscreen log view
+	_ = a.pm.ResizePTY(rp.Info.Name, uint16(a.height-2), uint16(a.width))
+
+	var cmds []tea.Cmd
+	sizeMsg := tea.WindowSizeMsg{Width: a.width, Height: a.height}
+	var cmd tea.Cmd
+	a.logView, cmd = a.logView.Update(sizeMsg)
+	if cmd != nil {
+		cmds = append(cmds, cmd)
+	}
+	subCmd := a.logView.Subscribe()
+	if subCmd != nil {
+		cmds = append(cmds, subCmd)
+	}
+	return a, tea.Batch(cmds...)
+}
+
+// OpenSession starts the program in the fullscreen log view of the named
+// session instead of the dashboard, for `devdash logs`. q then quits; esc
+// reveals the dashboard with the session selected.
+func (a App) OpenSession(name string) (App, error) {
+	rp := a.pm.Get(name)
+	if rp == nil {
+		var names []string
+		for _, p := range a.pm.List() {
+			names = append(names, p.Info.Name)
+		}
+		if len(names) == 0 {
+			return a, fmt.Errorf("no session %q: no sessions are running", name)
+		}
+		sort.Strings(names)
+		return a, fmt.Errorf("no session %q (sessions: %s)", name, strings.Join(names, ", "))
+	}
+	a.dashboard.selectByName(name)
+	a.logView = newLogViewModel(rp)
+	a.logView.wrapIndent = a.cfg.WrapIndent
+	a.logView.clock, a.logView.runner = a.pm.Clock(), a.pm.Runner()
+	a.logView.standalone = true
+	a.view = viewLogFull
+	return a, nil
+}
+
 // updateLogViewKeys handles key events on the fullscreen log view
 func (a App) updateLogViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Interactive mode: forward all keys to PTY (except exit key)
@@ -606,15 +1224,26 @@ func (a App) updateLogViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		key := msg.String()
 		switch key {
 		case "y":
+			flushLog(a.logView.logBuf)
 			text := a.logView.selection.selectedText()
 			count := a.logView.selection.selectedLineCount()
 			a.logView.selection.deactivate()
 			a.logView.refreshLogViewport()
-			return a, copySelectedLines(text, count)
+			return a, copySelectedLines(a.pm.Runner(), text, count)
+		case "Y":
+			flushLog(a.logView.logBuf)
+			text, count := a.logView.selection.copyStay()
+			return a, copySelectedLines(a.pm.Runner(), text, count)
 		case "esc":
 			a.logView.selection.deactivate()
 			a.logView.refreshLogViewport()
 			return a, nil
+		case "o":
+			workDir := ""
+			if a.logView.rp != nil {
+				workDir = a.logView.rp.Info.WorkDir
+			}
+			return a, openFileRefInLine(a.pm.Runner(), a.logView.selection.cursorLine(), workDir)
 		default:
 			action := a.logView.selection.handleKey(key, a.logView.viewport.Height)
 			if action == selActionMoved {
@@ -630,19 +1259,31 @@ func (a App) updateLogViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 
 	switch msg.String() {
+	case "w":
+		return a.toggleWrapIndent()
+
+	case "e", "E":
+		step := 1
+		if msg.String() == "E" {
+			step = -1
+		}
+		return a, a.logView.jumpToLevel(step)
+
+	case "L":
+		if a.logView.rp != nil {
+			return a, copyCurlCommand(a.pm.Runner(), a.logView.rp)
+		}
+		return a, nil
+
 	case "q", "esc":
+		if a.logView.standalone && msg.String() == "q" {
+			return a, tea.Quit
+		}
 		a.logView.Unsubscribe()
 		a.view = viewDashboard
 
 		// Resize PTY back to dashboard panel width
-		_, rightW := a.dashboard.panelWidths()
-		ptyCols := uint16(rightW - 2)
-		if ptyCols < 1 {
-			ptyCols = 1
-		}
-		for _, rp := range a.pm.List() {
-			_ = a.pm.ResizePTY(rp.Info.Name, uint16(a.height-2), ptyCols)
-		}
+		a.resizePTYs()
 
 		a.dashboard.SetProcesses(a.pm.List())
 		cmd := a.dashboard.SubscribeToSelected()
@@ -660,11 +1301,31 @@ func (a App) updateLogViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return a, cmd
 }
 
-// View implements tea.Model
+// Minimum usable terminal size. The launcher popup is the widest overlay
+// (50 columns plus border and padding), and needs ~20 rows for its chrome,
+// step header and at least a handful of list items.
+const (
+	minTermWidth  = 60
+	minTermHeight = 20
+)
+
+// View implements tea.Model. In monochrome the colors are stripped from the
+// finished frame, leaving bold, underline and reverse video.
 func (a App) View() string {
+	if monochrome {
+		return stripColors(a.render())
+	}
+	return a.render()
+}
+
+// render draws the current view and any overlay over it
+func (a App) render() string {
 	if a.width == 0 || a.height == 0 {
 		return "Initializing..."
 	}
+	if a.width < minTermWidth || a.height < minTermHeight {
+		return renderTooSmall(a.width, a.height)
+	}
 
 	var base string
 	switch a.view {
@@ -672,6 +1333,10 @@ func (a App) View() string {
 		base = a.dashboard.View()
 	case viewLogFull:
 		base = a.logView.View()
+	case viewOverview:
+		base = a.overview.View(a.dashboard.clipboardMsg)
+	case viewSplit:
+		base = a.split.View(a.dashboard.clipboardMsg)
 	}
 
 	switch a.overlay {
@@ -683,14 +1348,38 @@ func (a App) View() string {
 		return a.settings.View()
 	case overlayTunnel:
 		return a.tunnelOvl.View()
+	case overlayGlobalSearch:
+		return a.globalSearch.View()
+	case overlayAttach:
+		return a.attach.View()
+	case overlayScanning:
+		return a.scanning.View()
+	case overlayTags:
+		return a.tags.View()
+	case overlayLaunchError:
+		return a.launchErr.View()
+	case overlaySignal:
+		return a.signalMenu.View()
 	}
 
 	return base
 }
 
+// renderTooSmall replaces the layout with a hint when the terminal can't fit it
+func renderTooSmall(w, h int) string {
+	msg := lipgloss.JoinVertical(lipgloss.Center,
+		statusError.Render("Terminal too small"),
+		dimStyle.Render(fmt.Sprintf("min %dx%d, now %dx%d", minTermWidth, minTermHeight, w, h)),
+		dimStyle.Render("q: quit"),
+	)
+	return lipgloss.NewStyle().MaxWidth(w).MaxHeight(h).Render(
+		lipgloss.Place(w, h, lipgloss.Center, lipgloss.Center, msg),
+	)
+}
+
 // handleDashboardInteractiveKey forwards keys to stdin or exits interactive mode (dashboard)
 func (a App) handleDashboardInteractiveKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	exit, newLast, forward := shouldExitInteractive(time.Now(), a.lastEsc, msg, interactiveExitWindow)
+	exit, newLast, forward := shouldExitInteractive(a.pm.Clock().Now(), a.lastEsc, msg, interactiveExitWindow)
 	a.lastEsc = newLast
 	if exit {
 		a.dashboard.isInteractive = false
@@ -712,7 +1401,7 @@ func (a App) handleDashboardInteractiveKey(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 
 // handleLogViewInteractiveKey forwards keys to PTY or exits interactive mode (logview)
 func (a App) handleLogViewInteractiveKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	exit, newLast, forward := shouldExitInteractive(time.Now(), a.lastEsc, msg, interactiveExitWindow)
+	exit, newLast, forward := shouldExitInteractive(a.pm.Clock().Now(), a.lastEsc, msg, interactiveExitWindow)
 	a.lastEsc = newLast
 	if exit {
 		a.logView.isInteractive = false
@@ -733,54 +1422,41 @@ func (a App) handleLogViewInteractiveKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 type processLaunchedMsg struct{ name string }
 type processStoppedMsg struct{ name string }
-type processErrorMsg struct{ name, err string }
+// processErrorMsg reports a failed process action. start is set when a launch
+// or restart failed before the process got a PID; command is then the resolved
+// command line to retry by hand ("" if it couldn't be resolved).
+type processErrorMsg struct {
+	name, err string
+	start     bool
+	command   string
+}
 
 // launchProcess creates and starts a new process
 func (a App) launchProcess(req LaunchRequestMsg) tea.Cmd {
 	pm := a.pm
+	rows, cols := a.ptySize()
 	return func() tea.Msg {
-		wt := req.Worktree
-		proj := req.Project
-		port := req.Port
-
-		sessionName := config.SessionName(wt.Name, proj.Name)
-
-		// For workspace packages, use --filter and run from workspace root
-		filterPkg := ""
-		workDir := proj.Path
-		if proj.WorkspaceRoot != "" && proj.PkgName != "" {
-			filterPkg = proj.PkgName
-			workDir = proj.WorkspaceRoot
-		}
-
-		pmBin := req.PackageManager
-		if pmBin == "" {
-			pmBin = "pnpm"
-		}
-		pmPath := resolveBinary(pmBin)
-
-		cmd, args, extraEnv := config.DevCommand(proj.IsEncore, port, pmPath, filterPkg, req.Script)
-
-		info := devdash.SessionInfo{
-			Name:     sessionName,
-			Port:     port,
-			Command:  cmd,
-			Args:     args,
-			ExtraEnv: extraEnv,
-			WorkDir:  workDir,
-			Project:  proj.Name,
-			WtName:   wt.Name,
-			WtPath:   wt.Path,
+		info, err := buildSessionInfo(pm.Runner(), req)
+		if err != nil {
+			return processErrorMsg{name: info.Name, err: err.Error(), start: true}
 		}
+		sessionName := info.Name
+		info.Rows, info.Cols = int(rows), int(cols)
 
-		_, err := pm.Start(info)
+		_, err = pm.Start(info)
 		if err != nil {
-			return processErrorMsg{name: sessionName, err: err.Error()}
+			return processErrorMsg{name: sessionName, err: err.Error(), start: isStartError(err), command: manualCommand(info)}
 		}
 		return processLaunchedMsg{name: sessionName}
 	}
 }
 
+// isStartError reports whether err means the process could not be spawned
+func isStartError(err error) bool {
+	var startErr *devdash.StartError
+	return errors.As(err, &startErr)
+}
+
 // killProcess stops a running process
 func (a App) killProcess(name string) tea.Cmd {
 	pm := a.pm
@@ -803,14 +1479,215 @@ func (a App) killProcess(name string) tea.Cmd {
 func (a App) restartProcess(name string) tea.Cmd {
 	pm := a.pm
 	return func() tea.Msg {
+		info, _ := pm.Info(name)
 		_, err := pm.Restart(name)
 		if err != nil {
-			return processErrorMsg{name: name, err: err.Error()}
+			return processErrorMsg{name: name, err: err.Error(), start: isStartError(err), command: manualCommand(info)}
 		}
 		return processLaunchedMsg{name: name}
 	}
 }
 
+// scan shows the scanning overlay and runs discovery in the background;
+// handleDiscoveryDone opens what req.purpose asks for
+func (a App) scan(req scanRequest) (App, tea.Cmd) {
+	req.seq = a.scanning.start(a.overlay)
+	a.overlay = overlayScanning
+	discover := a.discover(req)
+	return a, tea.Batch(discover, a.scanning.spinner.Tick)
+}
+
+// discover numbers a discovery run so handleDiscoveryDone can tell which of
+// several overlapping runs is the newest, and returns the command running it
+func (a *App) discover(req scanRequest) tea.Cmd {
+	a.scanGen++
+	req.gen = a.scanGen
+	return discoverCmd(req, a.cfg)
+}
+
+// handleDiscoveryDone stores fresh worktrees and, unless the scan was
+// cancelled, opens the overlay it was started for
+func (a App) handleDiscoveryDone(msg discoveryDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.req.gen >= a.worktreesGen {
+		a.worktrees, a.worktreesGen = msg.worktrees, msg.req.gen
+		if msg.req.purpose == scanRefresh && a.overlay == overlayLauncher {
+			a.launcher = a.launcher.refreshWorktrees(msg.launcher)
+		}
+	}
+	if msg.req.purpose == scanRefresh || a.overlay != overlayScanning || msg.req.seq != a.scanning.seq {
+		return a, nil
+	}
+
+	switch msg.req.purpose {
+	case scanLauncher, scanDuplicate:
+		if !msg.ok {
+			a.overlay = overlayNone
+			return a, feedbackCmd(fmt.Sprintf("[Can't duplicate: %s/%s not found]", msg.req.dup.WtName, msg.req.dup.Project))
+		}
+		a.launcher = msg.launcher
+		a.launcher.runner = a.pm.Runner()
+		a.launcher.clock = a.pm.Clock()
+		a.launcher.SetSize(a.width, a.height)
+		a.overlay = overlayLauncher
+	case scanSettings:
+		a.settings = newSettingsModel(a.cfg.ScanDirs)
+		a.settings.SetSize(a.width, a.height)
+		fallthrough
+	case scanRescan:
+		a.settings.totalFound = len(msg.worktrees)
+		a.settings.worktreeCounts = countWorktreesPerDir(msg.req.scanDirs, msg.worktrees)
+		a.overlay = overlaySettings
+	}
+	return a, nil
+}
+
+// restartProcessWith restarts a process with a re-detected launch configuration
+func (a App) restartProcessWith(name string, info devdash.SessionInfo) tea.Cmd {
+	pm := a.pm
+	rows, cols := a.ptySize()
+	info.Rows, info.Cols = int(rows), int(cols)
+	return func() tea.Msg {
+		_, err := pm.RestartWith(name, info)
+		if err != nil {
+			return processErrorMsg{name: name, err: err.Error(), start: isStartError(err), command: manualCommand(info)}
+		}
+		return processLaunchedMsg{name: name}
+	}
+}
+
+// launchConfigCheckedMsg carries the re-detected launch configuration of a
+// session about to be restarted. diff is empty when it can't be re-detected or
+// would run the same command.
+type launchConfigCheckedMsg struct {
+	name string
+	next devdash.SessionInfo
+	diff []string
+}
+
+// changedLaunchConfigCmd re-detects the launch configuration of a session
+// about to be restarted off the UI goroutine and diffs it against the current one
+func changedLaunchConfigCmd(pm *devdash.ProcessManager, cfg *config.LocalConfig, wts []discovery.Worktree, name string) tea.Cmd {
+	return func() tea.Msg {
+		info, ok := pm.Info(name)
+		if !ok {
+			return launchConfigCheckedMsg{name: name}
+		}
+		next, ok := redetectSessionInfo(pm.Runner(), cfg, wts, info)
+		if !ok {
+			return launchConfigCheckedMsg{name: name}
+		}
+		return launchConfigCheckedMsg{name: name, next: next, diff: launchDiff(info, next)}
+	}
+}
+
+// portHoldersPrompt asks whether to kill the processes listening on port
+func portHoldersPrompt(port int, holders []devdash.PortHolder) string {
+	var lines []string
+	for _, h := range holders {
+		lines = append(lines, "  "+h.String())
+		if h.CommandLine != "" {
+			lines = append(lines, "    $ "+h.CommandLine)
+		}
+		if h.Dir != "" {
+			lines = append(lines, "    in "+h.Dir)
+		}
+	}
+	question := "Kill and launch? (No launches anyway)"
+	if slices.ContainsFunc(holders, func(h devdash.PortHolder) bool { return h.Session != "" }) {
+		question = "Kill the others and launch? Sessions keep running. (No launches anyway)"
+	}
+	return fmt.Sprintf("Port %d is already in use by:\n\n%s\n\n%s", port, strings.Join(lines, "\n"), question)
+}
+
+// joinPIDs encodes holder PIDs as a confirm target, comma-separated. Holders
+// in a devdash session are left out: they're stopped through the manager.
+func joinPIDs(holders []devdash.PortHolder) string {
+	var pids []string
+	for _, h := range holders {
+		if h.Session == "" {
+			pids = append(pids, strconv.Itoa(h.PID))
+		}
+	}
+	return strings.Join(pids, ",")
+}
+
+// portHoldersMsg reports the processes already listening on a launch's port
+type portHoldersMsg struct {
+	req     LaunchRequestMsg
+	holders []devdash.PortHolder
+}
+
+// checkPortHoldersCmd looks up what listens on req's port off the UI
+// goroutine. A free port continues with the launch.
+func checkPortHoldersCmd(pm *devdash.ProcessManager, req LaunchRequestMsg) tea.Cmd {
+	return func() tea.Msg {
+		holders := pm.PortHolders(req.Port)
+		if len(holders) == 0 {
+			return req
+		}
+		return portHoldersMsg{req: req, holders: holders}
+	}
+}
+
+// killPortHoldersCmd kills the comma-separated PIDs, then continues with the launch.
+// Only PIDs that still hold the port are killed: one that exited while the
+// prompt was open may already belong to an unrelated process.
+func killPortHoldersCmd(pm *devdash.ProcessManager, pids string, req LaunchRequestMsg) tea.Cmd {
+	return func() tea.Msg {
+		holding := make(map[int]bool)
+		for _, h := range pm.PortHolders(req.Port) {
+			holding[h.PID] = h.Session == ""
+		}
+		for _, s := range strings.Split(pids, ",") {
+			if pid, err := strconv.Atoi(s); err == nil && holding[pid] {
+				_ = pm.KillPID(pid)
+			}
+		}
+		return req
+	}
+}
+
+// uniqueSessionName returns base with the first free "-N" suffix (N >= 2) so a
+// second instance of a session doesn't collide with the first
+func uniqueSessionName(procs []*devdash.RunningProcess, base string) string {
+	taken := make(map[string]bool, len(procs))
+	for _, rp := range procs {
+		taken[rp.Info.Name] = true
+	}
+	if !taken[base] {
+		return base
+	}
+	for i := 2; ; i++ {
+		name := fmt.Sprintf("%s-%d", base, i)
+		if !taken[name] {
+			return name
+		}
+	}
+}
+
+// nextFreePort returns the first port above port not used by another session
+func nextFreePort(procs []*devdash.RunningProcess, port int) int {
+	used := make(map[int]bool, len(procs))
+	for _, rp := range procs {
+		used[rp.Info.Port] = true
+	}
+	for port++; used[port]; port++ {
+	}
+	return port
+}
+
+// erroredNames returns the sorted names of processes in StatusError
+func erroredNames(procs []*devdash.RunningProcess) []string {
+	var names []string
+	for _, rp := range procs {
+		if rp.Status == devdash.StatusError {
+			names = append(names, rp.Info.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 // --- Dependency check ---
 
 // hasDeps checks if dependencies are properly installed in the worktree root.
@@ -838,12 +1715,13 @@ type installDoneMsg struct {
 // startInstallProcess launches package manager install as a visible process via ProcessManager
 func (a App) startInstallProcess(dir string, pmPath string) tea.Cmd {
 	pm := a.pm
+	frozen := a.cfg.FrozenInstall
 	return func() tea.Msg {
-		sessionName := fmt.Sprintf("install/%s", filepath.Base(dir))
+		sessionName := installSessionPrefix + filepath.Base(dir)
 		info := devdash.SessionInfo{
 			Name:    sessionName,
 			Command: pmPath,
-			Args:    []string{"install"},
+			Args:    config.InstallArgs(pmPath, dir, frozen),
 			WorkDir: dir,
 		}
 		_, err := pm.Start(info)
@@ -870,9 +1748,9 @@ func (a App) watchInstallDone(name string) tea.Cmd {
 	}
 }
 
-// resolveBinary finds the full path for a binary name
-func resolveBinary(name string) string {
-	path, err := exec.LookPath(name)
+// resolveBinary finds the full path for a binary name through r
+func resolveBinary(r devdash.Runner, name string) string {
+	path, err := r.LookPath(name)
 	if err != nil {
 		return name
 	}
@@ -0,0 +1,174 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/kimaguri/simplx-toolkit/internal/config"
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+)
+
+func TestErroredNames(t *testing.T) {
+	procs := []*devdash.RunningProcess{
+		{Info: devdash.SessionInfo{Name: "web"}, Status: devdash.StatusError},
+		{Info: devdash.SessionInfo{Name: "api"}, Status: devdash.StatusRunning},
+		{Info: devdash.SessionInfo{Name: "admin"}, Status: devdash.StatusError},
+		{Info: devdash.SessionInfo{Name: "docs"}, Status: devdash.StatusStopped},
+	}
+
+	got := erroredNames(procs)
+	want := []string{"admin", "web"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("erroredNames = %v, want %v", got, want)
+	}
+
+	if got := erroredNames(nil); len(got) != 0 {
+		t.Errorf("expected no names, got %v", got)
+	}
+}
+
+func TestAppView_TerminalTooSmall(t *testing.T) {
+	a := App{width: 40, height: 10}
+	if got := ansi.Strip(a.View()); !strings.Contains(got, "Terminal too small") || !strings.Contains(got, "now 40x10") {
+		t.Errorf("expected too-small message, got %q", got)
+	}
+	for _, line := range strings.Split(a.View(), "\n") {
+		if w := ansi.StringWidth(line); w > 40 {
+			t.Errorf("line wider than terminal (%d): %q", w, line)
+		}
+	}
+}
+
+func TestUniqueSessionNameAndPort(t *testing.T) {
+	procs := []*devdash.RunningProcess{
+		{Info: devdash.SessionInfo{Name: "dev-web", Port: 4000}},
+		{Info: devdash.SessionInfo{Name: "dev-web-2", Port: 4001}},
+		{Info: devdash.SessionInfo{Name: "dev-api", Port: 4003}},
+	}
+	if got := uniqueSessionName(procs, "dev-web"); got != "dev-web-3" {
+		t.Errorf("uniqueSessionName = %q, want dev-web-3", got)
+	}
+	if got := uniqueSessionName(procs, "dev-new"); got != "dev-new" {
+		t.Errorf("uniqueSessionName = %q, want dev-new", got)
+	}
+	if got := nextFreePort(procs, 4000); got != 4002 {
+		t.Errorf("nextFreePort = %d, want 4002", got)
+	}
+}
+
+func TestPortHoldersPrompt(t *testing.T) {
+	holders := []devdash.PortHolder{
+		{PID: 12, Command: "node", CommandLine: "node server.js --port 4000", Dir: "/srv/api"},
+		{PID: 34, Command: "vite"},
+	}
+	prompt := portHoldersPrompt(4000, holders)
+	for _, want := range []string{"Port 4000", "node (PID 12)", "$ node server.js --port 4000", "in /srv/api", "vite (PID 34)"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+	if got := joinPIDs(holders); got != "12,34" {
+		t.Errorf("joinPIDs = %q", got)
+	}
+
+	// A session's own process is named but never a kill target
+	holders = append(holders, devdash.PortHolder{PID: 56, Command: "node", Session: "web"})
+	prompt = portHoldersPrompt(4000, holders)
+	if !strings.Contains(prompt, "node (PID 56, session web)") || !strings.Contains(prompt, "Sessions keep running") {
+		t.Errorf("prompt doesn't set the session apart:\n%s", prompt)
+	}
+	if got := joinPIDs(holders); got != "12,34" {
+		t.Errorf("joinPIDs with a session holder = %q, want it left out", got)
+	}
+}
+
+func TestPortHoldersMsg(t *testing.T) {
+	req := LaunchRequestMsg{SessionName: "api", Port: 4000, portChecked: true}
+	a := App{cfg: &config.LocalConfig{}, dashboard: newDashboardModel()}
+
+	// Held by another session only: no prompt, the launch goes ahead
+	m, cmd := a.Update(portHoldersMsg{req: req, holders: []devdash.PortHolder{{PID: 56, Command: "node", Session: "web"}}})
+	if got := m.(App); got.overlay == overlayConfirm || got.pendingLaunch != nil {
+		t.Error("a port held only by a session shouldn't prompt")
+	}
+	if cmd == nil {
+		t.Fatal("expected the launch to continue")
+	}
+
+	m, _ = a.Update(portHoldersMsg{req: req, holders: []devdash.PortHolder{{PID: 12, Command: "node"}}})
+	got := m.(App)
+	if got.overlay != overlayConfirm || got.pendingLaunch == nil || got.confirm.target != "12" {
+		t.Errorf("overlay %d, pending %v, target %q; want a kill prompt for PID 12", got.overlay, got.pendingLaunch, got.confirm.target)
+	}
+}
+
+func TestRestartChangedPrompt(t *testing.T) {
+	a := App{cfg: &config.LocalConfig{}, dashboard: newDashboardModel()}
+
+	m, _ := a.Update(launchConfigCheckedMsg{name: "api", next: devdash.SessionInfo{Command: "pnpm"}, diff: []string{"command: npm -> pnpm"}})
+	got := m.(App)
+	if got.overlay != overlayConfirm || got.pendingRestart == nil || got.confirm.action != "restart-changed" {
+		t.Fatalf("overlay %d, pending %v, action %q; want a restart-changed prompt", got.overlay, got.pendingRestart, got.confirm.action)
+	}
+
+	// esc closes the prompt without restarting
+	_, cmd := got.confirm.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m, cmd = got.Update(cmd())
+	got = m.(App)
+	if cmd != nil || got.pendingRestart != nil || got.overlay != overlayNone {
+		t.Errorf("esc: cmd %v, pending %v, overlay %d; want the restart cancelled", cmd != nil, got.pendingRestart, got.overlay)
+	}
+}
+
+func TestOpenSession(t *testing.T) {
+	pm := devdash.NewProcessManager(t.TempDir(), t.TempDir())
+	if _, err := pm.Start(devdash.SessionInfo{Name: "web", Command: "sleep", Args: []string{"30"}, WorkDir: t.TempDir()}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = pm.Stop("web") })
+	a := App{pm: pm, cfg: &config.LocalConfig{}, dashboard: newDashboardModel()}
+	a.dashboard.SetProcesses(pm.List())
+
+	if _, err := a.OpenSession("api"); err == nil || !strings.Contains(err.Error(), "sessions: web") {
+		t.Errorf("unknown session: err = %v", err)
+	}
+
+	a, err := a.OpenSession("web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.view != viewLogFull || !a.logView.standalone || a.logView.sessionName != "web" {
+		t.Fatalf("view %d, standalone %v, session %q", a.view, a.logView.standalone, a.logView.sessionName)
+	}
+
+	if _, cmd := a.updateLogViewKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}}); cmd == nil {
+		t.Error("q should quit")
+	} else if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("q should quit")
+	}
+	model, _ := a.updateLogViewKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	if a = model.(App); a.view != viewDashboard || a.dashboard.SelectedProcess().Info.Name != "web" {
+		t.Errorf("esc should reveal the dashboard with web selected, view %d", a.view)
+	}
+}
+
+func TestProcessLaunchedSizesPTYToPanel(t *testing.T) {
+	pm := devdash.NewProcessManager(t.TempDir(), t.TempDir())
+	if _, err := pm.Start(devdash.SessionInfo{Name: "web", Command: "sleep", Args: []string{"30"}, WorkDir: t.TempDir()}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = pm.Stop("web") })
+	a := App{pm: pm, cfg: &config.LocalConfig{}, dashboard: newDashboardModel(), width: 120, height: 40}
+	a.dashboard.width, a.dashboard.height = a.width, a.height
+
+	model, _ := a.Update(processLaunchedMsg{name: "web"})
+	a = model.(App)
+	_, rightW := a.dashboard.panelWidths()
+	if info := pm.Get("web").Info; info.Cols != rightW-2 || info.Rows != a.height-2 {
+		t.Errorf("PTY is %dx%d, want the log panel's %dx%d", info.Cols, info.Rows, rightW-2, a.height-2)
+	}
+}
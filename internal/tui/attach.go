@@ -0,0 +1,188 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+)
+
+// attachRequestMsg asks the app to adopt an external process
+type attachRequestMsg struct {
+	info devdash.SessionInfo
+}
+
+// attachCancelledMsg closes the attach overlay without attaching
+type attachCancelledMsg struct{}
+
+// attachedMsg reports a successful Attach
+type attachedMsg struct {
+	name string
+}
+
+// attachFailedMsg reports an Attach error back to the still-open form
+type attachFailedMsg struct {
+	err error
+}
+
+// Attach form fields, in tab order
+const (
+	attachFieldPID = iota
+	attachFieldPort
+	attachFieldName
+	attachFieldLog
+	attachFieldCount
+)
+
+// attachFieldLabels are shown left of each input
+var attachFieldLabels = [attachFieldCount]string{"PID", "Port", "Name", "Log file"}
+
+// attachModel is the "attach to PID" overlay: a small form for a process
+// started outside devdash
+type attachModel struct {
+	inputs [attachFieldCount]textinput.Model
+	focus  int
+	err    string
+	width  int
+	height int
+}
+
+// newAttachModel creates the form with the PID field focused
+func newAttachModel() attachModel {
+	placeholders := [attachFieldCount]string{"12345", "3000 (optional, for tunnels)", "pid-<PID> (optional)", "/path/to/server.log (optional)"}
+	var m attachModel
+	for i := range m.inputs {
+		ti := textinput.New()
+		ti.Placeholder = placeholders[i]
+		ti.Width = 40
+		ti.CharLimit = 256
+		m.inputs[i] = ti
+	}
+	m.inputs[attachFieldPID].CharLimit = 10
+	m.inputs[attachFieldPort].CharLimit = 5
+	m.inputs[attachFieldPID].Focus()
+	return m
+}
+
+// Update handles form input
+func (m attachModel) Update(msg tea.Msg) (attachModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "esc":
+		return m, func() tea.Msg { return attachCancelledMsg{} }
+	case "tab", "down":
+		m.setFocus((m.focus + 1) % attachFieldCount)
+		return m, textinput.Blink
+	case "shift+tab", "up":
+		m.setFocus((m.focus + attachFieldCount - 1) % attachFieldCount)
+		return m, textinput.Blink
+	case "enter":
+		info, err := m.sessionInfo()
+		if err != nil {
+			m.err = err.Error()
+			return m, nil
+		}
+		return m, func() tea.Msg { return attachRequestMsg{info: info} }
+	}
+
+	var cmd tea.Cmd
+	m.inputs[m.focus], cmd = m.inputs[m.focus].Update(keyMsg)
+	m.err = ""
+	return m, cmd
+}
+
+// setFocus moves the cursor to input i
+func (m *attachModel) setFocus(i int) {
+	m.inputs[m.focus].Blur()
+	m.focus = i
+	m.inputs[m.focus].Focus()
+}
+
+// sessionInfo validates the form. Only the PID is required; Attach fills in
+// the name and command.
+func (m attachModel) sessionInfo() (devdash.SessionInfo, error) {
+	value := func(i int) string { return strings.TrimSpace(m.inputs[i].Value()) }
+
+	pid, err := strconv.Atoi(value(attachFieldPID))
+	if err != nil || pid <= 0 {
+		return devdash.SessionInfo{}, fmt.Errorf("PID must be a positive number")
+	}
+	port := 0
+	if s := value(attachFieldPort); s != "" {
+		port, err = strconv.Atoi(s)
+		if err != nil || port <= 0 || port > 65535 {
+			return devdash.SessionInfo{}, fmt.Errorf("port must be 1-65535")
+		}
+	}
+	logPath := value(attachFieldLog)
+	if logPath != "" {
+		logPath = normalizeDir(logPath)
+	}
+	return devdash.SessionInfo{
+		PID:     pid,
+		Port:    port,
+		Name:    value(attachFieldName),
+		LogPath: logPath,
+	}, nil
+}
+
+// View renders the attach overlay
+func (m attachModel) View() string {
+	title := modalTitleStyle.Render("Attach to PID")
+
+	var rows []string
+	for i, in := range m.inputs {
+		label := dimStyle.Render(fmt.Sprintf("%-9s", attachFieldLabels[i]))
+		if i == m.focus {
+			label = selectedItemStyle.Render(fmt.Sprintf("%-9s", attachFieldLabels[i]))
+		}
+		rows = append(rows, label+" "+in.View())
+	}
+
+	note := dimStyle.Render("Tracks liveness and tails the log file. Kill and tunnel work;\nrestart and interactive mode don't.")
+
+	var errLine string
+	if m.err != "" {
+		errLine = statusError.Render(m.err)
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		"",
+		strings.Join(rows, "\n"),
+		"",
+		note,
+		errLine,
+		"",
+		dimStyle.Render("tab:next field  enter:attach  esc:cancel"),
+	)
+
+	popup := modalStyle.Width(64).Render(content)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, popup)
+}
+
+// SetSize updates dimensions for centering
+func (m *attachModel) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+}
+
+// attachCmd adopts the process in the background
+func attachCmd(pm *devdash.ProcessManager, info devdash.SessionInfo) tea.Cmd {
+	return func() tea.Msg {
+		rp, err := pm.Attach(info)
+		if err != nil {
+			return attachFailedMsg{err: err}
+		}
+		return attachedMsg{name: rp.Info.Name}
+	}
+}
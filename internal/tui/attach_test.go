@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func typeInto(m attachModel, s string) attachModel {
+	for _, r := range s {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return m
+}
+
+func TestAttachFormValidation(t *testing.T) {
+	m := newAttachModel()
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil || m.err == "" {
+		t.Fatal("empty PID should be rejected")
+	}
+
+	m = typeInto(m, "4242")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = typeInto(m, "99999")
+	if _, err := m.sessionInfo(); err == nil {
+		t.Error("out-of-range port should be rejected")
+	}
+}
+
+func TestAttachFormSubmits(t *testing.T) {
+	m := typeInto(newAttachModel(), "4242")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = typeInto(m, "5173")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = typeInto(m, "vite")
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter should submit")
+	}
+	req, ok := cmd().(attachRequestMsg)
+	if !ok {
+		t.Fatalf("got %T, want attachRequestMsg", cmd())
+	}
+	if req.info.PID != 4242 || req.info.Port != 5173 || req.info.Name != "vite" || req.info.LogPath != "" {
+		t.Errorf("info = %+v", req.info)
+	}
+}
@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+)
+
+// formatBuildTime formats a build duration with tenths under 10s, where they
+// matter: 1.8s, 9.9s, 12s, 2m
+func formatBuildTime(d time.Duration) string {
+	if d < 10*time.Second {
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	}
+	return formatDuration(d)
+}
+
+// buildLabel renders the session row's build timer: the running time of a
+// build in progress, else the last build's time, else "" if none finished yet.
+// A build can only be in progress while the session is running.
+func buildLabel(bt *devdash.BuildTimer, running bool, now time.Time) string {
+	if bt == nil {
+		return ""
+	}
+	started, last := bt.Snapshot()
+	switch {
+	case !started.IsZero() && running:
+		return statusStopped.Render("⏱ " + formatBuildTime(now.Sub(started)) + "…")
+	case last > 0:
+		return ageStyle.Render("⏱ " + formatBuildTime(last))
+	}
+	return ""
+}
+
+// toggleBuildTimer starts or stops timing a build of rp by hand at now, for
+// tools whose output the default patterns don't recognize
+func toggleBuildTimer(rp *devdash.RunningProcess, now time.Time) tea.Cmd {
+	if rp.Build == nil {
+		return feedbackCmd("[No build timer for this session]")
+	}
+	d, running := rp.Build.Toggle(now)
+	if running {
+		return feedbackCmd("[Build timer started: press b again when it's done]")
+	}
+	return feedbackCmd("[Build took " + formatBuildTime(d) + "]")
+}
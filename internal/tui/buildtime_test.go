@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+)
+
+func TestFormatBuildTime(t *testing.T) {
+	cases := map[time.Duration]string{
+		1800 * time.Millisecond: "1.8s",
+		9940 * time.Millisecond: "9.9s",
+		12 * time.Second:        "12s",
+		150 * time.Second:       "2m",
+	}
+	for d, want := range cases {
+		if got := formatBuildTime(d); got != want {
+			t.Errorf("formatBuildTime(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestBuildLabel(t *testing.T) {
+	if got := buildLabel(nil, true, time.Now()); got != "" {
+		t.Errorf("nil timer: %q", got)
+	}
+
+	bt := devdash.NewBuildTimer(devdash.SessionInfo{})
+	if got := buildLabel(bt, true, time.Now()); got != "" {
+		t.Errorf("no build yet: %q", got)
+	}
+
+	t0 := time.Now()
+	bt.Begin(t0)
+	if got := ansi.Strip(buildLabel(bt, true, t0.Add(2500*time.Millisecond))); !strings.Contains(got, "2.5s…") {
+		t.Errorf("running build: %q", got)
+	}
+	if got := buildLabel(bt, false, t0.Add(time.Hour)); got != "" {
+		t.Errorf("build of an exited session: %q", got)
+	}
+	bt.Finish(t0.Add(3 * time.Second))
+	if got := ansi.Strip(buildLabel(bt, false, t0.Add(time.Hour))); got != "⏱ 3.0s" {
+		t.Errorf("finished build: %q", got)
+	}
+}
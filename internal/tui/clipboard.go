@@ -1,14 +1,19 @@
 package tui
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/aymanbagabas/go-osc52/v2"
 	"github.com/atotto/clipboard"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+	"github.com/kimaguri/simplx-toolkit/internal/process"
 )
 
 // ClipboardFeedbackMsg carries a feedback message to display after copy
@@ -26,75 +31,152 @@ func clipboardFeedbackTimeout() tea.Cmd {
 	})
 }
 
+// feedbackCmd shows a transient message in the help bar
+func feedbackCmd(message string) tea.Cmd {
+	return tea.Batch(
+		func() tea.Msg { return ClipboardFeedbackMsg{Message: message} },
+		clipboardFeedbackTimeout(),
+	)
+}
+
+// osc52MaxBytes is the most text sent over OSC52. Base64 makes it just under
+// 100000 bytes, the sequence length many terminals (hterm, older xterm
+// builds, tmux passthrough on some setups) drop or truncate beyond.
+const osc52MaxBytes = 74000
+
 // copyToClipboard copies text to the system clipboard.
 // Tries OSC52 escape sequence first (works over SSH), falls back to atotto/clipboard.
-func copyToClipboard(text string) error {
+// Inside tmux the text also goes into tmux's paste buffer, which tmux forwards
+// to the outer terminal's clipboard itself when passthrough is off; r runs tmux.
+func copyToClipboard(r devdash.Runner, text string) error {
 	// Try OSC52 first — write escape sequence to stderr so it reaches the terminal
-	osc52.New(text).WriteTo(os.Stderr)
+	sent := false
+	if seq, ok := osc52Sequence(text, os.Getenv); ok {
+		_, err := os.Stderr.WriteString(seq)
+		sent = err == nil
+	}
+	if os.Getenv("TMUX") != "" && tmuxLoadBuffer(r, text) == nil {
+		sent = true
+	}
 
 	// Also try native clipboard as fallback (works locally, may fail over SSH)
-	if err := clipboard.WriteAll(text); err != nil {
-		// If OSC52 was written, we consider it a success even if native fails
-		return nil
+	if err := clipboard.WriteAll(text); err != nil && !sent {
+		if len(text) > osc52MaxBytes {
+			return fmt.Errorf("%s is too large for the terminal clipboard (max %s) and no system clipboard is reachable", formatBytes(uint64(len(text))), formatBytes(osc52MaxBytes))
+		}
+		return err
 	}
-
 	return nil
 }
 
-// copyVisibleLines extracts visible viewport lines and copies them to clipboard.
-// Returns the feedback message command batch.
-func copyVisibleLines(viewportContent string) tea.Cmd {
-	lines := strings.Split(viewportContent, "\n")
-	text := strings.Join(lines, "\n")
-	lineCount := len(lines)
+// osc52Sequence returns the OSC52 sequence that copies text, wrapped so it
+// passes through tmux ($TMUX) or GNU screen ($STY) to the outer terminal.
+// False when text is over osc52MaxBytes.
+func osc52Sequence(text string, getenv func(string) string) (string, bool) {
+	if len(text) > osc52MaxBytes {
+		return "", false
+	}
+	seq := osc52.New(text)
+	switch {
+	case getenv("TMUX") != "":
+		seq = seq.Tmux() // needs `set -g allow-passthrough on` (tmux 3.3+)
+	case getenv("STY") != "":
+		seq = seq.Screen()
+	}
+	return seq.String(), true
+}
 
-	if err := copyToClipboard(text); err != nil {
-		return func() tea.Msg {
+// tmuxLoadTimeout bounds tmux load-buffer, which waits on the tmux server
+const tmuxLoadTimeout = 2 * time.Second
+
+// tmuxLoadBuffer puts text in tmux's paste buffer and, with -w, has tmux set
+// the outer terminal's clipboard (tmux 3.2+, set-clipboard on or external)
+func tmuxLoadBuffer(r devdash.Runner, text string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), tmuxLoadTimeout)
+	defer cancel()
+	cmd := r.Command(ctx, "tmux", "load-buffer", "-w", "-")
+	cmd.Stdin = strings.NewReader(text)
+	return cmd.Run()
+}
+
+// copyCmd copies text off the UI goroutine, since the clipboard helpers and
+// tmux can be slow to answer, then shows message or the copy error
+func copyCmd(r devdash.Runner, text, message string) tea.Cmd {
+	run := func() tea.Msg {
+		if err := copyToClipboard(r, text); err != nil {
 			return ClipboardFeedbackMsg{Message: fmt.Sprintf("[Copy error: %v]", err)}
 		}
+		return ClipboardFeedbackMsg{Message: message}
 	}
+	return tea.Batch(run, clipboardFeedbackTimeout())
+}
 
-	return tea.Batch(
-		func() tea.Msg {
-			return ClipboardFeedbackMsg{Message: fmt.Sprintf("[Copied %d lines]", lineCount)}
-		},
-		clipboardFeedbackTimeout(),
-	)
+// copyVisibleLines extracts visible viewport lines and copies them to clipboard.
+// Returns the feedback message command batch.
+func copyVisibleLines(r devdash.Runner, viewportContent string) tea.Cmd {
+	lineCount := len(strings.Split(viewportContent, "\n"))
+
+	return copyCmd(r, viewportContent, fmt.Sprintf("[Copied %d lines]", lineCount))
+}
+
+// copyVisibleMarkdown copies the visible viewport lines wrapped in a fenced
+// code block with ANSI styling stripped, ready to paste into GitHub or Slack.
+func copyVisibleMarkdown(r devdash.Runner, viewportContent string) tea.Cmd {
+	lineCount := len(strings.Split(viewportContent, "\n"))
+
+	return copyCmd(r, markdownCodeBlock(viewportContent), fmt.Sprintf("[Copied %d lines as markdown]", lineCount))
+}
+
+// markdownCodeBlock strips ANSI sequences and trailing padding from text and
+// wraps it in a code fence longer than any backtick run it contains
+func markdownCodeBlock(text string) string {
+	lines := strings.Split(ansi.Strip(text), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(l, " ")
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	body := strings.Join(lines, "\n")
+
+	fence := "```"
+	for strings.Contains(body, fence) {
+		fence += "`"
+	}
+	return fence + "\n" + body + "\n" + fence
 }
 
 // copySelectedLines copies the given text (from visual selection) to clipboard.
 // Returns the feedback message command batch.
-func copySelectedLines(text string, lineCount int) tea.Cmd {
-	if err := copyToClipboard(text); err != nil {
-		return func() tea.Msg {
-			return ClipboardFeedbackMsg{Message: fmt.Sprintf("[Copy error: %v]", err)}
-		}
+func copySelectedLines(r devdash.Runner, text string, lineCount int) tea.Cmd {
+	return copyCmd(r, text, fmt.Sprintf("[Copied %d lines]", lineCount))
+}
+
+// copyFilteredLines copies only the lines matching the active search query,
+// without the match highlighting. Returns the feedback message command batch.
+func copyFilteredLines(r devdash.Runner, lines []string, lm lineMatcher) tea.Cmd {
+	var matched []string
+	for _, idx := range matchingLines(lines, lm) {
+		matched = append(matched, lines[idx])
 	}
 
-	return tea.Batch(
-		func() tea.Msg {
-			return ClipboardFeedbackMsg{Message: fmt.Sprintf("[Copied %d lines]", lineCount)}
-		},
-		clipboardFeedbackTimeout(),
-	)
+	return copyCmd(r, strings.Join(matched, "\n"), fmt.Sprintf("[Copied %d matching lines]", len(matched)))
+}
+
+// flushLog completes buf's partial last line before a copy, so a prompt still
+// waiting for its newline is copied as a line of its own. Every copy path
+// calls it; buf may be nil.
+func flushLog(buf *process.LogBuffer) {
+	if buf != nil {
+		buf.Flush()
+	}
 }
 
 // copyAllLines copies all log buffer content to clipboard.
 // Returns the feedback message command batch.
-func copyAllLines(content string) tea.Cmd {
+func copyAllLines(r devdash.Runner, content string) tea.Cmd {
 	lines := strings.Split(content, "\n")
 	lineCount := len(lines)
 
-	if err := copyToClipboard(content); err != nil {
-		return func() tea.Msg {
-			return ClipboardFeedbackMsg{Message: fmt.Sprintf("[Copy error: %v]", err)}
-		}
-	}
-
-	return tea.Batch(
-		func() tea.Msg {
-			return ClipboardFeedbackMsg{Message: fmt.Sprintf("[Copied all %d lines]", lineCount)}
-		},
-		clipboardFeedbackTimeout(),
-	)
+	return copyCmd(r, content, fmt.Sprintf("[Copied all %d lines]", lineCount))
 }
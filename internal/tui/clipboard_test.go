@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarkdownCodeBlock(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"strips ansi and padding", "\x1b[32mready\x1b[0m   \nline two  \n   ", "```\nready\nline two\n```"},
+		{"longer fence around backticks", "run ```sh```", "````\nrun ```sh```\n````"},
+	}
+	for _, tt := range tests {
+		if got := markdownCodeBlock(tt.in); got != tt.want {
+			t.Errorf("%s: markdownCodeBlock = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestOSC52Sequence(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(k string) string { return vars[k] }
+	}
+	const plain = "\x1b]52;c;aGk=\x07" // "hi"
+
+	tests := []struct {
+		name string
+		vars map[string]string
+		want string
+	}{
+		{"plain", nil, plain},
+		{"tmux passthrough", map[string]string{"TMUX": "/tmp/tmux-1000/default,1,0"}, "\x1bPtmux;\x1b" + plain + "\x1b\\"},
+		{"screen passthrough", map[string]string{"STY": "1234.pts-0.host"}, "\x1bP" + plain + "\x1b\\"},
+	}
+	for _, tt := range tests {
+		got, ok := osc52Sequence("hi", env(tt.vars))
+		if !ok || got != tt.want {
+			t.Errorf("%s: osc52Sequence = %q, %v; want %q", tt.name, got, ok, tt.want)
+		}
+	}
+
+	if _, ok := osc52Sequence(strings.Repeat("x", osc52MaxBytes+1), env(nil)); ok {
+		t.Error("text over osc52MaxBytes should not be sent")
+	}
+}
@@ -0,0 +1,255 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/kimaguri/simplx-toolkit/internal/config"
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+	"github.com/kimaguri/simplx-toolkit/internal/discovery"
+)
+
+// launchSessionName returns the name a launch request's session gets: the
+// explicit one of a duplicate, else derived from worktree and project
+func launchSessionName(req LaunchRequestMsg) string {
+	if req.SessionName != "" {
+		return req.SessionName
+	}
+	return config.SessionName(req.Worktree.Name, req.Project.Name)
+}
+
+// buildSessionInfo resolves a launch request into the exact command, args, env,
+// and working directory that will be started. Shared by launchProcess and the
+// launcher's dry-run preview so both always agree. Fails only when a custom
+// command references an undefined variable under strict_env.
+func buildSessionInfo(r devdash.Runner, req LaunchRequestMsg) (devdash.SessionInfo, error) {
+	wt := req.Worktree
+	proj := req.Project
+	port := req.Port
+
+	sessionName := launchSessionName(req)
+
+	// For workspace packages, use --filter and run from workspace root
+	filterPkg := ""
+	workDir := proj.Path
+	if proj.WorkspaceRoot != "" && proj.PkgName != "" {
+		filterPkg = proj.PkgName
+		workDir = proj.WorkspaceRoot
+	}
+
+	pmBin := req.PackageManager
+	if pmBin == "" {
+		pmBin = "pnpm"
+	}
+	pmPath := resolveBinary(r, pmBin)
+
+	pc := config.LoadProjectConfig(proj.Path)
+	cmd, args, extraEnv := config.DevCommand(proj.IsEncore, port, pmPath, filterPkg, req.Script, req.EncoreArgs)
+	portEnv := fmt.Sprintf("PORT=%d", port)
+
+	// Run under the project's pinned Node version when a manager is set up
+	if !proj.IsEncore && versionManagerAvailable(r, req.VersionManager) {
+		cmd, args = config.WithVersionManager(req.VersionManager, proj.NodeVersion, cmd, args)
+	}
+
+	// Custom commands run as written, from their own directory
+	if req.Custom != nil {
+		c, err := req.Custom.Expand(append(devdash.BaseEnv(req.CleanEnv), portEnv))
+		if err != nil {
+			return devdash.SessionInfo{Name: sessionName}, err
+		}
+		cmd = c.Command
+		if !strings.Contains(cmd, "/") {
+			cmd = resolveBinary(r, cmd)
+		}
+		args = c.Args
+		extraEnv = append([]string{portEnv}, c.Env...)
+		workDir = c.Dir(proj.Path)
+	} else {
+		env, err := pc.ExpandEnv(append(devdash.BaseEnv(req.CleanEnv), portEnv))
+		if err != nil {
+			return devdash.SessionInfo{Name: sessionName}, err
+		}
+		extraEnv = append(extraEnv, env...)
+	}
+
+	// A bad log pattern fails the launch rather than timing nothing
+	if err := pc.CheckLogPatterns(); err != nil {
+		return devdash.SessionInfo{Name: sessionName}, err
+	}
+
+	return devdash.SessionInfo{
+		Name:         sessionName,
+		Port:         port,
+		Command:      cmd,
+		Args:         args,
+		ExtraEnv:     extraEnv,
+		WorkDir:      workDir,
+		Project:      proj.Name,
+		Script:       req.Script,
+		Custom:       req.Custom != nil,
+		StopTimeout:  req.StopTimeout,
+		StopSignal:   req.StopSignal,
+		OpenFiles:    req.OpenFiles,
+		BuildStart:   pc.BuildStart,
+		BuildDone:    pc.BuildDone,
+		ReadyPattern: pc.ReadyPattern,
+		CleanEnv:     req.CleanEnv,
+		WtName:       wt.Name,
+		WtPath:       wt.Path,
+	}, nil
+}
+
+// sessionProject re-runs project detection in a session's worktree and
+// returns the worktree and the project it was launched from. Returns false
+// for sessions without one (attached or install processes) or when it's gone.
+func sessionProject(cfg *config.LocalConfig, wts []discovery.Worktree, info devdash.SessionInfo) (discovery.Worktree, discovery.Project, bool) {
+	if info.Attached || info.WtPath == "" || info.Project == "" {
+		return discovery.Worktree{}, discovery.Project{}, false
+	}
+	wt := discovery.Worktree{Name: info.WtName, Path: info.WtPath}
+	for _, w := range wts {
+		if w.Path == info.WtPath {
+			wt = w
+			break
+		}
+	}
+	filter := discoveryFilter(cfg)
+	proj, found := findProject(discovery.DetectProjectsFiltered(wt, filter), info.Project)
+	return wt, proj, found
+}
+
+// redetectSessionInfo re-runs project detection for a running session and
+// resolves the command a fresh launch would get today. The session keeps its
+// name and port unless the port is now hardcoded in the project's config.
+// Returns false when the session's directory or project can't be found
+// (e.g. attached or install processes).
+func redetectSessionInfo(r devdash.Runner, cfg *config.LocalConfig, wts []discovery.Worktree, info devdash.SessionInfo) (devdash.SessionInfo, bool) {
+	wt, proj, found := sessionProject(cfg, wts, info)
+	if !found {
+		return devdash.SessionInfo{}, false
+	}
+
+	port := info.Port
+	if proj.PortFixed && proj.DetectedPort > 0 {
+		port = proj.DetectedPort
+	}
+	var custom *config.CustomCommand
+	if info.Custom {
+		c, ok := config.LoadProjectConfig(proj.Path).LaunchCommand(info.Script)
+		if !ok {
+			return devdash.SessionInfo{}, false
+		}
+		custom = &c
+	}
+	var encoreArgs []string
+	if proj.IsEncore {
+		encoreArgs = cfg.EncoreArgsFor(proj.Path)
+	}
+
+	next, err := buildSessionInfo(r, LaunchRequestMsg{
+		Worktree:       wt,
+		Project:        proj,
+		Port:           port,
+		Script:         info.Script,
+		Custom:         custom,
+		PackageManager: proj.PackageManager,
+		EncoreArgs:     encoreArgs,
+		SessionName:    info.Name,
+		StopTimeout:    cfg.StopTimeoutFor(proj.Path),
+		StopSignal:     cfg.StopSignalFor(proj.Path),
+		OpenFiles:      cfg.OpenFilesFor(proj.Path),
+		VersionManager: cfg.VersionManager,
+		CleanEnv:       info.CleanEnv, // a restart keeps the launch's choice
+	})
+	return next, err == nil
+}
+
+// launchDiff lists what differs between a session's launch configuration and
+// a re-detected one, as "-"/"+" line pairs; empty when they would run the same
+func launchDiff(old, new devdash.SessionInfo) []string {
+	var lines []string
+	add := func(label, before, after string) {
+		if before != after {
+			lines = append(lines, "- "+label+": "+before, "+ "+label+": "+after)
+		}
+	}
+	add("command", formatCommandLine(old.Command, old.Args), formatCommandLine(new.Command, new.Args))
+	add("port", strconv.Itoa(old.Port), strconv.Itoa(new.Port))
+	add("dir", old.WorkDir, new.WorkDir)
+	add("env", strings.Join(old.ExtraEnv, " "), strings.Join(new.ExtraEnv, " "))
+	return lines
+}
+
+// versionManagerAvailable reports whether the configured version manager is
+// installed, so a missing one falls back to the plain command
+func versionManagerAvailable(r devdash.Runner, manager string) bool {
+	switch manager {
+	case config.VersionManagerFnm, config.VersionManagerVolta, config.VersionManagerAsdf:
+		_, err := r.LookPath(manager)
+		return err == nil
+	case config.VersionManagerNvm:
+		dir := os.Getenv("NVM_DIR")
+		if dir == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return false
+			}
+			dir = filepath.Join(home, ".nvm")
+		}
+		_, err := os.Stat(filepath.Join(dir, "nvm.sh"))
+		return err == nil
+	}
+	return false
+}
+
+// formatCommandLine renders a command and its args as a copy-pasteable shell line
+func formatCommandLine(command string, args []string) string {
+	parts := make([]string, 0, len(args)+1)
+	parts = append(parts, shellQuote(command))
+	for _, a := range args {
+		parts = append(parts, shellQuote(a))
+	}
+	return strings.Join(parts, " ")
+}
+
+// manualCommand renders info as one shell line to paste into a terminal:
+// cd into its working dir, then the command with the session's own env
+// (PORT, .devdash.json env) prefixed. devdash's forced color/CI variables
+// are left out; they only matter when output goes to a log file.
+func manualCommand(info devdash.SessionInfo) string {
+	parts := make([]string, 0, len(info.ExtraEnv)+1)
+	for _, kv := range info.ExtraEnv {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			parts = append(parts, k+"="+shellQuote(v))
+		}
+	}
+	parts = append(parts, formatCommandLine(info.Command, info.Args))
+	line := strings.Join(parts, " ")
+	if info.WorkDir != "" {
+		line = "cd " + shellQuote(info.WorkDir) + " && " + line
+	}
+	return line
+}
+
+// shellQuote single-quotes s if it contains characters the shell would interpret
+func shellQuote(s string) string {
+	if s == "" {
+		return "''"
+	}
+	safe := true
+	for _, c := range s {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+			strings.ContainsRune("-_./:=@%+,", c)) {
+			safe = false
+			break
+		}
+	}
+	if safe {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
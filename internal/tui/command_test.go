@@ -0,0 +1,228 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/kimaguri/simplx-toolkit/internal/config"
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+	"github.com/kimaguri/simplx-toolkit/internal/discovery"
+)
+
+func TestFormatCommandLine(t *testing.T) {
+	got := formatCommandLine("/usr/local/bin/pnpm", []string{"--filter", "@acme/web", "run", "dev"})
+	want := "/usr/local/bin/pnpm --filter @acme/web run dev"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	got = formatCommandLine("node", []string{"-e", "console.log('hi there')", ""})
+	want = `node -e 'console.log('\''hi there'\'')' ''`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestManualCommand(t *testing.T) {
+	info := devdash.SessionInfo{
+		Command:  "/usr/local/bin/pnpm",
+		Args:     []string{"run", "dev"},
+		WorkDir:  "/home/me/my app",
+		ExtraEnv: []string{"PORT=5173", "GREETING=hello world"},
+	}
+	want := `cd '/home/me/my app' && PORT=5173 GREETING='hello world' /usr/local/bin/pnpm run dev`
+	if got := manualCommand(info); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestBuildSessionInfo_VersionManager(t *testing.T) {
+	req := LaunchRequestMsg{
+		Worktree:       discovery.Worktree{Name: "web", Path: "/src/web"},
+		Project:        discovery.Project{Name: "web", Path: "/src/web", NodeVersion: "20"},
+		Port:           4000,
+		Script:         "dev",
+		PackageManager: "npm",
+		VersionManager: config.VersionManagerNvm,
+	}
+
+	// nvm not installed: plain command
+	t.Setenv("NVM_DIR", t.TempDir())
+	if info, _ := buildSessionInfo(devdash.SystemRunner, req); info.Args[0] != "run" {
+		t.Errorf("expected plain command without nvm, got %s %v", info.Command, info.Args)
+	}
+
+	nvmDir := t.TempDir()
+	os.WriteFile(filepath.Join(nvmDir, "nvm.sh"), nil, 0644)
+	t.Setenv("NVM_DIR", nvmDir)
+	info, _ := buildSessionInfo(devdash.SystemRunner, req)
+	if info.Command != "bash" || info.Args[2] != "20" {
+		t.Errorf("expected nvm wrapper, got %s %v", info.Command, info.Args)
+	}
+}
+
+// fakeRunner finds only the binaries it maps to paths and runs nothing
+type fakeRunner map[string]string
+
+func (r fakeRunner) LookPath(file string) (string, error) {
+	if p, ok := r[file]; ok {
+		return p, nil
+	}
+	return "", errors.New("not found")
+}
+
+func (r fakeRunner) Output(string, ...string) ([]byte, error) {
+	return nil, errors.New("not run")
+}
+
+// Command runs true in place of any command
+func (r fakeRunner) Command(ctx context.Context, _ string, _ ...string) *exec.Cmd {
+	return exec.CommandContext(ctx, "true")
+}
+
+func TestBuildSessionInfo_ResolvesThroughRunner(t *testing.T) {
+	req := LaunchRequestMsg{
+		Worktree:       discovery.Worktree{Name: "web", Path: "/src/web"},
+		Project:        discovery.Project{Name: "web", Path: "/src/web", NodeVersion: "20"},
+		Port:           4000,
+		Script:         "dev",
+		PackageManager: "npm",
+		VersionManager: config.VersionManagerFnm,
+	}
+
+	// fnm missing: plain command, npm from the runner's path
+	info, _ := buildSessionInfo(fakeRunner{"npm": "/opt/node/bin/npm"}, req)
+	if info.Command != "/opt/node/bin/npm" {
+		t.Errorf("Command = %q, want the runner's npm", info.Command)
+	}
+
+	info, _ = buildSessionInfo(fakeRunner{"npm": "/opt/node/bin/npm", "fnm": "/usr/bin/fnm"}, req)
+	if info.Command != "fnm" || !slices.Contains(info.Args, "/opt/node/bin/npm") {
+		t.Errorf("expected fnm wrapping the runner's npm, got %s %v", info.Command, info.Args)
+	}
+}
+
+func TestBuildSessionInfo_CustomCommand(t *testing.T) {
+	req := LaunchRequestMsg{
+		Worktree:       discovery.Worktree{Name: "svc", Path: "/src/svc"},
+		Project:        discovery.Project{Name: "api", Path: "/src/svc/api", NodeVersion: "20"},
+		Port:           8080,
+		Script:         "serve",
+		PackageManager: "npm",
+		VersionManager: config.VersionManagerFnm,
+		Custom: &config.CustomCommand{
+			Name:    "serve",
+			Command: "./bin/api",
+			Args:    []string{"--watch", "--port=${PORT}"},
+			Cwd:     "cmd",
+			Env:     []string{"LOG=debug"},
+		},
+	}
+
+	info, err := buildSessionInfo(devdash.SystemRunner, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Command != "./bin/api" || !reflect.DeepEqual(info.Args, []string{"--watch", "--port=8080"}) {
+		t.Errorf("command = %s %v, want the custom command unwrapped", info.Command, info.Args)
+	}
+	if info.WorkDir != "/src/svc/api/cmd" {
+		t.Errorf("work dir = %q", info.WorkDir)
+	}
+	if !reflect.DeepEqual(info.ExtraEnv, []string{"PORT=8080", "LOG=debug"}) {
+		t.Errorf("env = %v", info.ExtraEnv)
+	}
+	if !info.Custom || info.Script != "serve" {
+		t.Errorf("session should record the custom command name, got %+v", info)
+	}
+}
+
+func TestRedetectSessionInfo(t *testing.T) {
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, "package.json"), []byte(`{"name":"web","scripts":{"dev":"vite"}}`), 0644)
+	os.WriteFile(filepath.Join(root, "pnpm-lock.yaml"), nil, 0644)
+	wt := discovery.Worktree{Name: "web", Path: root}
+	proj := discovery.DetectProjects(wt)[0]
+	cfg := &config.LocalConfig{}
+
+	info, _ := buildSessionInfo(devdash.SystemRunner, LaunchRequestMsg{Worktree: wt, Project: proj, Port: 4000, Script: "dev", PackageManager: proj.PackageManager})
+	info.WtName, info.WtPath = wt.Name, wt.Path
+
+	next, ok := redetectSessionInfo(devdash.SystemRunner, cfg, []discovery.Worktree{wt}, info)
+	if !ok {
+		t.Fatal("redetect failed")
+	}
+	if diff := launchDiff(info, next); len(diff) != 0 {
+		t.Errorf("unchanged project should have no diff, got %v", diff)
+	}
+
+	// Switching package managers changes the command; the port is kept
+	os.Remove(filepath.Join(root, "pnpm-lock.yaml"))
+	os.WriteFile(filepath.Join(root, "package-lock.json"), nil, 0644)
+	next, _ = redetectSessionInfo(devdash.SystemRunner, cfg, nil, info)
+	diff := launchDiff(info, next)
+	if len(diff) != 2 || !strings.HasPrefix(diff[0], "- command: ") || !strings.Contains(diff[1], "npm") {
+		t.Errorf("diff = %v", diff)
+	}
+	if next.Port != 4000 || next.Name != info.Name {
+		t.Errorf("redetected %+v", next)
+	}
+
+	if _, ok := redetectSessionInfo(devdash.SystemRunner, cfg, nil, devdash.SessionInfo{Name: "pid-1", Attached: true}); ok {
+		t.Error("attached sessions can't be re-detected")
+	}
+}
+
+func TestSessionProjectLaunch(t *testing.T) {
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, "package.json"), []byte(`{"name":"web","scripts":{"dev":"vite"}}`), 0644)
+	os.WriteFile(filepath.Join(root, ".devdash.json"), []byte(`{"env":["DEBUG=1"]}`), 0644)
+	wt := discovery.Worktree{Name: "web", Path: root}
+	proj := discovery.DetectProjects(wt)[0]
+	cfg := &config.LocalConfig{}
+
+	info, _ := buildSessionInfo(devdash.SystemRunner, LaunchRequestMsg{Worktree: wt, Project: proj, Port: 4100, Script: "dev", PackageManager: proj.PackageManager})
+	if !reflect.DeepEqual(info.ExtraEnv, []string{"PORT=4100", "DEBUG=1"}) {
+		t.Errorf("ExtraEnv = %v, want the project env after PORT", info.ExtraEnv)
+	}
+
+	os.WriteFile(filepath.Join(root, ".devdash.json"), []byte(`{"env":["DB_PORT=5433","DATABASE_URL=postgres://localhost:${DB_PORT}/app","API=:${PORT}"]}`), 0644)
+	expanded, _ := buildSessionInfo(devdash.SystemRunner, LaunchRequestMsg{Worktree: wt, Project: proj, Port: 4100, Script: "dev", PackageManager: proj.PackageManager})
+	if want := []string{"PORT=4100", "DB_PORT=5433", "DATABASE_URL=postgres://localhost:5433/app", "API=:4100"}; !reflect.DeepEqual(expanded.ExtraEnv, want) {
+		t.Errorf("ExtraEnv = %v, want the project env expanded: %v", expanded.ExtraEnv, want)
+	}
+	// Saving keeps the templates, not the values they expanded to
+	templ, _ := sessionProjectLaunch(cfg, []discovery.Worktree{wt}, expanded)
+	if diff := templ.diff(config.LoadProjectConfig(root)); !reflect.DeepEqual(diff, []string{"- port: (unset)", "+ port: 4100"}) {
+		t.Errorf("saving an expanded env: diff = %v, want only the port", diff)
+	}
+	// A custom command doesn't use the project env, so it can't fail the launch
+	os.WriteFile(filepath.Join(root, ".devdash.json"), []byte(`{"strict_env":true,"env":["URL=${NOPE}"]}`), 0644)
+	if _, err := buildSessionInfo(devdash.SystemRunner, LaunchRequestMsg{Worktree: wt, Project: proj, Port: 4100, Custom: &config.CustomCommand{Name: "api", Command: "./api"}}); err != nil {
+		t.Errorf("custom launch failed on the project env: %v", err)
+	}
+	os.WriteFile(filepath.Join(root, ".devdash.json"), []byte(`{"env":["DEBUG=1"]}`), 0644)
+
+	pl, ok := sessionProjectLaunch(cfg, []discovery.Worktree{wt}, info)
+	if !ok || pl.dir != root || pl.port != 4100 || !reflect.DeepEqual(pl.env, []string{"DEBUG=1"}) {
+		t.Fatalf("sessionProjectLaunch = %+v, %v", pl, ok)
+	}
+	diff := pl.diff(config.LoadProjectConfig(root))
+	if !reflect.DeepEqual(diff, []string{"- port: (unset)", "+ port: 4100"}) {
+		t.Errorf("diff = %v", diff)
+	}
+
+	if err := config.SaveProjectLaunch(pl.dir, pl.port, pl.env); err != nil {
+		t.Fatal(err)
+	}
+	if diff := pl.diff(config.LoadProjectConfig(root)); len(diff) != 0 {
+		t.Errorf("saved config still differs: %v", diff)
+	}
+}
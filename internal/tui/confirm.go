@@ -8,6 +8,7 @@ import (
 // ConfirmResultMsg is emitted when the confirmation popup resolves
 type ConfirmResultMsg struct {
 	Confirmed bool
+	Cancelled bool   // closed with esc rather than answered
 	Action    string // e.g. "kill", "restart"
 	Target    string // session name
 }
@@ -59,6 +60,7 @@ func (m confirmModel) Update(msg tea.Msg) (confirmModel, tea.Cmd) {
 			return m, func() tea.Msg {
 				return ConfirmResultMsg{
 					Confirmed: false,
+					Cancelled: true,
 					Action:    m.action,
 					Target:    m.target,
 				}
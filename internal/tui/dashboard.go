@@ -41,6 +41,30 @@ type dashboardModel struct {
 	search          searchModel
 	selection       selectionModel
 	isInteractive   bool // interactive mode active (keys → PTY)
+	wrapNames       bool                 // wrap long session names instead of eliding the middle
+	wrapIndent      bool                 // indent the continuation rows of wrapped log lines
+	levelLine       int                  // log line of the last error jump (-1 = none), see jumpToLevel
+	timeFmt         timeFormat           // how uptime and idle time are shown
+	listRatio       float64              // fraction of the width given to the session list (0 = default)
+	dense           bool                 // one row per session: tighter spacing, tunnel shown as a glyph
+	idleAfter       time.Duration        // dim running sessions silent for this long (0 = off)
+	scrolled        map[string]logScroll // saved positions of sessions scrolled back (auto-scroll off)
+	stickySearch    bool                 // carry the search query over to the next selected session
+	tags            map[string][]string  // session name → tags, shared with the config
+	tagFilter       string               // list only sessions with this tag ("" = all)
+	statusFilter    statusFilter         // list only running or only stopped/errored sessions
+	paused          bool                 // terminal unfocused: new log lines are buffered, not rendered
+	dockName        string               // session whose tunnel URL is pinned above the panels ("" = none)
+	all             []*devdash.RunningProcess // every session, before the tag filter
+	clock           devdash.Clock        // the time uptime, idle time and activity are measured at
+	runner          devdash.Runner       // runs the clipboard helpers
+}
+
+// logScroll is the saved log position of a session that was scrolled back
+// when the dashboard switched away from it
+type logScroll struct {
+	offset  int     // viewport YOffset
+	percent float64 // viewport ScrollPercent, for the list indicator
 }
 
 // newDashboardModel creates a new dashboard
@@ -48,6 +72,9 @@ func newDashboardModel() dashboardModel {
 	return dashboardModel{
 		autoScroll: true,
 		search:     newSearchModel(),
+		levelLine:  -1,
+		clock:      devdash.SystemClock,
+		runner:     devdash.SystemRunner,
 	}
 }
 
@@ -56,13 +83,32 @@ func (m dashboardModel) Init() tea.Cmd {
 	return nil
 }
 
-// SetProcesses updates the process list
+// SetProcesses updates the process list. The selected session stays selected
+// while it is listed, even as sessions above it come and go.
 func (m *dashboardModel) SetProcesses(procs []*devdash.RunningProcess) {
+	var selName string
+	if sel := m.SelectedProcess(); sel != nil {
+		selName = sel.Info.Name
+	}
+
 	// Sort by name for stable ordering
 	sort.Slice(procs, func(i, j int) bool {
 		return procs[i].Info.Name < procs[j].Info.Name
 	})
-	m.processes = procs
+	m.all = procs
+	m.processes = filterByStatus(filterByTag(procs, m.tags, m.tagFilter), m.statusFilter)
+
+	// Unpin the tunnel banner once its tunnel is closed or its session gone
+	if m.dockName != "" && m.dockedTunnel() == nil {
+		m.dockTunnel("")
+	}
+
+	// Forget saved scroll positions of sessions that are gone
+	for name := range m.scrolled {
+		if !hasProcess(procs, name) {
+			delete(m.scrolled, name)
+		}
+	}
 
 	// Clamp selection
 	if m.selected >= len(m.processes) {
@@ -71,6 +117,17 @@ func (m *dashboardModel) SetProcesses(procs []*devdash.RunningProcess) {
 	if m.selected < 0 {
 		m.selected = 0
 	}
+	m.selectByName(selName)
+}
+
+// hasProcess reports whether procs contains a session named name
+func hasProcess(procs []*devdash.RunningProcess, name string) bool {
+	for _, rp := range procs {
+		if rp.Info.Name == name {
+			return true
+		}
+	}
+	return false
 }
 
 // SelectedProcess returns the currently selected process, or nil
@@ -81,8 +138,24 @@ func (m *dashboardModel) SelectedProcess() *devdash.RunningProcess {
 	return nil
 }
 
-// SubscribeToSelected subscribes the log viewport to the selected session's buffer
+// selectByName selects the session called name; the selection is unchanged
+// if it isn't listed
+func (m *dashboardModel) selectByName(name string) {
+	for i, rp := range m.processes {
+		if rp.Info.Name == name {
+			m.selected = i
+			return
+		}
+	}
+}
+
+// SubscribeToSelected subscribes the log viewport to the selected session's buffer.
+// The outgoing session's scroll position is saved and the incoming one's restored,
+// so each session keeps its own auto-scroll state.
 func (m *dashboardModel) SubscribeToSelected() tea.Cmd {
+	m.saveScroll()
+	prevName := m.logSubName
+
 	// Unsubscribe from current
 	m.unsubscribeLogs()
 
@@ -90,23 +163,74 @@ func (m *dashboardModel) SubscribeToSelected() tea.Cmd {
 	if sel == nil {
 		return nil
 	}
+	switched := sel.Info.Name != prevName
+	if switched && !m.stickySearch {
+		m.search.deactivate()
+	}
 
 	m.logBuf = sel.LogBuf
 	m.logSubName = sel.Info.Name
 	m.logSubCh = sel.LogBuf.Subscribe()
+	m.levelLine = -1
+
+	saved, paused := m.scrolled[sel.Info.Name]
+	m.autoScroll = !paused
 
-	// Load existing content (with word wrapping)
-	if m.ready {
-		content := wrapLogContent(sel.LogBuf.Content(), m.logViewport.Width)
+	// Load existing content (with word wrapping), re-filtered when a search carries over
+	if m.ready && m.search.isActive() && m.search.query != "" {
+		m.applySearchFilter()
+		if switched || m.search.currentMatch > m.search.matchCount {
+			m.search.currentMatch = min(1, m.search.matchCount)
+		}
+	} else if m.ready {
+		content := m.wrapLog(sel.LogBuf.ContentSinceMark())
 		m.logViewport.SetContent(content)
 		if m.autoScroll {
 			m.logViewport.GotoBottom()
+		} else {
+			m.logViewport.SetYOffset(saved.offset)
 		}
 	}
 
 	return waitForLogLine(m.logSubName, m.logSubCh)
 }
 
+// saveScroll records the current session's position if it is scrolled back,
+// or forgets it if it follows the tail
+func (m *dashboardModel) saveScroll() {
+	if m.logSubName == "" {
+		return
+	}
+	if m.autoScroll {
+		delete(m.scrolled, m.logSubName)
+		return
+	}
+	if m.scrolled == nil {
+		m.scrolled = make(map[string]logScroll)
+	}
+	m.scrolled[m.logSubName] = logScroll{
+		offset:  m.logViewport.YOffset,
+		percent: m.logViewport.ScrollPercent(),
+	}
+}
+
+// scrollIndicator returns "↑NN%" for a session whose log is scrolled back
+// (auto-scroll paused), or "" if it follows the tail
+func (m dashboardModel) scrollIndicator(name string) string {
+	var percent float64
+	if name == m.logSubName {
+		if m.autoScroll {
+			return ""
+		}
+		percent = m.logViewport.ScrollPercent()
+	} else if saved, ok := m.scrolled[name]; ok {
+		percent = saved.percent
+	} else {
+		return ""
+	}
+	return fmt.Sprintf("↑%d%%", int(percent*100))
+}
+
 // unsubscribeLogs cleans up the current log subscription
 func (m *dashboardModel) unsubscribeLogs() {
 	if m.logSubCh != nil && m.logBuf != nil {
@@ -138,6 +262,12 @@ func (m dashboardModel) Update(msg tea.Msg) (dashboardModel, tea.Cmd) {
 		m.tunnelFeedback = ""
 		return m, nil
 
+	case searchDebounceMsg:
+		if m.search.settle(msg) {
+			m.applySearchFilter()
+		}
+		return m, nil
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -146,24 +276,15 @@ func (m dashboardModel) Update(msg tea.Msg) (dashboardModel, tea.Cmd) {
 
 	case LogLineMsg:
 		if msg.SessionName == m.logSubName && m.ready {
-			// Freeze viewport during visual selection
-			if m.selection.isActive() {
+			// Freeze viewport during visual selection, and skip rendering
+			// while the terminal is unfocused
+			if m.selection.isActive() || m.paused {
 				if m.logSubCh != nil {
 					cmds = append(cmds, waitForLogLine(m.logSubName, m.logSubCh))
 				}
 				return m, tea.Batch(cmds...)
 			}
-			if m.isInteractive {
-				m.refreshInteractiveViewport()
-			} else if m.search.isActive() && m.search.query != "" {
-				m.applySearchFilter()
-			} else {
-				content := wrapLogContent(m.logBuf.Content(), m.logViewport.Width)
-				m.logViewport.SetContent(content)
-				if m.autoScroll {
-					m.logViewport.GotoBottom()
-				}
-			}
+			m.showNewLines()
 			if m.logSubCh != nil {
 				cmds = append(cmds, waitForLogLine(m.logSubName, m.logSubCh))
 			}
@@ -195,9 +316,6 @@ func (m dashboardModel) updateList(msg tea.KeyMsg) (dashboardModel, tea.Cmd) {
 		if m.selected < len(m.processes)-1 {
 			m.selected++
 		}
-	case "tab":
-		m.focus = focusLogs
-		return m, nil
 	}
 
 	if m.selected != prevSelected {
@@ -239,13 +357,15 @@ func (m dashboardModel) updateLogs(msg tea.KeyMsg) (dashboardModel, tea.Cmd) {
 		case "/":
 			cmd := m.search.activate()
 			return m, cmd
+		case "alt+c", "alt+m":
+			m.search.toggleOption(msg.String())
+			m.applySearchFilter()
+			m.search.clampMatch()
+			return m, nil
 		}
 	}
 
 	switch msg.String() {
-	case "tab":
-		m.focus = focusList
-		return m, nil
 	case "G":
 		m.logViewport.GotoBottom()
 		m.autoScroll = true
@@ -256,12 +376,35 @@ func (m dashboardModel) updateLogs(msg tea.KeyMsg) (dashboardModel, tea.Cmd) {
 		return m, nil
 	case "c":
 		if m.ready {
-			return m, copyVisibleLines(m.logViewport.View())
+			flushLog(m.logBuf)
+			return m, copyVisibleLines(m.runner, m.logViewport.View())
+		}
+		return m, nil
+	case "C":
+		if m.ready {
+			flushLog(m.logBuf)
+			return m, copyVisibleMarkdown(m.runner, m.logViewport.View())
+		}
+		return m, nil
+	case "m":
+		if m.logBuf != nil {
+			toggleMark(m.logBuf)
+			m.autoScroll = true
+			if m.search.isActive() && m.search.query != "" {
+				m.applySearchFilter()
+			} else {
+				m.refreshLogViewport()
+			}
 		}
 		return m, nil
 	case "y":
+		flushLog(m.logBuf)
+		// With a search filter active, copy what's shown: the matching lines
+		if m.logBuf != nil && m.search.isActive() && m.search.query != "" {
+			return m, copyFilteredLines(m.runner, m.logBuf.LinesSinceMark(), m.search.matcher())
+		}
 		if m.logBuf != nil {
-			return m, copyAllLines(m.logBuf.Content())
+			return m, copyAllLines(m.runner, m.logBuf.ContentSinceMark())
 		}
 		return m, nil
 	case "/":
@@ -270,8 +413,7 @@ func (m dashboardModel) updateLogs(msg tea.KeyMsg) (dashboardModel, tea.Cmd) {
 	case "v":
 		if m.logBuf != nil && m.ready {
 			m.search.deactivate()
-			content := wrapLogContent(m.logBuf.Content(), m.logViewport.Width)
-			m.selection.activate(m.logViewport, content)
+			m.selection.activate(m.logViewport, m.logBuf.ContentSinceMark(), m.wrapLog, m.wrapPad)
 			m.selection.applyToViewport(&m.logViewport)
 			return m, nil
 		}
@@ -288,7 +430,9 @@ func (m dashboardModel) updateLogs(msg tea.KeyMsg) (dashboardModel, tea.Cmd) {
 
 	prevOffset := m.logViewport.YOffset
 	var cmd tea.Cmd
-	m.logViewport, cmd = m.logViewport.Update(msg)
+	if !scrollPage(&m.logViewport, msg.String()) {
+		m.logViewport, cmd = m.logViewport.Update(msg)
+	}
 
 	if m.logViewport.YOffset < prevOffset {
 		m.autoScroll = false
@@ -308,11 +452,28 @@ func (m dashboardModel) updateLogsSearchInput(msg tea.KeyMsg) (dashboardModel, t
 		m.refreshLogViewport()
 		return m, nil
 	case "enter":
+		if m.search.pending {
+			m.search.pending = false
+			m.applySearchFilter()
+		}
 		m.search.enterNavigateMode()
 		return m, nil
+	case "alt+c", "alt+m":
+		m.search.toggleOption(msg.String())
+		m.search.pending = false
+		m.applySearchFilter()
+		return m, nil
 	}
 
+	prevQuery := m.search.query
 	cmd := m.search.update(msg)
+	if m.search.query == prevQuery {
+		return m, cmd
+	}
+	// Large buffers wait for a pause in typing before the rescan
+	if m.logBuf != nil && needsDebounce(m.logBuf.Len()) {
+		return m, tea.Batch(cmd, m.search.debounce())
+	}
 	// Update viewport with filtered content
 	m.applySearchFilter()
 	return m, cmd
@@ -320,25 +481,22 @@ func (m dashboardModel) updateLogsSearchInput(msg tea.KeyMsg) (dashboardModel, t
 
 // handleLogsSelectionKey processes keys during visual selection mode in dashboard
 func (m dashboardModel) handleLogsSelectionKey(msg tea.KeyMsg) (dashboardModel, tea.Cmd) {
-	// Allow tab to exit selection and switch panel
-	if msg.String() == "tab" {
-		m.selection.deactivate()
-		m.refreshLogViewport()
-		m.focus = focusList
-		return m, nil
-	}
-
 	action := m.selection.handleKey(msg.String(), m.logViewport.Height)
 	switch action {
 	case selActionMoved:
 		m.selection.applyToViewport(&m.logViewport)
 		return m, nil
 	case selActionCopy:
+		flushLog(m.logBuf)
 		text := m.selection.selectedText()
 		count := m.selection.selectedLineCount()
 		m.selection.deactivate()
 		m.refreshLogViewport()
-		return m, copySelectedLines(text, count)
+		return m, copySelectedLines(m.runner, text, count)
+	case selActionCopyStay:
+		flushLog(m.logBuf)
+		text, count := m.selection.copyStay()
+		return m, copySelectedLines(m.runner, text, count)
 	case selActionCancel:
 		m.selection.deactivate()
 		m.refreshLogViewport()
@@ -347,6 +505,22 @@ func (m dashboardModel) handleLogsSelectionKey(msg tea.KeyMsg) (dashboardModel,
 	return m, nil
 }
 
+// showNewLines re-renders the log panel with the lines that arrived: the
+// live screen in interactive mode, else the log, filtered while searching
+func (m *dashboardModel) showNewLines() {
+	if m.isInteractive {
+		m.refreshInteractiveViewport()
+	} else if m.search.isActive() && m.search.query != "" {
+		m.applySearchFilter()
+	} else {
+		content := m.wrapLog(m.logBuf.ContentSinceMark())
+		m.logViewport.SetContent(content)
+		if m.autoScroll {
+			m.logViewport.GotoBottom()
+		}
+	}
+}
+
 // applySearchFilter filters log content by the current search query
 func (m *dashboardModel) applySearchFilter() {
 	if m.logBuf == nil || !m.ready {
@@ -358,12 +532,12 @@ func (m *dashboardModel) applySearchFilter() {
 		return
 	}
 
-	lines := m.logBuf.Lines()
-	filtered, matchCount := filterAndHighlight(lines, m.search.query)
+	lines := m.logBuf.LinesSinceMark()
+	filtered, matchCount := filterAndHighlight(lines, m.search.matcher())
 	m.search.matchCount = matchCount
 
 	content := strings.Join(filtered, "\n")
-	wrapped := wrapLogContent(content, m.logViewport.Width)
+	wrapped := m.wrapLog(content)
 	m.logViewport.SetContent(wrapped)
 	m.logViewport.GotoBottom()
 }
@@ -373,7 +547,7 @@ func (m *dashboardModel) refreshLogViewport() {
 	if m.logBuf == nil || !m.ready {
 		return
 	}
-	content := wrapLogContent(m.logBuf.Content(), m.logViewport.Width)
+	content := m.wrapLog(m.logBuf.ContentSinceMark())
 	m.logViewport.SetContent(content)
 	if m.autoScroll {
 		m.logViewport.GotoBottom()
@@ -387,11 +561,12 @@ func (m *dashboardModel) refreshInteractiveViewport() {
 		return
 	}
 	if sel.VTerm != nil {
-		content := sel.VTerm.Content()
+		// Keep blank rows: a live screen must not shrink as the app redraws
+		content := sel.VTerm.RawContent()
 		m.logViewport.SetContent(content)
 	} else {
 		// Fallback: show log content (for daemon processes without VTerm)
-		content := wrapLogContent(m.logBuf.Content(), m.logViewport.Width)
+		content := m.wrapLog(m.logBuf.ContentSinceMark())
 		m.logViewport.SetContent(content)
 	}
 	m.logViewport.GotoBottom()
@@ -403,7 +578,7 @@ func (m *dashboardModel) initViewport() {
 	m.selection.deactivate()
 
 	_, rightW := m.panelWidths()
-	vpH := m.height - 3 // title + help bar + border
+	vpH := m.height - 3 - m.dockHeight() // title + help bar + border, and the pinned tunnel
 	if vpH < 1 {
 		vpH = 1
 	}
@@ -421,9 +596,45 @@ func (m *dashboardModel) initViewport() {
 	}
 }
 
+// Session list width limits, as a fraction of the terminal width
+const (
+	defaultListRatio = 1.0 / 3
+	minListRatio     = 0.15
+	maxListRatio     = 0.7
+	listRatioStep    = 0.05
+)
+
+// clampListRatio keeps a split ratio within the allowed range
+func clampListRatio(r float64) float64 {
+	if r < minListRatio {
+		return minListRatio
+	}
+	if r > maxListRatio {
+		return maxListRatio
+	}
+	return r
+}
+
+// adjustListRatio widens (delta > 0) or narrows the session list and
+// returns the new ratio
+func (m *dashboardModel) adjustListRatio(delta float64) float64 {
+	ratio := m.listRatio
+	if ratio == 0 {
+		ratio = defaultListRatio
+	}
+	m.listRatio = clampListRatio(ratio + delta)
+	m.initViewport()
+	m.refreshLogViewport()
+	return m.listRatio
+}
+
 // panelWidths calculates left and right panel widths
 func (m dashboardModel) panelWidths() (int, int) {
-	leftW := m.width / 3
+	ratio := defaultListRatio
+	if m.listRatio > 0 {
+		ratio = clampListRatio(m.listRatio)
+	}
+	leftW := int(float64(m.width) * ratio)
 	if leftW < 20 {
 		leftW = 20
 	}
@@ -442,7 +653,7 @@ func (m dashboardModel) View() string {
 
 	leftW, rightW := m.panelWidths()
 	helpH := 1
-	contentH := m.height - helpH
+	contentH := m.height - helpH - m.dockHeight()
 
 	// Left panel: session list
 	leftPanel := m.renderSessionList(leftW, contentH)
@@ -456,6 +667,9 @@ func (m dashboardModel) View() string {
 	// Help bar
 	help := m.renderHelpBar()
 
+	if m.dockName != "" {
+		return lipgloss.JoinVertical(lipgloss.Left, m.renderTunnelDock(), body, help)
+	}
 	return lipgloss.JoinVertical(lipgloss.Left, body, help)
 }
 
@@ -472,19 +686,34 @@ func (m dashboardModel) renderSessionList(w, h int) string {
 
 	focused := m.focus == focusList
 
+	title := " Sessions "
+	if m.tagFilter != "" {
+		title += "#" + m.tagFilter + " "
+	}
+	if label := m.statusFilter.label(); label != "" {
+		title += "(" + label + ") "
+	}
+
 	var lines []string
-	if len(m.processes) == 0 {
+	if len(m.processes) == 0 && m.tagFilter != "" {
+		lines = append(lines, dimStyle.Render("No sessions tagged #"+m.tagFilter))
+		lines = append(lines, "")
+		lines = append(lines, dimStyle.Render("Press f to change the filter"))
+	} else if len(m.processes) == 0 && m.statusFilter != showAll {
+		lines = append(lines, dimStyle.Render(m.statusFilter.emptyText()))
+		lines = append(lines, "")
+		lines = append(lines, dimStyle.Render("Press h to show all"))
+	} else if len(m.processes) == 0 {
 		lines = append(lines, dimStyle.Render("No active sessions"))
 		lines = append(lines, "")
 		lines = append(lines, dimStyle.Render("Press n to launch"))
 	} else {
+		items := make([][]string, len(m.processes))
 		for i, rp := range m.processes {
-			item := m.renderSessionItem(i, rp, innerW)
-			// Item may contain multiple lines (e.g. tunnel info)
-			for _, l := range strings.Split(item, "\n") {
-				lines = append(lines, l)
-			}
+			// Item may contain multiple lines (e.g. tunnel info, wrapped name)
+			items[i] = strings.Split(m.renderSessionItem(i, rp, innerW), "\n")
 		}
+		lines = windowItems(items, m.selected, innerH)
 	}
 
 	// Pad/trim to exact innerH lines
@@ -497,7 +726,7 @@ func (m dashboardModel) renderSessionList(w, h int) string {
 
 	// Build panel: top border with title + body lines + bottom border
 	var b strings.Builder
-	b.WriteString(buildTopBorder(" Sessions ", innerW, focused))
+	b.WriteString(buildTopBorder(title, innerW, focused))
 	b.WriteByte('\n')
 	for _, line := range lines {
 		b.WriteString(buildBodyLine(line, innerW, focused))
@@ -508,25 +737,85 @@ func (m dashboardModel) renderSessionList(w, h int) string {
 	return b.String()
 }
 
+// windowItems flattens multi-line list items into at most height rows, keeping
+// the selected item fully visible and roughly centered. Hidden items are
+// summarized with "↑ N more" / "↓ N more" indicator rows.
+func windowItems(items [][]string, selected, height int) []string {
+	total := 0
+	for _, it := range items {
+		total += len(it)
+	}
+	if total <= height || len(items) == 0 {
+		var out []string
+		for _, it := range items {
+			out = append(out, it...)
+		}
+		return out
+	}
+	if selected < 0 {
+		selected = 0
+	}
+	if selected >= len(items) {
+		selected = len(items) - 1
+	}
+
+	// fits reports whether items[s:e] plus any needed indicator rows fit
+	fits := func(s, e, rows int) bool {
+		if s > 0 {
+			rows++
+		}
+		if e < len(items) {
+			rows++
+		}
+		return rows <= height
+	}
+
+	// Grow [start, end) around the selection, alternating below and above
+	start, end := selected, selected+1
+	rows := len(items[selected])
+	for {
+		grew := false
+		if end < len(items) && fits(start, end+1, rows+len(items[end])) {
+			rows += len(items[end])
+			end++
+			grew = true
+		}
+		if start > 0 && fits(start-1, end, rows+len(items[start-1])) {
+			start--
+			rows += len(items[start])
+			grew = true
+		}
+		if !grew {
+			break
+		}
+	}
+
+	var out []string
+	if start > 0 {
+		out = append(out, dimStyle.Render(fmt.Sprintf("  ↑ %d more", start)))
+	}
+	for _, it := range items[start:end] {
+		out = append(out, it...)
+	}
+	if end < len(items) {
+		out = append(out, dimStyle.Render(fmt.Sprintf("  ↓ %d more", len(items)-end)))
+	}
+	return out
+}
+
 // renderSessionItem renders a single session item in the list
 func (m dashboardModel) renderSessionItem(idx int, rp *devdash.RunningProcess, width int) string {
 	isSelected := idx == m.selected
+	statusIcon := sessionStatusIcon(rp)
+	now := m.clock.Now()
 
-	// Status indicator
-	var statusIcon string
-	switch rp.Status {
-	case devdash.StatusRunning:
-		statusIcon = statusRunning.Render("*")
-	case devdash.StatusStopped:
-		statusIcon = statusStopped.Render("-")
-	case devdash.StatusError:
-		statusIcon = statusError.Render("!")
+	// Cursor and column gap (tighter in dense mode)
+	cursor, sep := "  ", "  "
+	if m.dense {
+		cursor, sep = " ", " "
 	}
-
-	// Cursor
-	cursor := "  "
 	if isSelected {
-		cursor = "> "
+		cursor = ">" + cursor[1:]
 	}
 
 	// Name
@@ -538,23 +827,102 @@ func (m dashboardModel) renderSessionItem(idx int, rp *devdash.RunningProcess, w
 
 	// Port and age
 	port := portStyle.Render(fmt.Sprintf(":%d", rp.Info.Port))
-	age := ageStyle.Render(formatAge(rp.StartedAt))
+	age := ageStyle.Render(m.timeFmt.age(rp.StartedAt, now))
+	if deadline := rp.StopDeadline(); !deadline.IsZero() {
+		age = statusStopped.Render(stoppingLabel(deadline, now))
+	}
+	meta := port + sep + age
+
+	// Tags as colored chips
+	if chips := renderTagChips(m.tags[rp.Info.Name]); chips != "" {
+		meta += sep + chips
+	}
+
+	// Last build time, or the running time of a build in progress
+	if build := buildLabel(rp.Build, rp.Status == devdash.StatusRunning, now); build != "" {
+		meta += sep + build
+	}
+
+	// How far along a dependency install is
+	if install := installLabel(rp, now); install != "" {
+		meta += sep + install
+	}
+
+	// Dense mode folds the tunnel line into a glyph on the same row
+	if m.dense {
+		if glyph := tunnelGlyph(rp.Tunnel); glyph != "" {
+			meta += sep + glyph
+		}
+	}
+
+	// Quiet sessions are dimmed and marked; informational only, status is unchanged
+	if idle := idleFor(rp, m.idleAfter, now); idle > 0 {
+		if !isSelected {
+			nameStyle = dimStyle
+		}
+		meta += sep + idleStyle.Render("idle "+m.timeFmt.age(now.Add(-idle), now))
+	}
 
-	line := fmt.Sprintf("%s%s %s  %s  %s",
-		cursor,
-		statusIcon,
-		nameStyle.Render(name),
-		port,
-		age,
-	)
+	// A server that just came up flashes green for a moment
+	if readyFlashing(rp, now) {
+		nameStyle = readyFlashStyle
+	}
+
+	// Scrolled back in this session's log
+	if ind := m.scrollIndicator(rp.Info.Name); ind != "" {
+		meta += sep + dimStyle.Render(ind)
+	}
+
+	// Log activity over the last minute
+	if rp.LogBuf != nil {
+		if spark := renderSparkline(rp.LogBuf.Activity(now)); spark != "" {
+			meta += sep + sparklineStyle.Render(spark)
+		}
+	}
+
+	// Name budget: everything left after cursor, icon and the port/age columns
+	prefix := cursor + statusIcon + " "
+	indent := strings.Repeat(" ", lipgloss.Width(prefix))
+	nameW := width - lipgloss.Width(prefix) - lipgloss.Width(port) - lipgloss.Width(age) - 2*len(sep)
+	if nameW < sessionNameMinWidth {
+		nameW = sessionNameMinWidth
+	}
+
+	var line string
+	if m.wrapNames && !m.dense && len([]rune(name)) > nameW {
+		// Continuation rows are indented under the name so the cursor and icon column stays clean
+		chunks := wrapRunes(name, width-lipgloss.Width(prefix))
+		rows := make([]string, len(chunks))
+		for i, chunk := range chunks {
+			lead := indent
+			if i == 0 {
+				lead = prefix
+			}
+			rows[i] = lead + nameStyle.Render(chunk)
+		}
+		last := len(rows) - 1
+		if lipgloss.Width(rows[last])+len(sep)+lipgloss.Width(meta) <= width {
+			rows[last] += sep + meta
+		} else {
+			rows = append(rows, indent+meta)
+		}
+		for i, row := range rows {
+			if lipgloss.Width(row) > width {
+				rows[i] = lipgloss.NewStyle().MaxWidth(width).Render(row)
+			}
+		}
+		line = strings.Join(rows, "\n")
+	} else {
+		line = prefix + nameStyle.Render(elideMiddle(name, nameW)) + sep + meta
+	}
 
 	// Truncate if too wide (ANSI-safe via lipgloss MaxWidth)
-	if lipgloss.Width(line) > width {
+	if !strings.Contains(line, "\n") && lipgloss.Width(line) > width {
 		line = lipgloss.NewStyle().MaxWidth(width).Render(line)
 	}
 
 	// Tunnel info as second line
-	if rp.Tunnel != nil {
+	if rp.Tunnel != nil && !m.dense {
 		var tunnelLine string
 		switch rp.Tunnel.Status {
 		case devdash.TunnelStarting:
@@ -572,9 +940,100 @@ func (m dashboardModel) renderSessionItem(idx int, rp *devdash.RunningProcess, w
 		}
 	}
 
+	// A failed boot shows the output that most likely explains it
+	if rp.BootFailed && rp.Status == devdash.StatusError && !m.dense {
+		bootLine := "     " + statusError.Render("failed to boot")
+		if last := lastOutputLine(rp.LogBuf); last != "" {
+			bootLine += dimStyle.Render(": " + last)
+		}
+		if lipgloss.Width(bootLine) > width {
+			bootLine = lipgloss.NewStyle().MaxWidth(width).Render(bootLine)
+		}
+		line += "\n" + bootLine
+	}
+
 	return line
 }
 
+// lastOutputLine returns the last non-blank line the process itself printed,
+// skipping devdash's own "[process ...]" exit markers; "" if there is none
+func lastOutputLine(buf *process.LogBuffer) string {
+	if buf == nil {
+		return ""
+	}
+	lines := buf.Tail(20)
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(ansi.Strip(lines[i]))
+		if line != "" && !strings.HasPrefix(line, "[process ") {
+			return line
+		}
+	}
+	return ""
+}
+
+// stoppingLabel renders the SIGKILL countdown for a session being stopped
+func stoppingLabel(deadline, now time.Time) string {
+	left := int((deadline.Sub(now) + time.Second - 1) / time.Second) // round up
+	if left < 0 {
+		left = 0
+	}
+	return fmt.Sprintf("stopping (%ds)", left)
+}
+
+// idleFor returns how long a running session has produced no output, or 0 if
+// it is not idle (recent output, not running, or idle marking disabled).
+// Sessions that never logged count from their start time.
+func idleFor(rp *devdash.RunningProcess, after time.Duration, now time.Time) time.Duration {
+	if after <= 0 || rp.Status != devdash.StatusRunning {
+		return 0
+	}
+	last := rp.StartedAt
+	if rp.LogBuf != nil {
+		if t := rp.LogBuf.LastLineAt(); !t.IsZero() {
+			last = t
+		}
+	}
+	if last.IsZero() {
+		return 0
+	}
+	if d := now.Sub(last); d >= after {
+		return d
+	}
+	return 0
+}
+
+// sessionStatusIcon renders the status indicator of a session row
+func sessionStatusIcon(rp *devdash.RunningProcess) string {
+	switch rp.Status {
+	case devdash.StatusRunning:
+		return statusRunning.Render("*")
+	case devdash.StatusStopped:
+		return statusStopped.Render("-")
+	case devdash.StatusError:
+		if rp.BootFailed {
+			return statusError.Render("x")
+		}
+		return statusError.Render("!")
+	}
+	return ""
+}
+
+// tunnelGlyph returns a one-character tunnel indicator for dense mode, or "" without a tunnel
+func tunnelGlyph(t *devdash.TunnelInfo) string {
+	if t == nil {
+		return ""
+	}
+	switch t.Status {
+	case devdash.TunnelStarting:
+		return dimStyle.Render("⇡")
+	case devdash.TunnelActive:
+		return tunnelURLStyle.Render("⇡")
+	case devdash.TunnelError:
+		return statusError.Render("⇡")
+	}
+	return ""
+}
+
 // renderLogPanel renders the right panel with log viewport
 func (m dashboardModel) renderLogPanel(w, h int) string {
 	innerW := w - 2
@@ -592,6 +1051,15 @@ func (m dashboardModel) renderLogPanel(w, h int) string {
 	sel := m.SelectedProcess()
 	if sel != nil {
 		title = fmt.Sprintf(" Logs: %s ", sel.Info.Name)
+		if sel.LogBuf != nil && sel.LogBuf.HasMark() {
+			title = fmt.Sprintf(" Logs: %s [since mark] ", sel.Info.Name)
+		}
+		if label := restartLabel(sel, m.timeFmt, m.clock.Now()); label != "" {
+			title += "· " + label + " "
+		}
+		if sel.Info.NoLog {
+			title += "· no persisted log "
+		}
 	}
 
 	// Reserve 1 line for selection or search bar when active
@@ -648,17 +1116,19 @@ func (m dashboardModel) renderHelpBar() string {
 		{"n", "new"},
 		{"k", "kill"},
 		{"r", "restart"},
+		{"d", "dup"},
 		{"t", "tunnel"},
 		{"enter", "fullscreen"},
-		{"tab", "switch"},
+		{"tab", "focus"},
 		{"s", "settings"},
+		{"F", "find all"},
 		{"q", "quit"},
 	}
 
 	// Show copy tunnel URL key when selected process has an active tunnel
 	sel := m.SelectedProcess()
 	if sel != nil && sel.Tunnel != nil && sel.Tunnel.URL != "" {
-		keys = append(keys[:4], append([]struct{ key, desc string }{{"u", "copy url"}}, keys[4:]...)...)
+		keys = append(keys[:5], append([]struct{ key, desc string }{{"u", "copy url"}}, keys[5:]...)...)
 	}
 
 	// Show copy and search keys when log panel is focused
@@ -673,11 +1143,15 @@ func (m dashboardModel) renderHelpBar() string {
 				{"j/k", "move"},
 				{"G/g", "top/bottom"},
 				{"y", "copy"},
+				{"Y", "copy+stay"},
 				{"esc", "cancel"},
 			}
 		} else {
+			keys = append(keys, struct{ key, desc string }{"^d/^u", "half page"})
+			keys = append(keys, struct{ key, desc string }{"^f/^b", "page"})
 			keys = append(keys, struct{ key, desc string }{"c", "copy"})
 			keys = append(keys, struct{ key, desc string }{"y", "copy all"})
+			keys = append(keys, struct{ key, desc string }{"m", "mark"})
 			keys = append(keys, struct{ key, desc string }{"v", "select"})
 			keys = append(keys, struct{ key, desc string }{"/", "search"})
 			keys = append(keys, struct{ key, desc string }{"i", "interactive"})
@@ -689,6 +1163,11 @@ func (m dashboardModel) renderHelpBar() string {
 		parts = append(parts, helpKeyStyle.Render(k.key)+":"+helpDescStyle.Render(k.desc))
 	}
 
+	// Hint at bulk restart when any process has errored
+	if n := len(erroredNames(m.processes)); n > 0 && !m.isInteractive && !m.selection.isActive() {
+		parts = append(parts, statusError.Render(fmt.Sprintf("%d errored — X:restart all", n)))
+	}
+
 	// Append clipboard feedback if present
 	if m.clipboardMsg != "" {
 		parts = append(parts, helpKeyStyle.Render(m.clipboardMsg))
@@ -712,6 +1191,9 @@ func buildTopBorder(title string, innerW int, focused bool) string {
 	}
 	bc := lipgloss.NewStyle().Foreground(color)
 	titleStr := titleStyle.Render(title)
+	if monochrome && focused {
+		titleStr = titleStyle.Reverse(true).Render(title)
+	}
 	titleW := lipgloss.Width(titleStr)
 	fillW := innerW - titleW - 1 // -1 for the dash before title
 	if fillW < 0 {
@@ -730,8 +1212,22 @@ func buildBottomBorder(innerW int, focused bool) string {
 	return bc.Render("╰" + strings.Repeat("─", innerW) + "╯")
 }
 
+// restartLabel describes how often rp was restarted and when last, for the
+// log titles: "restarted 3× 2m ago". "" if it never was.
+func restartLabel(rp *devdash.RunningProcess, f timeFormat, now time.Time) string {
+	if rp.Restarts == 0 {
+		return ""
+	}
+	label := fmt.Sprintf("restarted %d×", rp.Restarts)
+	if ago := f.ago(rp.LastRestart, now); ago != "" {
+		label += " " + ago
+	}
+	return label
+}
+
 // buildBodyLine wraps a content line with side borders and padding.
-// Truncates lines wider than innerW to prevent layout breakage.
+// Lines wider than innerW are cut to end in the clip marker, to prevent
+// layout breakage without hiding that something was cut.
 func buildBodyLine(line string, innerW int, focused bool) string {
 	color := colorGray
 	if focused {
@@ -740,13 +1236,37 @@ func buildBodyLine(line string, innerW int, focused bool) string {
 	bc := lipgloss.NewStyle().Foreground(color)
 	lineW := lipgloss.Width(line)
 	if lineW > innerW {
-		line = ansi.Truncate(line, innerW, "")
+		line = clipRow(line, innerW)
 		lineW = innerW
 	}
 	pad := innerW - lineW
 	return bc.Render("│") + line + strings.Repeat(" ", pad) + bc.Render("│")
 }
 
+// jumpToLevel scrolls the log panel to the next (step 1) or previous (step -1)
+// error or warning line, pausing auto-scroll when it moves
+func (m *dashboardModel) jumpToLevel(step int) tea.Cmd {
+	if m.logBuf == nil || !m.ready {
+		return nil
+	}
+	render := func(lines []string) string { return m.wrapLog(strings.Join(lines, "\n")) }
+	cmd, moved := levelJumpCmd(&m.logViewport, m.logBuf.LinesSinceMark(), render, &m.levelLine, step)
+	if moved {
+		m.autoScroll = false
+	}
+	return cmd
+}
+
+// wrapLog wraps log content to the log panel width
+func (m dashboardModel) wrapLog(content string) string {
+	return hangWrap(content, m.logViewport.Width, m.wrapIndent, wrapLogContent)
+}
+
+// wrapPad returns the indent wrapLog adds to a log line's continuation rows
+func (m dashboardModel) wrapPad(line string) string {
+	return hangPad(line, m.logViewport.Width, m.wrapIndent)
+}
+
 // wrapLogContent wraps long lines in log content to fit within maxWidth.
 // Uses ANSI-aware word wrapping, then hard wrapping to break any remaining
 // long "words" (URLs, paths) that exceed the width.
@@ -758,12 +1278,72 @@ func wrapLogContent(content string, maxWidth int) string {
 	return ansi.Hardwrap(wrapped, maxWidth, false)
 }
 
-// formatAge formats a duration since a time as a human-readable string
-func formatAge(t time.Time) string {
-	if t.IsZero() {
+// sessionNameMinWidth is the narrowest a session name is elided to
+const sessionNameMinWidth = 8
+
+// elideMiddle shortens s to max runes by replacing its middle with "…",
+// keeping both the prefix and the distinguishing suffix visible
+func elideMiddle(s string, max int) string {
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	if max <= 1 {
+		return "…"
+	}
+	head := max / 2
+	tail := max - 1 - head
+	return string(r[:head]) + "…" + string(r[len(r)-tail:])
+}
+
+// wrapRunes splits s into chunks of at most width runes
+func wrapRunes(s string, width int) []string {
+	r := []rune(s)
+	if width < 1 {
+		width = 1
+	}
+	var chunks []string
+	for len(r) > width {
+		chunks = append(chunks, string(r[:width]))
+		r = r[width:]
+	}
+	return append(chunks, string(r))
+}
+
+// sparkBlocks are the sparkline levels, lowest to highest
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// renderSparkline renders counts as block characters scaled to the largest
+// count. Returns "" when every count is zero so idle sessions stay quiet.
+func renderSparkline(counts []int) string {
+	peak := 0
+	for _, c := range counts {
+		if c > peak {
+			peak = c
+		}
+	}
+	if peak == 0 {
 		return ""
 	}
-	d := time.Since(t)
+	out := make([]rune, len(counts))
+	for i, c := range counts {
+		if c == 0 {
+			out[i] = ' '
+			continue
+		}
+		level := (c*len(sparkBlocks) - 1) / peak
+		out[i] = sparkBlocks[level]
+	}
+	return string(out)
+}
+
+// formatAge formats how long before now t was, e.g. 3h12m
+func formatAge(t, now time.Time) string {
+	return timeShort.age(t, now)
+}
+
+// formatDuration formats a duration compactly: 45s, 12m, 2h5m, 3d
+func formatDuration(d time.Duration) string {
 	if d < time.Minute {
 		return fmt.Sprintf("%ds", int(d.Seconds()))
 	}
@@ -0,0 +1,475 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+	"github.com/kimaguri/simplx-toolkit/internal/process"
+)
+
+func TestFormatAge(t *testing.T) {
+	now := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{0, "0s"},
+		{42 * time.Second, "42s"},
+		{5*time.Minute + 30*time.Second, "5m"},
+		{2 * time.Hour, "2h"},
+		{3*time.Hour + 15*time.Minute, "3h15m"},
+		{50 * time.Hour, "2d"},
+	}
+	for _, tt := range tests {
+		if got := formatAge(now.Add(-tt.ago), now); got != tt.want {
+			t.Errorf("formatAge(now-%v) = %q, want %q", tt.ago, got, tt.want)
+		}
+	}
+	if got := formatAge(time.Time{}, now); got != "" {
+		t.Errorf("formatAge(zero) = %q, want empty", got)
+	}
+}
+
+func TestRenderSparkline(t *testing.T) {
+	tests := []struct {
+		counts []int
+		want   string
+	}{
+		{[]int{0, 0, 0}, ""},
+		{[]int{0, 1, 8}, " ▁█"},
+		{[]int{4, 8, 0, 2}, "▄█ ▂"},
+		{[]int{1, 100}, "▁█"},
+	}
+	for _, tt := range tests {
+		if got := renderSparkline(tt.counts); got != tt.want {
+			t.Errorf("renderSparkline(%v) = %q, want %q", tt.counts, got, tt.want)
+		}
+	}
+}
+
+func TestElideMiddle(t *testing.T) {
+	tests := []struct {
+		in   string
+		max  int
+		want string
+	}{
+		{"web", 10, "web"},
+		{"dev-simplx-apps-feature-xyz-web", 15, "dev-sim…xyz-web"},
+		{"abcdef", 5, "ab…ef"},
+		{"abcdef", 1, "…"},
+	}
+	for _, tt := range tests {
+		if got := elideMiddle(tt.in, tt.max); got != tt.want {
+			t.Errorf("elideMiddle(%q, %d) = %q, want %q", tt.in, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestRenderSessionItem_WrapKeepsColumns(t *testing.T) {
+	rp := &devdash.RunningProcess{
+		Info:   devdash.SessionInfo{Name: "dev-simplx-apps-feature-xyz-web", Port: 4000},
+		Status: devdash.StatusRunning,
+	}
+	m := newDashboardModel()
+	m.wrapNames = true
+
+	rows := strings.Split(ansi.Strip(m.renderSessionItem(0, rp, 24)), "\n")
+	if len(rows) < 2 {
+		t.Fatalf("expected wrapped rows, got %q", rows)
+	}
+	if !strings.HasPrefix(rows[0], "> * ") {
+		t.Errorf("first row should carry cursor and icon, got %q", rows[0])
+	}
+	for _, row := range rows[1:] {
+		if !strings.HasPrefix(row, "    ") {
+			t.Errorf("continuation row not indented under the name: %q", row)
+		}
+	}
+	if got := strings.Join(rows, ""); !strings.Contains(strings.ReplaceAll(got, " ", ""), "dev-simplx-apps-feature-xyz-web") {
+		t.Errorf("wrapped name lost characters: %q", rows)
+	}
+}
+
+// fixedClock is a Clock stopped at one instant
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time                       { return time.Time(c) }
+func (c fixedClock) After(time.Duration) <-chan time.Time { return make(chan time.Time) }
+func (c fixedClock) Sleep(time.Duration)                  {}
+
+func TestRenderSessionItem_UptimeFromClock(t *testing.T) {
+	started := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	rp := &devdash.RunningProcess{
+		Info:      devdash.SessionInfo{Name: "web", Port: 4000},
+		Status:    devdash.StatusRunning,
+		StartedAt: started,
+	}
+	m := newDashboardModel()
+	m.clock = fixedClock(started.Add(90 * time.Minute))
+
+	if item := ansi.Strip(m.renderSessionItem(0, rp, 40)); !strings.Contains(item, ":4000  1h30m") {
+		t.Errorf("uptime should be measured at the clock's time, got %q", item)
+	}
+}
+
+func TestPanelWidths_Ratio(t *testing.T) {
+	m := newDashboardModel()
+	m.width = 120
+
+	if left, right := m.panelWidths(); left != 40 || right != 80 {
+		t.Errorf("default split = %d/%d, want 40/80", left, right)
+	}
+
+	m.adjustListRatio(listRatioStep)
+	if left, _ := m.panelWidths(); left <= 40 {
+		t.Errorf("expected wider list after '>', got %d", left)
+	}
+
+	for i := 0; i < 50; i++ {
+		m.adjustListRatio(-listRatioStep)
+	}
+	if m.listRatio != minListRatio {
+		t.Errorf("ratio = %v, want clamped to %v", m.listRatio, minListRatio)
+	}
+	// 15% of 120 is 18, below the 20-column floor
+	if left, _ := m.panelWidths(); left != 20 {
+		t.Errorf("left = %d, want floor of 20", left)
+	}
+}
+
+func TestRenderSessionItem_DenseSingleRow(t *testing.T) {
+	rp := &devdash.RunningProcess{
+		Info:   devdash.SessionInfo{Name: "web", Port: 4000},
+		Status: devdash.StatusRunning,
+		Tunnel: &devdash.TunnelInfo{Status: devdash.TunnelActive, URL: "https://x.trycloudflare.com"},
+	}
+	m := newDashboardModel()
+
+	if rows := strings.Split(m.renderSessionItem(0, rp, 40), "\n"); len(rows) != 2 {
+		t.Fatalf("normal mode should show the tunnel line, got %d rows", len(rows))
+	}
+
+	m.dense = true
+	item := ansi.Strip(m.renderSessionItem(0, rp, 40))
+	if strings.Contains(item, "\n") {
+		t.Fatalf("dense mode should render one row, got %q", item)
+	}
+	if !strings.HasPrefix(item, ">* web :4000") || !strings.Contains(item, "⇡") {
+		t.Errorf("unexpected dense row %q", item)
+	}
+}
+
+func TestWindowItems(t *testing.T) {
+	one := func(s string) []string { return []string{s} }
+	items := [][]string{one("a"), {"b", "b-tunnel"}, one("c"), one("d"), {"e", "e-tunnel"}, one("f"), one("g")}
+
+	// Everything fits
+	if got := windowItems(items, 0, 20); len(got) != 9 {
+		t.Errorf("expected all 9 rows, got %v", got)
+	}
+
+	tests := []struct {
+		selected int
+		want     []string
+	}{
+		{0, []string{"a", "b", "b-tunnel", "c", "  ↓ 4 more"}},
+		{2, []string{"  ↑ 2 more", "c", "d", "  ↓ 3 more"}},
+		{6, []string{"  ↑ 4 more", "e", "e-tunnel", "f", "g"}},
+	}
+	for _, tt := range tests {
+		got := windowItems(items, tt.selected, 5)
+		for i := range got {
+			got[i] = ansi.Strip(got[i])
+		}
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("selected=%d: got %q, want %q", tt.selected, got, tt.want)
+		}
+	}
+}
+
+func TestIdleFor(t *testing.T) {
+	now := time.Now()
+	buf := process.NewLogBuffer(10)
+	buf.Write([]byte("hello\n"))
+	rp := &devdash.RunningProcess{
+		Info:      devdash.SessionInfo{Name: "api"},
+		Status:    devdash.StatusRunning,
+		StartedAt: now.Add(-time.Hour),
+		LogBuf:    buf,
+	}
+
+	if d := idleFor(rp, 5*time.Minute, now); d != 0 {
+		t.Errorf("recent output should not be idle, got %v", d)
+	}
+	if d := idleFor(rp, 5*time.Minute, now.Add(7*time.Minute)); d < 6*time.Minute {
+		t.Errorf("expected idle >= 6m, got %v", d)
+	}
+	if d := idleFor(rp, 0, now.Add(time.Hour)); d != 0 {
+		t.Errorf("disabled idle marking returned %v", d)
+	}
+
+	// No output yet: counts from start
+	rp.LogBuf = process.NewLogBuffer(10)
+	if d := idleFor(rp, 5*time.Minute, now); d < time.Hour {
+		t.Errorf("silent session should be idle since start, got %v", d)
+	}
+
+	// Stopped sessions are never idle
+	rp.Status = devdash.StatusStopped
+	if d := idleFor(rp, 5*time.Minute, now); d != 0 {
+		t.Errorf("stopped session reported idle %v", d)
+	}
+}
+
+func TestStoppingLabel(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		left time.Duration
+		want string
+	}{
+		{15 * time.Second, "stopping (15s)"},
+		{1500 * time.Millisecond, "stopping (2s)"},
+		{-time.Second, "stopping (0s)"},
+	}
+	for _, tt := range tests {
+		if got := stoppingLabel(now.Add(tt.left), now); got != tt.want {
+			t.Errorf("stoppingLabel(%v) = %q, want %q", tt.left, got, tt.want)
+		}
+	}
+}
+
+func TestScrollPositionPerSession(t *testing.T) {
+	newProc := func(name string) *devdash.RunningProcess {
+		buf := process.NewLogBuffer(1000)
+		for i := 0; i < 100; i++ {
+			buf.Write([]byte(name + " line\n"))
+		}
+		return &devdash.RunningProcess{Info: devdash.SessionInfo{Name: name}, LogBuf: buf}
+	}
+	m := newDashboardModel()
+	m.width, m.height = 120, 30
+	m.initViewport()
+	m.SetProcesses([]*devdash.RunningProcess{newProc("api"), newProc("web")})
+	m.SubscribeToSelected()
+
+	// Scroll "api" back, then switch to "web"
+	m.logViewport.SetYOffset(10)
+	m.autoScroll = false
+	if ind := m.scrollIndicator("api"); ind == "" {
+		t.Fatal("expected an indicator for the scrolled-back session")
+	}
+	m.selected = 1
+	m.SubscribeToSelected()
+
+	if !m.autoScroll {
+		t.Error("web should follow the tail")
+	}
+	if ind := m.scrollIndicator("web"); ind != "" {
+		t.Errorf("web indicator = %q, want none", ind)
+	}
+	if ind := m.scrollIndicator("api"); !strings.HasPrefix(ind, "↑") {
+		t.Errorf("api indicator = %q, want ↑NN%%", ind)
+	}
+
+	// Switching back restores the saved position
+	m.selected = 0
+	m.SubscribeToSelected()
+	if m.autoScroll || m.logViewport.YOffset != 10 {
+		t.Errorf("api restored with autoScroll=%v offset=%d, want false/10", m.autoScroll, m.logViewport.YOffset)
+	}
+}
+
+func TestSwitchBackKeepsMark(t *testing.T) {
+	api := process.NewLogBuffer(100)
+	api.Write([]byte("before mark\n"))
+	api.SetMark()
+	api.Write([]byte("after mark\n"))
+	web := process.NewLogBuffer(100)
+	web.Write([]byte("web line\n"))
+
+	m := newDashboardModel()
+	m.width, m.height = 120, 30
+	m.initViewport()
+	m.SetProcesses([]*devdash.RunningProcess{
+		{Info: devdash.SessionInfo{Name: "api"}, LogBuf: api},
+		{Info: devdash.SessionInfo{Name: "web"}, LogBuf: web},
+	})
+	m.selected = 1
+	m.SubscribeToSelected()
+	m.selected = 0
+	m.SubscribeToSelected()
+
+	view := ansi.Strip(m.logViewport.View())
+	if strings.Contains(view, "before mark") || !strings.Contains(view, "after mark") {
+		t.Errorf("switching back should show only lines after the mark:\n%s", view)
+	}
+}
+
+func TestLogPagingKeys(t *testing.T) {
+	buf := process.NewLogBuffer(1000)
+	for i := 0; i < 200; i++ {
+		buf.Write([]byte("line\n"))
+	}
+	m := newDashboardModel()
+	m.width, m.height = 120, 30
+	m.initViewport()
+	m.SetProcesses([]*devdash.RunningProcess{{Info: devdash.SessionInfo{Name: "api"}, LogBuf: buf}})
+	m.SubscribeToSelected()
+	m.focus = focusLogs
+
+	bottom := m.logViewport.YOffset
+	m, _ = m.updateLogs(tea.KeyMsg{Type: tea.KeyCtrlU})
+	if m.autoScroll || m.logViewport.YOffset != bottom-m.logViewport.Height/2 {
+		t.Errorf("ctrl+u: offset=%d autoScroll=%v, want %d/false", m.logViewport.YOffset, m.autoScroll, bottom-m.logViewport.Height/2)
+	}
+	m, _ = m.updateLogs(tea.KeyMsg{Type: tea.KeyCtrlB})
+	if m.logViewport.YOffset != bottom-m.logViewport.Height/2-m.logViewport.Height {
+		t.Errorf("ctrl+b: offset=%d", m.logViewport.YOffset)
+	}
+	m, _ = m.updateLogs(tea.KeyMsg{Type: tea.KeyCtrlF})
+	m, _ = m.updateLogs(tea.KeyMsg{Type: tea.KeyCtrlD})
+	if !m.autoScroll || !m.logViewport.AtBottom() {
+		t.Errorf("back at bottom: offset=%d autoScroll=%v", m.logViewport.YOffset, m.autoScroll)
+	}
+}
+
+func TestStickySearchAcrossSessions(t *testing.T) {
+	procs := []*devdash.RunningProcess{
+		newTestProcess("api", "GET /health", "timeout talking to db"),
+		newTestProcess("web", "timeout", "timeout again", "ready"),
+	}
+	setup := func(sticky bool) dashboardModel {
+		m := newDashboardModel()
+		m.width, m.height = 120, 30
+		m.initViewport()
+		m.stickySearch = sticky
+		m.SetProcesses(procs)
+		m.SubscribeToSelected()
+		m.search.query = "timeout"
+		m.applySearchFilter()
+		m.search.enterNavigateMode()
+		m.selected = 1
+		m.SubscribeToSelected()
+		return m
+	}
+
+	m := setup(true)
+	if m.search.query != "timeout" || m.search.matchCount != 2 || m.search.currentMatch != 1 {
+		t.Errorf("sticky: query=%q matches=%d current=%d, want timeout/2/1", m.search.query, m.search.matchCount, m.search.currentMatch)
+	}
+	if view := ansi.Strip(m.logViewport.View()); strings.Contains(view, "ready") {
+		t.Errorf("new session's log not filtered:\n%s", view)
+	}
+
+	m = setup(false)
+	if m.search.isActive() {
+		t.Error("search should close on switch when not sticky")
+	}
+}
+
+func TestSearchDebounceOnLargeBuffers(t *testing.T) {
+	buf := process.NewLogBuffer(searchDebounceLines + 10)
+	for i := 0; i < searchDebounceLines; i++ {
+		buf.Write([]byte("GET /health 200\n"))
+	}
+	buf.Write([]byte("panic: boom\n"))
+	m := newDashboardModel()
+	m.width, m.height = 120, 30
+	m.initViewport()
+	m.SetProcesses([]*devdash.RunningProcess{{Info: devdash.SessionInfo{Name: "api"}, LogBuf: buf}})
+	m.SubscribeToSelected()
+	m.focus = focusLogs
+	m.search.activate()
+
+	typeKeys := func(s string) {
+		for _, r := range s {
+			m, _ = m.updateLogs(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+	typeKeys("pa")
+	if !m.search.pending || m.search.matchCount != 0 {
+		t.Fatalf("pending=%v matches=%d, want the filter deferred", m.search.pending, m.search.matchCount)
+	}
+	if bar := ansi.Strip(m.search.renderSearchBar(80)); !strings.Contains(bar, "searching...") {
+		t.Errorf("search bar = %q, want a searching state", bar)
+	}
+
+	// Only the latest keystroke's debounce runs the filter
+	m, _ = m.Update(searchDebounceMsg{seq: m.search.seq - 1})
+	if !m.search.pending {
+		t.Error("stale debounce settled the search")
+	}
+	m, _ = m.Update(searchDebounceMsg{seq: m.search.seq})
+	if m.search.pending || m.search.matchCount != 1 {
+		t.Errorf("after debounce: pending=%v matches=%d, want false/1", m.search.pending, m.search.matchCount)
+	}
+
+	// enter filters right away instead of waiting
+	typeKeys("n")
+	m, _ = m.updateLogs(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.search.pending || m.search.matchCount != 1 || m.search.mode != searchNavigate {
+		t.Errorf("after enter: pending=%v matches=%d mode=%v", m.search.pending, m.search.matchCount, m.search.mode)
+	}
+}
+
+func TestBootFailureRow(t *testing.T) {
+	buf := process.NewLogBuffer(100)
+	buf.Write([]byte("Error: Cannot find module 'vite'\n\n[process failed to boot: exited after 0.3s: exit status 1]\n"))
+	buf.Flush()
+	rp := &devdash.RunningProcess{
+		Info:       devdash.SessionInfo{Name: "web", Port: 5173},
+		LogBuf:     buf,
+		Status:     devdash.StatusError,
+		BootFailed: true,
+	}
+	m := newDashboardModel()
+	row := ansi.Strip(m.renderSessionItem(1, rp, 80))
+	lines := strings.Split(row, "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], "x web") {
+		t.Fatalf("row = %q", row)
+	}
+	if !strings.Contains(lines[1], "failed to boot: Error: Cannot find module 'vite'") {
+		t.Errorf("boot line = %q", lines[1])
+	}
+
+	rp.BootFailed = false
+	if row := ansi.Strip(m.renderSessionItem(1, rp, 80)); strings.Contains(row, "\n") || !strings.Contains(row, "! web") {
+		t.Errorf("a later crash keeps the plain error row, got %q", row)
+	}
+}
+
+func TestRestartLabel(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	rp := &devdash.RunningProcess{}
+	if got := restartLabel(rp, timeShort, now); got != "" {
+		t.Errorf("never restarted: %q", got)
+	}
+	rp.Restarts, rp.LastRestart = 3, now.Add(-2*time.Minute)
+	if got := restartLabel(rp, timeShort, now); got != "restarted 3× 2m ago" {
+		t.Errorf("restartLabel = %q", got)
+	}
+	// Reconnected sessions from before restart times were saved
+	rp.LastRestart = time.Time{}
+	if got := restartLabel(rp, timeShort, now); got != "restarted 3×" {
+		t.Errorf("restartLabel without a time = %q", got)
+	}
+}
+
+func TestRenderHelpBar_CopyURLKey(t *testing.T) {
+	m := newDashboardModel()
+	m.width = 300
+	m.SetProcesses([]*devdash.RunningProcess{{
+		Info:   devdash.SessionInfo{Name: "web"},
+		Status: devdash.StatusRunning,
+		Tunnel: &devdash.TunnelInfo{Status: devdash.TunnelActive, URL: "https://a.trycloudflare.com"},
+	}})
+	help := ansi.Strip(m.renderHelpBar())
+	if !strings.Contains(help, "u:copy url") || strings.Count(help, "t:tunnel") != 1 {
+		t.Errorf("help bar = %q, want u:copy url and t:tunnel once", help)
+	}
+}
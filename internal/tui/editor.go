@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+)
+
+// fileRef is a source location found in a log line (e.g. a stack-trace frame)
+type fileRef struct {
+	Path string
+	Line int
+	Col  int // 0 if not present
+}
+
+// fileRefPattern matches Node/TS/JS style locations: `/abs/src/foo.ts:42:10`,
+// `file:///abs/foo.js:3:1`, `src/foo.ts:42` — a path with an extension followed by :line[:col]
+var fileRefPattern = regexp.MustCompile(`(?:file://)?((?:[A-Za-z]:)?[\w.~/@\\-][^\s():'"]*\.[A-Za-z0-9]+):(\d+)(?::(\d+))?`)
+
+// editorFinishedMsg is sent when the editor launched by openInEditor exits
+type editorFinishedMsg struct{ err error }
+
+// parseFileRef returns the first file location in line, e.g. the path in
+// `at fn (/path/file.ts:1:2)`
+func parseFileRef(line string) (fileRef, bool) {
+	m := fileRefPattern.FindStringSubmatch(ansi.Strip(line))
+	if m == nil {
+		return fileRef{}, false
+	}
+	ln, err := strconv.Atoi(m[2])
+	if err != nil || ln <= 0 {
+		return fileRef{}, false
+	}
+	col, _ := strconv.Atoi(m[3])
+	return fileRef{Path: m[1], Line: ln, Col: col}, true
+}
+
+// resolve makes a relative path absolute against workDir
+func (r fileRef) resolve(workDir string) fileRef {
+	if !filepath.IsAbs(r.Path) && workDir != "" {
+		r.Path = filepath.Join(workDir, r.Path)
+	}
+	return r
+}
+
+// editorCommand returns the editor command line for opening ref.
+// Uses $VISUAL, then $EDITOR, then vi. Editors that don't take `+line`
+// (VS Code and friends, Sublime) get their own goto syntax.
+func editorCommand(ref fileRef) []string {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	args := strings.Fields(editor)
+
+	loc := fmt.Sprintf("%s:%d", ref.Path, ref.Line)
+	if ref.Col > 0 {
+		loc += fmt.Sprintf(":%d", ref.Col)
+	}
+	switch filepath.Base(args[0]) {
+	case "code", "code-insiders", "codium", "cursor":
+		return append(args, "--goto", loc)
+	case "subl", "zed":
+		return append(args, loc)
+	default:
+		return append(args, fmt.Sprintf("+%d", ref.Line), ref.Path)
+	}
+}
+
+// openInEditor suspends the TUI and opens ref in the user's editor, run
+// through r. Reports failures through the help-bar feedback message.
+func openInEditor(r devdash.Runner, ref fileRef) tea.Cmd {
+	if _, err := os.Stat(ref.Path); err != nil {
+		return feedbackCmd(fmt.Sprintf("[Not found: %s]", ref.Path))
+	}
+	argv := editorCommand(ref)
+	cmd := r.Command(context.Background(), argv[0], argv[1:]...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return editorFinishedMsg{err: err}
+	})
+}
+
+// openFileRefInLine opens the file location found in line, resolving relative
+// paths against workDir
+func openFileRefInLine(r devdash.Runner, line, workDir string) tea.Cmd {
+	ref, ok := parseFileRef(line)
+	if !ok {
+		return feedbackCmd("[No file:line on this line]")
+	}
+	return openInEditor(r, ref.resolve(workDir))
+}
@@ -0,0 +1,51 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFileRef(t *testing.T) {
+	tests := []struct {
+		line string
+		want fileRef
+		ok   bool
+	}{
+		{"    at handler (/Users/me/project/src/foo.ts:42:10)", fileRef{"/Users/me/project/src/foo.ts", 42, 10}, true},
+		{"    at /Users/me/project/src/foo.ts:42:10", fileRef{"/Users/me/project/src/foo.ts", 42, 10}, true},
+		{"    at Object.<anonymous> (file:///app/dist/index.mjs:3:1)", fileRef{"/app/dist/index.mjs", 3, 1}, true},
+		{"\x1b[31msrc/routes/api.ts:7\x1b[0m error TS2304", fileRef{"src/routes/api.ts", 7, 0}, true},
+		{"    at Module._compile (node:internal/modules/cjs/loader:1105:14)", fileRef{}, false},
+		{"Server listening on http://localhost:3000", fileRef{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseFileRef(tt.line)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("parseFileRef(%q) = %+v, %v; want %+v, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestFileRefResolve(t *testing.T) {
+	if got := (fileRef{Path: "src/a.ts"}).resolve("/repo/web").Path; got != "/repo/web/src/a.ts" {
+		t.Errorf("relative path resolved to %q", got)
+	}
+	if got := (fileRef{Path: "/abs/a.ts"}).resolve("/repo/web").Path; got != "/abs/a.ts" {
+		t.Errorf("absolute path changed to %q", got)
+	}
+}
+
+func TestEditorCommand(t *testing.T) {
+	ref := fileRef{Path: "/repo/a.ts", Line: 42, Col: 10}
+
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "nvim")
+	if got, want := editorCommand(ref), []string{"nvim", "+42", "/repo/a.ts"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("editorCommand = %v, want %v", got, want)
+	}
+
+	t.Setenv("EDITOR", "code -w")
+	if got, want := editorCommand(ref), []string{"code", "-w", "--goto", "/repo/a.ts:42:10"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("editorCommand = %v, want %v", got, want)
+	}
+}
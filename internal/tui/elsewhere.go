@@ -0,0 +1,34 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+)
+
+// sessionAdoptedMsg reports reconnecting to a session found running under
+// another devdash
+type sessionAdoptedMsg struct {
+	name string
+	err  error
+}
+
+// runningElsewherePrompt asks whether to reconnect to a session that is
+// already running instead of launching a second copy of it
+func runningElsewherePrompt(info devdash.SessionInfo) string {
+	port := ""
+	if info.Port > 0 {
+		port = fmt.Sprintf(" on port %d", info.Port)
+	}
+	return fmt.Sprintf("%s is already running as PID %d%s,\npossibly under another devdash.\nReconnect to it instead of launching a second copy?", info.Name, info.PID, port)
+}
+
+// adoptSessionCmd reconnects to the session called name off the UI goroutine
+func adoptSessionCmd(pm *devdash.ProcessManager, name string) tea.Cmd {
+	return func() tea.Msg {
+		_, err := pm.Adopt(name)
+		return sessionAdoptedMsg{name: name, err: err}
+	}
+}
@@ -0,0 +1,42 @@
+package tui
+
+// Dashboard focus model. Tab moves focus forward and shift+tab back through
+// focusOrder, wrapping around, whatever the log panel is doing: a visual
+// selection ends and an open search input is confirmed (its filter stays
+// applied) when focus leaves the log panel. Overlays take Tab for moving
+// between their own fields, and interactive mode sends it to the process.
+
+// focusOrder is the dashboard's Tab cycle
+var focusOrder = []focusPanel{focusList, focusLogs}
+
+// cycleFocus moves focus step panels along focusOrder (1 = tab,
+// -1 = shift+tab), closing log panel modes when focus leaves it
+func (m *dashboardModel) cycleFocus(step int) {
+	i := 0
+	for j, p := range focusOrder {
+		if p == m.focus {
+			i = j
+		}
+	}
+	next := focusOrder[(i+step+len(focusOrder))%len(focusOrder)]
+	if m.focus == focusLogs && next != focusLogs {
+		m.leaveLogs()
+	}
+	m.focus = next
+}
+
+// leaveLogs ends the log panel's modal states as focus moves away: the
+// selection is dropped and a search still being typed is confirmed
+func (m *dashboardModel) leaveLogs() {
+	if m.selection.isActive() {
+		m.selection.deactivate()
+		m.refreshLogViewport()
+	}
+	if m.search.mode == searchInput {
+		if m.search.pending {
+			m.search.pending = false
+			m.applySearchFilter()
+		}
+		m.search.enterNavigateMode()
+	}
+}
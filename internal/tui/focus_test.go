@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+)
+
+// pressDashboard sends one key to the dashboard through the app, as a user would
+func pressDashboard(t *testing.T, a App, key tea.KeyMsg) App {
+	t.Helper()
+	model, _ := a.updateDashboardKeys(key)
+	return model.(App)
+}
+
+func newFocusTestApp() App {
+	m := newDashboardModel()
+	m.width, m.height = 120, 30
+	m.initViewport()
+	m.SetProcesses([]*devdash.RunningProcess{newTestProcess("web", "starting", "ready on :3000")})
+	m.SubscribeToSelected()
+	return App{dashboard: m}
+}
+
+func TestTabCyclesFocus(t *testing.T) {
+	tab, shiftTab := tea.KeyMsg{Type: tea.KeyTab}, tea.KeyMsg{Type: tea.KeyShiftTab}
+	a := newFocusTestApp()
+
+	for i, want := range []focusPanel{focusLogs, focusList, focusLogs} {
+		if a = pressDashboard(t, a, tab); a.dashboard.focus != want {
+			t.Fatalf("tab %d: focus %d, want %d", i+1, a.dashboard.focus, want)
+		}
+	}
+	if a = pressDashboard(t, a, shiftTab); a.dashboard.focus != focusList {
+		t.Errorf("shift+tab: focus %d, want list", a.dashboard.focus)
+	}
+}
+
+func TestTabLeavesLogModes(t *testing.T) {
+	tab := tea.KeyMsg{Type: tea.KeyTab}
+
+	// Visual selection ends
+	a := newFocusTestApp()
+	a = pressDashboard(t, a, tab)
+	a = pressDashboard(t, a, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	if !a.dashboard.selection.isActive() {
+		t.Fatal("v should start a selection")
+	}
+	if a = pressDashboard(t, a, tab); a.dashboard.focus != focusList || a.dashboard.selection.isActive() {
+		t.Errorf("tab in selection: focus %d, selection active %v", a.dashboard.focus, a.dashboard.selection.isActive())
+	}
+
+	// A search being typed is confirmed, and the filter stays
+	a = newFocusTestApp()
+	a = pressDashboard(t, a, tab)
+	for _, r := range "/ready" {
+		a = pressDashboard(t, a, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if a.dashboard.search.mode != searchInput {
+		t.Fatal("/ should open the search input")
+	}
+	a = pressDashboard(t, a, tab)
+	if a.dashboard.focus != focusList || a.dashboard.search.mode != searchNavigate || a.dashboard.search.query != "ready" {
+		t.Errorf("tab in search input: focus %d, mode %d, query %q", a.dashboard.focus, a.dashboard.search.mode, a.dashboard.search.query)
+	}
+	if a.dashboard.search.matchCount != 1 {
+		t.Errorf("filter should stay applied, %d matches", a.dashboard.search.matchCount)
+	}
+}
@@ -0,0 +1,273 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+)
+
+// globalSearchMaxHits caps the result list so huge buffers don't flood the overlay
+const globalSearchMaxHits = 500
+
+// globalSearchHit is a single matching line in one session's log buffer
+type globalSearchHit struct {
+	session string
+	line    int    // index into LogBuf.Lines()
+	text    string // highlighted line
+}
+
+// globalSearchResultMsg delivers the results of a background search
+type globalSearchResultMsg struct {
+	seq       int
+	hits      []globalSearchHit
+	truncated bool
+}
+
+// globalSearchJumpMsg asks the app to open a session's log at a line
+type globalSearchJumpMsg struct {
+	session string
+	line    int
+}
+
+// globalSearchClosedMsg is sent when the search overlay is dismissed
+type globalSearchClosedMsg struct{}
+
+type globalSearchPhase int
+
+const (
+	globalSearchInput globalSearchPhase = iota
+	globalSearchRunning
+	globalSearchResults
+)
+
+// globalSearchModel is an overlay that searches every session's log buffer
+type globalSearchModel struct {
+	input     textinput.Model
+	spinner   spinner.Model
+	phase     globalSearchPhase
+	seq       int // incremented per search; stale results are dropped
+	hits      []globalSearchHit
+	truncated bool
+	cursor    int
+	width     int
+	height    int
+}
+
+// newGlobalSearchModel creates the overlay with the input focused
+func newGlobalSearchModel() globalSearchModel {
+	ti := textinput.New()
+	ti.Placeholder = "search all sessions..."
+	ti.Prompt = "/"
+	ti.CharLimit = 256
+	ti.PromptStyle = searchPromptStyle
+	ti.TextStyle = lipgloss.NewStyle().Foreground(colorWhite)
+	ti.Focus()
+	return globalSearchModel{
+		input:   ti,
+		spinner: spinner.New(spinner.WithSpinner(spinner.Dot)),
+	}
+}
+
+// Update handles keys and search results
+func (m globalSearchModel) Update(msg tea.Msg, procs []*devdash.RunningProcess) (globalSearchModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case globalSearchResultMsg:
+		if msg.seq != m.seq {
+			return m, nil
+		}
+		m.hits = msg.hits
+		m.truncated = msg.truncated
+		m.cursor = 0
+		m.phase = globalSearchResults
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.phase != globalSearchRunning {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case tea.KeyMsg:
+		if m.phase == globalSearchResults {
+			return m.updateResults(msg)
+		}
+		switch msg.String() {
+		case "esc":
+			return m, func() tea.Msg { return globalSearchClosedMsg{} }
+		case "enter":
+			query := m.input.Value()
+			if query == "" {
+				return m, nil
+			}
+			m.seq++
+			m.phase = globalSearchRunning
+			m.input.Blur()
+			return m, tea.Batch(searchAllSessions(procs, query, m.seq), m.spinner.Tick)
+		}
+		if m.phase == globalSearchRunning {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// updateResults handles navigation in the results list
+func (m globalSearchModel) updateResults(msg tea.KeyMsg) (globalSearchModel, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		return m, func() tea.Msg { return globalSearchClosedMsg{} }
+	case "/":
+		m.phase = globalSearchInput
+		return m, m.input.Focus()
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.hits)-1 {
+			m.cursor++
+		}
+	case "enter":
+		if m.cursor < len(m.hits) {
+			hit := m.hits[m.cursor]
+			return m, func() tea.Msg {
+				return globalSearchJumpMsg{session: hit.session, line: hit.line}
+			}
+		}
+	}
+	return m, nil
+}
+
+// searchAllSessions scans every process's log buffer in the background
+func searchAllSessions(procs []*devdash.RunningProcess, query string, seq int) tea.Cmd {
+	lm := newLineMatcher(query, false, matchSubstring)
+	return func() tea.Msg {
+		sorted := make([]*devdash.RunningProcess, len(procs))
+		copy(sorted, procs)
+		sort.Slice(sorted, func(i, j int) bool {
+			return sorted[i].Info.Name < sorted[j].Info.Name
+		})
+
+		var hits []globalSearchHit
+		for _, rp := range sorted {
+			if rp.LogBuf == nil {
+				continue
+			}
+			lines := rp.LogBuf.Lines()
+			for _, idx := range matchingLines(lines, lm) {
+				if len(hits) >= globalSearchMaxHits {
+					return globalSearchResultMsg{seq: seq, hits: hits, truncated: true}
+				}
+				hits = append(hits, globalSearchHit{
+					session: rp.Info.Name,
+					line:    idx,
+					text:    highlightMatches(lines[idx], lm),
+				})
+			}
+		}
+		return globalSearchResultMsg{seq: seq, hits: hits}
+	}
+}
+
+// View renders the overlay
+func (m globalSearchModel) View() string {
+	popupW := m.width - 8
+	if popupW < 40 {
+		popupW = 40
+	}
+	innerW := popupW - 4
+
+	var body string
+	switch m.phase {
+	case globalSearchRunning:
+		body = m.spinner.View() + " Searching..."
+	case globalSearchResults:
+		body = m.renderResults(innerW)
+	default:
+		m.input.Width = innerW - 2
+		body = m.input.View()
+	}
+
+	footer := "enter:search  esc:close"
+	if m.phase == globalSearchResults {
+		footer = "j/k:move  enter:open  /:new search  esc:close"
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		modalTitleStyle.Render("Search All Sessions"),
+		"",
+		body,
+		"",
+		dimStyle.Render(footer),
+	)
+	popup := modalStyle.Width(popupW).Render(content)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, popup)
+}
+
+// renderResults renders hits grouped under their session names
+func (m globalSearchModel) renderResults(width int) string {
+	if len(m.hits) == 0 {
+		return dimStyle.Render(fmt.Sprintf("No matches for %q", m.input.Value()))
+	}
+
+	var lines []string
+	selectedRow := 0
+	prevSession := ""
+	for i, hit := range m.hits {
+		if hit.session != prevSession {
+			lines = append(lines, sectionStyle.Render(hit.session))
+			prevSession = hit.session
+		}
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+			selectedRow = len(lines)
+		}
+		num := dimStyle.Render(fmt.Sprintf("%5d ", hit.line+1))
+		lines = append(lines, ansi.Truncate(prefix+num+hit.text, width, "…"))
+	}
+
+	summary := fmt.Sprintf("%d matches", len(m.hits))
+	if m.truncated {
+		summary = fmt.Sprintf("first %d matches", len(m.hits))
+	}
+
+	maxVisible := m.height - 12
+	if maxVisible < 3 {
+		maxVisible = 3
+	}
+	return searchCountStyle.Render(summary+" for "+fmt.Sprintf("%q", m.input.Value())) + "\n\n" +
+		scrollWindow(lines, selectedRow, maxVisible)
+}
+
+// SetSize updates the terminal dimensions for centering
+func (m *globalSearchModel) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+}
+
+// matchingLines returns the indices of lines lm matches
+func matchingLines(lines []string, lm lineMatcher) []int {
+	if lm.query == "" {
+		return nil
+	}
+	var idx []int
+	for i, line := range lines {
+		if len(lm.ranges(line)) > 0 {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+	"github.com/kimaguri/simplx-toolkit/internal/process"
+)
+
+func newTestProcess(name string, lines ...string) *devdash.RunningProcess {
+	buf := process.NewLogBuffer(100)
+	for _, l := range lines {
+		buf.Write([]byte(l + "\n"))
+	}
+	return &devdash.RunningProcess{Info: devdash.SessionInfo{Name: name}, LogBuf: buf}
+}
+
+func TestSearchAllSessions_GroupsBySession(t *testing.T) {
+	procs := []*devdash.RunningProcess{
+		newTestProcess("web", "starting", "ECONNREFUSED 127.0.0.1:5432", "ready"),
+		newTestProcess("api", "econnrefused on retry", "ok", "ECONNREFUSED again"),
+		newTestProcess("docs", "nothing here"),
+	}
+
+	msg := searchAllSessions(procs, "econnrefused", 7)().(globalSearchResultMsg)
+	if msg.seq != 7 {
+		t.Errorf("seq = %d, want 7", msg.seq)
+	}
+
+	type hit struct {
+		session string
+		line    int
+	}
+	want := []hit{{"api", 0}, {"api", 2}, {"web", 1}}
+	if len(msg.hits) != len(want) {
+		t.Fatalf("got %d hits, want %d: %+v", len(msg.hits), len(want), msg.hits)
+	}
+	for i, w := range want {
+		if msg.hits[i].session != w.session || msg.hits[i].line != w.line {
+			t.Errorf("hit %d = %s:%d, want %s:%d", i, msg.hits[i].session, msg.hits[i].line, w.session, w.line)
+		}
+	}
+}
+
+func TestSearchAllSessions_Truncates(t *testing.T) {
+	lines := make([]string, globalSearchMaxHits+10)
+	for i := range lines {
+		lines[i] = "match"
+	}
+	buf := process.NewLogBuffer(len(lines))
+	for _, l := range lines {
+		buf.Write([]byte(l + "\n"))
+	}
+	procs := []*devdash.RunningProcess{{Info: devdash.SessionInfo{Name: "a"}, LogBuf: buf}}
+
+	msg := searchAllSessions(procs, "match", 1)().(globalSearchResultMsg)
+	if !msg.truncated || len(msg.hits) != globalSearchMaxHits {
+		t.Errorf("expected %d truncated hits, got %d (truncated=%v)", globalSearchMaxHits, len(msg.hits), msg.truncated)
+	}
+}
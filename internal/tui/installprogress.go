@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+)
+
+// installSessionPrefix starts the names of dependency install sessions
+const installSessionPrefix = "install/"
+
+// installProgressLines is how many of the latest log lines are searched for
+// a progress marker
+const installProgressLines = 50
+
+// Install progress markers, matched against ANSI-stripped log lines. They
+// are loose on purpose: a format they don't recognize just means no bar.
+var (
+	// pnpm: "Progress: resolved 812, reused 790, downloaded 3, added 120, done"
+	pnpmProgressPattern = regexp.MustCompile(`Progress: resolved (\d+), reused (\d+), downloaded (\d+)(?:, added \d+)?(, done)?`)
+	// yarn classic: "[2/4] Fetching packages..."
+	yarnStepPattern = regexp.MustCompile(`^\[(\d+)/(\d+)\] `)
+	// The summary every package manager prints at the end
+	installDonePattern = regexp.MustCompile(`(?i)(added \d+ packages?|up to date|packages? installed|done in \d|already up[- ]to[- ]date|lockfile is up to date)`)
+)
+
+// npmStages maps the stages npm logs (timing and verbose lines) to how far
+// along an install they are
+var npmStages = []struct {
+	marker string
+	frac   float64
+}{
+	{"reify:", 0.5},
+	{"idealTree", 0.25},
+	{"build:", 0.8},
+}
+
+// parseInstallProgress returns how far along an install a log line says it
+// is, from 0 to 1; ok is false if the line has no progress marker
+func parseInstallProgress(line string) (frac float64, ok bool) {
+	plain := strings.TrimSpace(ansi.Strip(line))
+	if installDonePattern.MatchString(plain) {
+		return 1, true
+	}
+	if m := pnpmProgressPattern.FindStringSubmatch(plain); m != nil {
+		if m[4] != "" {
+			return 1, true
+		}
+		resolved, _ := strconv.Atoi(m[1])
+		reused, _ := strconv.Atoi(m[2])
+		downloaded, _ := strconv.Atoi(m[3])
+		if resolved == 0 {
+			return 0, true
+		}
+		return min(float64(reused+downloaded)/float64(resolved), 1), true
+	}
+	if m := yarnStepPattern.FindStringSubmatch(plain); m != nil {
+		step, _ := strconv.Atoi(m[1])
+		total, _ := strconv.Atoi(m[2])
+		if total > 0 && step > 0 && step <= total {
+			return float64(step-1) / float64(total), true
+		}
+	}
+	for _, s := range npmStages {
+		if strings.Contains(plain, s.marker) {
+			return s.frac, true
+		}
+	}
+	return 0, false
+}
+
+// installProgress returns the progress of an install session from the
+// latest marker in its log; ok is false if none was recognized yet
+func installProgress(rp *devdash.RunningProcess) (frac float64, ok bool) {
+	if rp.LogBuf == nil {
+		return 0, false
+	}
+	lines := rp.LogBuf.Tail(installProgressLines)
+	for i := len(lines) - 1; i >= 0; i-- {
+		if frac, ok := parseInstallProgress(lines[i]); ok {
+			return frac, true
+		}
+	}
+	return 0, false
+}
+
+// installProgressWidth is the width of the progress bar in cells
+const installProgressWidth = 10
+
+// installLabel renders the session row's install progress: a bar and
+// percentage once the output shows how far along it is, else a spinner.
+// "" for sessions that aren't a running install.
+func installLabel(rp *devdash.RunningProcess, now time.Time) string {
+	if !strings.HasPrefix(rp.Info.Name, installSessionPrefix) || rp.Status != devdash.StatusRunning {
+		return ""
+	}
+	frac, ok := installProgress(rp)
+	if !ok {
+		frames := spinner.MiniDot.Frames
+		frame := frames[int(now.UnixMilli()/int64(spinner.MiniDot.FPS/time.Millisecond))%len(frames)]
+		return statusStopped.Render(frame + " installing")
+	}
+	filled := int(frac * installProgressWidth)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", installProgressWidth-filled)
+	return statusStopped.Render(fmt.Sprintf("%s %3.0f%%", bar, frac*100))
+}
@@ -0,0 +1,57 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+	"github.com/kimaguri/simplx-toolkit/internal/process"
+)
+
+func TestParseInstallProgress(t *testing.T) {
+	tests := []struct {
+		line string
+		frac float64
+		ok   bool
+	}{
+		{"Progress: resolved 200, reused 100, downloaded 50, added 0", 0.75, true},
+		{"\x1b[1mProgress: resolved 812, reused 800, downloaded 12, added 812, done\x1b[0m", 1, true},
+		{"Progress: resolved 0, reused 0, downloaded 0, added 0", 0, true},
+		{"[3/4] Linking dependencies...", 0.5, true},
+		{"npm timing reify:loadTrees Completed in 812ms", 0.5, true},
+		{"added 1204 packages, and audited 1205 packages in 14s", 1, true},
+		{"Done in 3.2s", 1, true},
+		{"WARN deprecated inflight@1.0.6", 0, false},
+		{"[9/4] nonsense", 0, false},
+	}
+	for _, tt := range tests {
+		frac, ok := parseInstallProgress(tt.line)
+		if ok != tt.ok || frac != tt.frac {
+			t.Errorf("parseInstallProgress(%q) = %v, %v; want %v, %v", tt.line, frac, ok, tt.frac, tt.ok)
+		}
+	}
+}
+
+func TestInstallLabel(t *testing.T) {
+	buf := process.NewLogBuffer(100)
+	rp := &devdash.RunningProcess{
+		Info:   devdash.SessionInfo{Name: installSessionPrefix + "web"},
+		Status: devdash.StatusRunning,
+		LogBuf: buf,
+	}
+	now := time.Now()
+	if got := installLabel(rp, now); !strings.Contains(got, "installing") {
+		t.Errorf("without a marker: got %q, want the spinner", got)
+	}
+
+	buf.Write([]byte("Progress: resolved 10, reused 4, downloaded 1, added 0\nWARN something\n"))
+	if got := installLabel(rp, now); !strings.Contains(got, "█████░░░░░  50%") {
+		t.Errorf("half done: got %q", got)
+	}
+
+	rp.Info.Name = "web-dev"
+	if got := installLabel(rp, now); got != "" {
+		t.Errorf("not an install session: got %q", got)
+	}
+}
@@ -0,0 +1,164 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Keys structured loggers (pino, bunyan, winston, zap, logrus) put the
+// timestamp, level and message under, most common first
+var (
+	jsonTimeKeys  = []string{"time", "timestamp", "ts", "@timestamp"}
+	jsonLevelKeys = []string{"level", "severity", "lvl"}
+	jsonMsgKeys   = []string{"msg", "message"}
+)
+
+// jsonNoiseKeys are fields pino and bunyan add to every line that say
+// nothing about it; left out of the compact form
+var jsonNoiseKeys = map[string]bool{"pid": true, "hostname": true, "v": true}
+
+// prettyJSONLines returns lines with each JSON object line replaced by its
+// compact form; other lines are passed through untouched
+func prettyJSONLines(lines []string) []string {
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		out[i] = prettyJSONLine(line)
+	}
+	return out
+}
+
+// prettyJSONLine renders a one-object JSON log line compactly as
+// "15:04:05 LEVEL message key=value ...", the level colored by severity.
+// Lines that aren't a JSON object are returned as they are.
+func prettyJSONLine(line string) string {
+	plain := strings.TrimSpace(ansi.Strip(line))
+	if !strings.HasPrefix(plain, "{") || !strings.HasSuffix(plain, "}") {
+		return line
+	}
+	dec := json.NewDecoder(strings.NewReader(plain))
+	dec.UseNumber()
+	var obj map[string]any
+	if err := dec.Decode(&obj); err != nil || dec.More() {
+		return line
+	}
+
+	var parts []string
+	if t, ok := takeField(obj, jsonTimeKeys); ok {
+		parts = append(parts, dimStyle.Render(jsonTime(t)))
+	}
+	if l, ok := takeField(obj, jsonLevelKeys); ok {
+		parts = append(parts, jsonLevel(l))
+	}
+	if msg, ok := takeField(obj, jsonMsgKeys); ok {
+		parts = append(parts, jsonValue(msg, false))
+	}
+
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		if !jsonNoiseKeys[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		parts = append(parts, dimStyle.Render(k+"=")+jsonValue(obj[k], true))
+	}
+	return strings.Join(parts, " ")
+}
+
+// takeField removes and returns the first of keys present in obj
+func takeField(obj map[string]any, keys []string) (any, bool) {
+	for _, k := range keys {
+		if v, ok := obj[k]; ok {
+			delete(obj, k)
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// jsonTime formats a timestamp as a local wall-clock time: epoch
+// milliseconds (pino) or seconds (zap), or an RFC 3339 string (bunyan).
+// Anything else is shown as it is.
+func jsonTime(v any) string {
+	switch t := v.(type) {
+	case json.Number:
+		f, err := t.Float64()
+		if err != nil {
+			return t.String()
+		}
+		if f > 1e12 {
+			return time.UnixMilli(int64(f)).Format("15:04:05.000")
+		}
+		return time.UnixMilli(int64(f * 1000)).Format("15:04:05.000")
+	case string:
+		if parsed, err := time.Parse(time.RFC3339Nano, t); err == nil {
+			return parsed.Local().Format("15:04:05.000")
+		}
+		return t
+	}
+	return jsonValue(v, false)
+}
+
+// jsonLevel renders a level, numeric (pino/bunyan: 10 trace ... 60 fatal) or
+// named, as a padded upper-case word colored by severity. The words are the
+// ones classifyLine looks for, so error jumps and scrollbar ticks still work.
+func jsonLevel(v any) string {
+	name := strings.ToUpper(jsonValue(v, false))
+	if n, ok := v.(json.Number); ok {
+		switch i, _ := n.Int64(); {
+		case i >= 60:
+			name = "FATAL"
+		case i >= 50:
+			name = "ERROR"
+		case i >= 40:
+			name = "WARN"
+		case i >= 30:
+			name = "INFO"
+		case i >= 20:
+			name = "DEBUG"
+		default:
+			name = "TRACE"
+		}
+	}
+	padded := fmt.Sprintf("%-5s", name)
+	switch classifyLine(name) {
+	case levelError:
+		return statusError.Render(padded)
+	case levelWarn:
+		return portStyle.Render(padded)
+	}
+	if name == "DEBUG" || name == "TRACE" {
+		return dimStyle.Render(padded)
+	}
+	return padded
+}
+
+// jsonValue formats a field value: strings bare (quoted when quote is set and
+// they contain spaces), nested objects and arrays as compact JSON
+func jsonValue(v any, quote bool) string {
+	switch t := v.(type) {
+	case string:
+		if quote && strings.ContainsAny(t, " \t") {
+			return fmt.Sprintf("%q", t)
+		}
+		return t
+	case json.Number:
+		return t.String()
+	case nil:
+		return "null"
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Sprint(v)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
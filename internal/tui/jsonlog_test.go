@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestPrettyJSONLine(t *testing.T) {
+	at := time.Date(2026, 3, 1, 14, 2, 33, 120e6, time.Local)
+	tests := []struct {
+		name, line, want string
+	}{
+		{"pino",
+			`{"level":50,"time":` + strconv.FormatInt(at.UnixMilli(), 10) + `,"pid":42,"hostname":"mac","msg":"request failed","status":502,"req":{"url":"/api"}}`,
+			`14:02:33.120 ERROR request failed req={"url":"/api"} status=502`},
+		{"bunyan",
+			`{"name":"api","hostname":"mac","pid":1,"level":30,"msg":"listening","time":"` + at.UTC().Format(time.RFC3339Nano) + `","v":0}`,
+			`14:02:33.120 INFO  listening name=api`},
+		{"named level, quoted value",
+			`{"severity":"warning","message":"slow query","sql":"select 1"}`,
+			`WARNING slow query sql="select 1"`},
+		{"plain text", `ready in 120ms`, `ready in 120ms`},
+		{"not an object", `[1,2,3]`, `[1,2,3]`},
+		{"broken JSON", `{"level":30,"msg":`, `{"level":30,"msg":`},
+		{"two objects", `{"a":1} {"b":2}`, `{"a":1} {"b":2}`},
+	}
+	for _, tt := range tests {
+		if got := ansi.Strip(prettyJSONLine(tt.line)); got != tt.want {
+			t.Errorf("%s:\n got %q\nwant %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPrettyJSONLevelIsClassified(t *testing.T) {
+	if got := classifyLine(prettyJSONLine(`{"level":40,"msg":"disk almost full"}`)); got != levelWarn {
+		t.Errorf("level 40 classified as %v, want warning", got)
+	}
+}
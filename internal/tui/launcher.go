@@ -5,12 +5,15 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/kimaguri/simplx-toolkit/internal/config"
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
 	"github.com/kimaguri/simplx-toolkit/internal/discovery"
 )
 
@@ -19,8 +22,20 @@ type LaunchRequestMsg struct {
 	Worktree       discovery.Worktree
 	Project        discovery.Project
 	Port           int
-	Script         string // selected script name (e.g. "dev", "start")
-	PackageManager string // detected package manager binary (e.g. "pnpm", "npm")
+	Script         string                // selected script name (e.g. "dev", "start")
+	Custom         *config.CustomCommand // .devdash.json command to run instead of a script; nil = Script
+	PackageManager string                // detected package manager binary (e.g. "pnpm", "npm")
+	EncoreArgs     []string              // extra `encore run` args (Encore projects only)
+	SessionName    string                // explicit session name (duplicates); empty = derived from worktree and project
+	StopTimeout    time.Duration         // graceful-shutdown window from config; 0 = manager default
+	StopSignal     string                // graceful stop signal from config; "" = SIGTERM
+	OpenFiles      int                   // soft open-file limit from config; 0 = inherit
+	VersionManager string                // Node version manager from config; "" = run commands directly
+	CleanEnv       bool                  // start from a minimal environment, see devdash.BaseEnv
+
+	portChecked      bool // port holders were already reported for this request
+	elsewhereChecked bool // a copy running under another devdash was already reported
+	fromProfile      bool // queued by StartProfile: waits for overlays and doesn't save its port
 }
 
 // launcherStep tracks which step of the wizard we're on
@@ -49,30 +64,55 @@ type launcherModel struct {
 	// Step 3: modules
 	projects     []discovery.Project
 	projIndex    int
-	// Step 4: scripts
+	// Step 4: scripts — .devdash.json commands first, then package.json scripts
+	commands     []config.CustomCommand
 	scripts      []string
 	scriptIndex  int
 	// Step 5: port
 	portInput    textinput.Model
 	portFixed    bool
 	portMap      map[string]int
+	// config
+	cfg          *config.LocalConfig
+	filter       discovery.Filter
+	runner       devdash.Runner // resolves binaries for the preview and dry run
+	clock        devdash.Clock  // dates the repo and directory ages
+	// Duplicate of a running session: fixed session name for the new instance
+	sessionName  string
+	// Confirm step: launch with the opposite of the configured clean_env
+	envToggled   bool
+	// Dry run (from confirm step): resolved command preview, nothing is started
+	dryRun       bool
+	dryRunView   viewport.Model
 	// layout
 	width        int
 	height       int
 }
 
+// discoveryFilter builds the project discovery filter from the user's configuration
+func discoveryFilter(cfg *config.LocalConfig) discovery.Filter {
+	return discovery.Filter{
+		Ignore:          cfg.IgnorePatterns,
+		Include:         cfg.IncludePatterns,
+		PriorityScripts: cfg.PriorityScripts,
+		Orchestrators:   cfg.Orchestrators,
+	}
+}
+
 // newLauncherModel creates a new launch wizard
-func newLauncherModel(worktrees []discovery.Worktree, portOverrides map[string]int) launcherModel {
+func newLauncherModel(worktrees []discovery.Worktree, cfg *config.LocalConfig) launcherModel {
 	ti := textinput.New()
 	ti.Placeholder = "3000"
 	ti.Width = 10
 	ti.CharLimit = 5
 
+	filter := discoveryFilter(cfg)
+
 	// Separate main repos from worktrees, filter to those with projects
 	var mainRepos []discovery.Worktree
 	for _, wt := range worktrees {
 		if !wt.IsWorktree {
-			projects := discovery.DetectProjects(wt)
+			projects := discovery.DetectProjectsFiltered(wt, filter)
 			if len(projects) > 0 {
 				mainRepos = append(mainRepos, wt)
 			}
@@ -88,8 +128,12 @@ func newLauncherModel(worktrees []discovery.Worktree, portOverrides map[string]i
 		step:         stepRepo,
 		allWorktrees: worktrees,
 		mainRepos:    mainRepos,
-		portMap:      portOverrides,
+		portMap:      cfg.PortOverrides,
 		portInput:    ti,
+		cfg:          cfg,
+		filter:       filter,
+		runner:       devdash.SystemRunner,
+		clock:        devdash.SystemClock,
 	}
 }
 
@@ -102,6 +146,10 @@ func (m launcherModel) Init() tea.Cmd {
 func (m launcherModel) Update(msg tea.Msg) (launcherModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.dryRun {
+			return m.updateDryRun(msg)
+		}
+
 		switch msg.String() {
 		case "esc":
 			if m.step == stepRepo {
@@ -111,7 +159,8 @@ func (m launcherModel) Update(msg tea.Msg) (launcherModel, tea.Cmd) {
 			if m.step == stepModule && len(m.directories) <= 1 {
 				m.step = stepRepo
 			} else if m.step == stepPort && m.projIndex < len(m.projects) &&
-				m.projects[m.projIndex].IsEncore && len(m.projects[m.projIndex].Scripts) == 0 {
+				m.projects[m.projIndex].IsEncore && len(m.projects[m.projIndex].Scripts) == 0 &&
+				len(m.projects[m.projIndex].Commands) == 0 {
 				m.step = stepModule
 			} else {
 				m.step--
@@ -134,6 +183,18 @@ func (m launcherModel) Update(msg tea.Msg) (launcherModel, tea.Cmd) {
 				return m.advance()
 			}
 			return m, nil
+
+		case "d":
+			if m.step == stepConfirm {
+				m.openDryRun()
+				return m, nil
+			}
+
+		case "e":
+			if m.step == stepConfirm {
+				m.envToggled = !m.envToggled
+				return m, nil
+			}
 		}
 
 		if m.step == stepPort && !m.portFixed {
@@ -173,6 +234,7 @@ func (m launcherModel) advance() (launcherModel, tea.Cmd) {
 		return m.advanceFromScript()
 	case stepPort:
 		m.step = stepConfirm
+		m.envToggled = false
 		m.portInput.Blur()
 		return m, nil
 	case stepConfirm:
@@ -185,16 +247,31 @@ func (m launcherModel) advanceFromRepo() (launcherModel, tea.Cmd) {
 	if len(m.mainRepos) == 0 {
 		return m, nil
 	}
-	selectedRepo := m.mainRepos[m.repoIndex]
+	m.loadDirectories(m.mainRepos[m.repoIndex])
+	m.dirIndex = 0
 
+	// Auto-skip directory step if only main dir exists
+	if len(m.directories) == 1 {
+		m.projects = m.dirProjects[0]
+		m.projIndex = m.lastProjectIndex()
+		m.step = stepModule
+		return m, nil
+	}
+	m.step = stepDirectory
+	return m, nil
+}
+
+// loadDirectories lists repo's main dir and its worktrees that have projects,
+// freshest first, for the directory step
+func (m *launcherModel) loadDirectories(selectedRepo discovery.Worktree) {
 	// Build directory list: main dir + worktrees belonging to this project
 	dirs := []discovery.Worktree{selectedRepo}
 	var dirProjects [][]discovery.Project
-	dirProjects = append(dirProjects, discovery.DetectProjects(selectedRepo))
+	dirProjects = append(dirProjects, discovery.DetectProjectsFiltered(selectedRepo, m.filter))
 
 	for _, wt := range m.allWorktrees {
 		if wt.IsWorktree && wt.MainProject == selectedRepo.Name {
-			projects := discovery.DetectProjects(wt)
+			projects := discovery.DetectProjectsFiltered(wt, m.filter)
 			if len(projects) > 0 {
 				dirs = append(dirs, wt)
 				dirProjects = append(dirProjects, projects)
@@ -220,17 +297,46 @@ func (m launcherModel) advanceFromRepo() (launcherModel, tea.Cmd) {
 
 	m.directories = sortedDirs
 	m.dirProjects = sortedProjects
-	m.dirIndex = 0
+}
 
-	// Auto-skip directory step if only main dir exists
-	if len(dirs) == 1 {
-		m.projects = dirProjects[0]
-		m.projIndex = 0
-		m.step = stepModule
-		return m, nil
+// refreshWorktrees takes the repos and worktrees of fresh, a launcher built
+// from a rescan, keeping the selected repo and directory selected. Only the
+// repo and directory steps list worktrees; further along nothing changes.
+func (m launcherModel) refreshWorktrees(fresh launcherModel) launcherModel {
+	if m.step != stepRepo && m.step != stepDirectory {
+		return m
 	}
-	m.step = stepDirectory
-	return m, nil
+	var repoPath, dirPath string
+	if m.repoIndex < len(m.mainRepos) {
+		repoPath = m.mainRepos[m.repoIndex].Path
+	}
+	if m.step == stepDirectory && m.dirIndex < len(m.directories) {
+		dirPath = m.directories[m.dirIndex].Path
+	}
+
+	m.allWorktrees, m.mainRepos = fresh.allWorktrees, fresh.mainRepos
+	repo := worktreeIndex(m.mainRepos, repoPath)
+	m.repoIndex = max(repo, 0)
+	if m.step == stepRepo {
+		return m
+	}
+	if repo < 0 {
+		m.step = stepRepo // the repo is gone
+		return m
+	}
+	m.loadDirectories(m.mainRepos[m.repoIndex])
+	m.dirIndex = max(worktreeIndex(m.directories, dirPath), 0)
+	return m
+}
+
+// worktreeIndex returns the index of the worktree at path in wts, -1 if absent
+func worktreeIndex(wts []discovery.Worktree, path string) int {
+	for i, wt := range wts {
+		if wt.Path == path {
+			return i
+		}
+	}
+	return -1
 }
 
 func (m launcherModel) advanceFromDirectory() (launcherModel, tea.Cmd) {
@@ -240,20 +346,32 @@ func (m launcherModel) advanceFromDirectory() (launcherModel, tea.Cmd) {
 	if m.dirIndex < len(m.dirProjects) {
 		m.projects = m.dirProjects[m.dirIndex]
 	} else {
-		m.projects = discovery.DetectProjects(m.directories[m.dirIndex])
+		m.projects = discovery.DetectProjectsFiltered(m.directories[m.dirIndex], m.filter)
 	}
-	m.projIndex = 0
+	m.projIndex = m.lastProjectIndex()
 	m.step = stepModule
 	return m, nil
 }
 
+// lastProjectIndex returns the index of the project last launched from the
+// selected directory, 0 if there is none or it's no longer listed
+func (m launcherModel) lastProjectIndex() int {
+	name := m.cfg.LastProject(m.selectedWorktree().Name)
+	for i, proj := range m.projects {
+		if name != "" && proj.Name == name {
+			return i
+		}
+	}
+	return 0
+}
+
 func (m launcherModel) advanceFromModule() (launcherModel, tea.Cmd) {
 	if len(m.projects) == 0 {
 		return m, nil
 	}
 	proj := m.projects[m.projIndex]
 
-	if proj.IsEncore && len(proj.Scripts) == 0 {
+	if proj.IsEncore && len(proj.Scripts) == 0 && len(proj.Commands) == 0 {
 		dir := m.selectedWorktree()
 		key := config.PortKey(dir.Name, proj.Name)
 		m.portFixed = false
@@ -263,18 +381,25 @@ func (m launcherModel) advanceFromModule() (launcherModel, tea.Cmd) {
 			m.portInput.SetValue("3000")
 		}
 		m.portInput.Focus()
+		m.commands = nil
 		m.scripts = nil
 		m.scriptIndex = 0
 		m.step = stepPort
 		return m, textinput.Blink
 	}
 
+	m.commands = config.LoadProjectConfig(proj.Path).LaunchCommands()
 	m.scripts = proj.Scripts
 	m.scriptIndex = 0
 	m.step = stepScript
 	return m, nil
 }
 
+// scriptCount is the number of entries in the script step
+func (m launcherModel) scriptCount() int {
+	return len(m.commands) + len(m.scripts)
+}
+
 func (m launcherModel) advanceFromScript() (launcherModel, tea.Cmd) {
 	dir := m.selectedWorktree()
 	proj := m.projects[m.projIndex]
@@ -303,9 +428,19 @@ func (m launcherModel) advanceFromScript() (launcherModel, tea.Cmd) {
 }
 
 func (m launcherModel) advanceFromConfirm() (launcherModel, tea.Cmd) {
-	if len(m.directories) == 0 || len(m.projects) == 0 {
+	req, ok := m.launchRequest()
+	if !ok {
 		return m, nil
 	}
+	return m, func() tea.Msg { return req }
+}
+
+// launchRequest builds the launch request from the current wizard selections.
+// Returns false if no project is selected.
+func (m launcherModel) launchRequest() (LaunchRequestMsg, bool) {
+	if len(m.directories) == 0 || len(m.projects) == 0 {
+		return LaunchRequestMsg{}, false
+	}
 	wt := m.selectedWorktree()
 	proj := m.projects[m.projIndex]
 
@@ -317,19 +452,162 @@ func (m launcherModel) advanceFromConfirm() (launcherModel, tea.Cmd) {
 	}
 
 	script := ""
-	if m.scriptIndex < len(m.scripts) {
-		script = m.scripts[m.scriptIndex]
+	var custom *config.CustomCommand
+	if m.scriptIndex < len(m.commands) {
+		c := m.commands[m.scriptIndex]
+		script = c.Name
+		custom = &c
+	} else if i := m.scriptIndex - len(m.commands); i < len(m.scripts) {
+		script = m.scripts[i]
 	}
 
-	return m, func() tea.Msg {
-		return LaunchRequestMsg{
-			Worktree:       wt,
-			Project:        proj,
-			Port:           port,
-			Script:         script,
-			PackageManager: proj.PackageManager,
+	var encoreArgs []string
+	if proj.IsEncore {
+		encoreArgs = m.cfg.EncoreArgsFor(proj.Path)
+	}
+
+	return LaunchRequestMsg{
+		Worktree:       wt,
+		Project:        proj,
+		Port:           port,
+		Script:         script,
+		Custom:         custom,
+		PackageManager: proj.PackageManager,
+		EncoreArgs:     encoreArgs,
+		SessionName:    m.sessionName,
+		StopTimeout:    m.cfg.StopTimeoutFor(proj.Path),
+		StopSignal:     m.cfg.StopSignalFor(proj.Path),
+		OpenFiles:      m.cfg.OpenFilesFor(proj.Path),
+		VersionManager: m.cfg.VersionManager,
+		CleanEnv:       m.cfg.CleanEnvFor(proj.Path) != m.envToggled,
+	}, true
+}
+
+// prefillDuplicate walks the wizard to the confirm step for a copy of an
+// existing session: same directory, project and script, launched as name on
+// port. Returns false if the session's directory or project is no longer found.
+func (m launcherModel) prefillDuplicate(info devdash.SessionInfo, name string, port int) (launcherModel, bool) {
+	// Find the main repo that owns the session's directory
+	repo := ""
+	for _, wt := range m.allWorktrees {
+		if wt.Path == info.WtPath {
+			repo = wt.Name
+			if wt.IsWorktree {
+				repo = wt.MainProject
+			}
+			break
 		}
 	}
+	m.repoIndex = -1
+	for i, r := range m.mainRepos {
+		if r.Name == repo {
+			m.repoIndex = i
+		}
+	}
+	if m.repoIndex < 0 {
+		return m, false
+	}
+
+	m, _ = m.advanceFromRepo()
+	m.dirIndex = -1
+	for i, d := range m.directories {
+		if d.Path == info.WtPath {
+			m.dirIndex = i
+		}
+	}
+	if m.dirIndex < 0 {
+		return m, false
+	}
+	m.projects = m.dirProjects[m.dirIndex]
+	m.projIndex = -1
+	for i, p := range m.projects {
+		if p.Name == info.Project {
+			m.projIndex = i
+		}
+	}
+	if m.projIndex < 0 {
+		return m, false
+	}
+	proj := m.projects[m.projIndex]
+
+	m.commands = config.LoadProjectConfig(proj.Path).LaunchCommands()
+	m.scripts = proj.Scripts
+	m.scriptIndex = 0
+	if info.Custom {
+		for i, c := range m.commands {
+			if c.Name == info.Script {
+				m.scriptIndex = i
+			}
+		}
+	} else {
+		for i, s := range m.scripts {
+			if s == info.Script {
+				m.scriptIndex = len(m.commands) + i
+			}
+		}
+	}
+
+	m.portFixed = proj.PortFixed && proj.DetectedPort > 0
+	if m.portFixed {
+		port = proj.DetectedPort
+	}
+	m.portInput.SetValue(fmt.Sprintf("%d", port))
+	m.sessionName = name
+	m.step = stepConfirm
+	return m, true
+}
+
+// openDryRun resolves the launch into its exact command and shows it
+// in a scrollable preview instead of starting the process
+func (m *launcherModel) openDryRun() {
+	req, ok := m.launchRequest()
+	if !ok {
+		return
+	}
+	info, err := buildSessionInfo(m.runner, req)
+	if err != nil {
+		m.showDryRun([]string{statusError.Render(err.Error())})
+		return
+	}
+
+	lines := []string{
+		dimStyle.Render("Working dir:"),
+		"  " + info.WorkDir,
+		"",
+		dimStyle.Render("Command:"),
+		"  " + selectedItemStyle.Render(formatCommandLine(info.Command, info.Args)),
+		"",
+		dimStyle.Render("Environment (added to inherited env):"),
+	}
+	for _, kv := range devdash.LaunchEnv(info) {
+		lines = append(lines, "  "+portStyle.Render(kv))
+	}
+	lines = append(lines, "", dimStyle.Render("Session: ")+info.Name)
+	m.showDryRun(lines)
+}
+
+// showDryRun opens the dry-run preview with lines
+func (m *launcherModel) showDryRun(lines []string) {
+	w := m.popupWidth() - 6
+	vp := viewport.New(w, m.maxVisibleItems(0))
+	vp.SetContent(wrapLogContent(strings.Join(lines, "\n"), w))
+	m.dryRunView = vp
+	m.dryRun = true
+}
+
+// updateDryRun handles keys while the dry-run preview is shown
+func (m launcherModel) updateDryRun(msg tea.KeyMsg) (launcherModel, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "d":
+		m.dryRun = false
+		return m, nil
+	case "enter":
+		m.dryRun = false
+		return m.advanceFromConfirm()
+	}
+	var cmd tea.Cmd
+	m.dryRunView, cmd = m.dryRunView.Update(msg)
+	return m, cmd
 }
 
 // moveSelection navigates the current list
@@ -351,10 +629,10 @@ func (m *launcherModel) moveSelection(delta int) {
 		}
 		m.projIndex = clampIndex(m.projIndex+delta, len(m.projects))
 	case stepScript:
-		if len(m.scripts) == 0 {
+		if m.scriptCount() == 0 {
 			return
 		}
-		m.scriptIndex = clampIndex(m.scriptIndex+delta, len(m.scripts))
+		m.scriptIndex = clampIndex(m.scriptIndex+delta, m.scriptCount())
 	}
 }
 
@@ -382,8 +660,8 @@ func clampIndex(idx, length int) int {
 	return idx
 }
 
-// View renders the launcher popup
-func (m launcherModel) View() string {
+// popupWidth returns the launcher modal width for the current terminal size
+func (m launcherModel) popupWidth() int {
 	maxWidth := m.width * 80 / 100
 	if maxWidth < 50 {
 		maxWidth = 50
@@ -391,9 +669,19 @@ func (m launcherModel) View() string {
 	if maxWidth > 120 {
 		maxWidth = 120
 	}
+	return maxWidth
+}
+
+// View renders the launcher popup
+func (m launcherModel) View() string {
+	maxWidth := m.popupWidth()
 
 	title := modalTitleStyle.Render("Launch New Process")
+	if m.sessionName != "" {
+		title = modalTitleStyle.Render("Duplicate Session")
+	}
 	var body string
+	footer := "enter:select  esc:back  arrows:navigate"
 
 	switch m.step {
 	case stepRepo:
@@ -408,6 +696,12 @@ func (m launcherModel) View() string {
 		body = m.renderPortInput(maxWidth - 6)
 	case stepConfirm:
 		body = m.renderConfirm(maxWidth - 6)
+		footer = "enter:launch  d:dry run  esc:back"
+	}
+	if m.dryRun {
+		title = modalTitleStyle.Render("Dry Run") + dimStyle.Render("  (nothing started)")
+		body = m.dryRunView.View()
+		footer = "enter:launch  arrows:scroll  esc:back"
 	}
 
 	stepIndicator := m.renderStepIndicator()
@@ -419,7 +713,7 @@ func (m launcherModel) View() string {
 		"",
 		body,
 		"",
-		dimStyle.Render("enter:select  esc:back  arrows:navigate"),
+		dimStyle.Render(footer),
 	)
 
 	popup := modalStyle.
@@ -483,7 +777,7 @@ func (m launcherModel) renderRepoList(width int) string {
 
 		var age string
 		if !repo.LastModified.IsZero() {
-			age = "  " + ageStyle.Render(formatAge(repo.LastModified))
+			age = "  " + ageStyle.Render(formatAge(repo.LastModified, m.clock.Now()))
 		}
 
 		// Count worktrees for this project
@@ -542,7 +836,7 @@ func (m launcherModel) renderDirectoryList(width int) string {
 
 		var age string
 		if !dir.LastModified.IsZero() {
-			age = "  " + ageStyle.Render(formatAge(dir.LastModified))
+			age = "  " + ageStyle.Render(formatAge(dir.LastModified, m.clock.Now()))
 		}
 
 		line := fmt.Sprintf("%s%s  %s%s%s",
@@ -594,6 +888,12 @@ func (m launcherModel) renderModuleList(width int) string {
 		} else if proj.PackageManager != "" {
 			badges = append(badges, "["+proj.PackageManager+"]")
 		}
+		if proj.Framework != "" && !proj.IsEncore {
+			badges = append(badges, "["+proj.Framework+"]")
+		}
+		for _, pin := range proj.ToolVersions {
+			badges = append(badges, "["+pin.String()+"]")
+		}
 		suffix := ""
 		if len(badges) > 0 {
 			suffix = " " + dimStyle.Render(strings.Join(badges, " "))
@@ -616,12 +916,16 @@ func (m launcherModel) renderScriptList(width int) string {
 	projName := m.projects[m.projIndex].Name
 	pm := m.projects[m.projIndex].PackageManager
 
+	projLine := dimStyle.Render("Project:   ") + selectedItemStyle.Render(projName)
+	if len(m.scripts) > 0 {
+		projLine += " " + dimStyle.Render("["+pm+"]")
+	}
 	header := lipgloss.JoinVertical(lipgloss.Left,
 		dimStyle.Render("Directory: ")+selectedItemStyle.Render(dir.Name),
-		dimStyle.Render("Project:   ")+selectedItemStyle.Render(projName)+" "+dimStyle.Render("["+pm+"]"),
+		projLine,
 	)
 
-	if len(m.scripts) == 0 {
+	if m.scriptCount() == 0 {
 		return lipgloss.JoinVertical(lipgloss.Left,
 			header,
 			"",
@@ -630,14 +934,20 @@ func (m launcherModel) renderScriptList(width int) string {
 	}
 
 	var lines []string
-	for i, script := range m.scripts {
+	for i := 0; i < m.scriptCount(); i++ {
 		prefix := "  "
 		style := normalItemStyle
 		if i == m.scriptIndex {
 			prefix = "> "
 			style = selectedItemStyle
 		}
-		line := fmt.Sprintf("%s%s", prefix, style.Render(script))
+		var line string
+		if i < len(m.commands) {
+			c := m.commands[i]
+			line = fmt.Sprintf("%s%s  %s", prefix, style.Render(c.Name), dimStyle.Render("$ "+formatCommandLine(c.Command, c.Args)))
+		} else {
+			line = fmt.Sprintf("%s%s", prefix, style.Render(m.scripts[i-len(m.commands)]))
+		}
 		lines = append(lines, line)
 	}
 
@@ -683,11 +993,9 @@ func (m launcherModel) renderConfirm(width int) string {
 	proj := m.projects[m.projIndex]
 	port := m.portInput.Value()
 
-	sessionName := config.SessionName(wt.Name, proj.Name)
-
-	script := ""
-	if m.scriptIndex < len(m.scripts) {
-		script = m.scripts[m.scriptIndex]
+	sessionName := m.sessionName
+	if sessionName == "" {
+		sessionName = config.SessionName(wt.Name, proj.Name)
 	}
 
 	var summaryLines []string
@@ -695,27 +1003,34 @@ func (m launcherModel) renderConfirm(width int) string {
 		dimStyle.Render("Directory: ")+selectedItemStyle.Render(wt.Name),
 		dimStyle.Render("Project:   ")+selectedItemStyle.Render(proj.Name),
 	)
-	if script != "" {
-		pm := proj.PackageManager
-		if pm == "" {
-			pm = "npm"
+	if req, ok := m.launchRequest(); ok {
+		command := statusError.Render("can't resolve")
+		if info, err := buildSessionInfo(m.runner, req); err == nil {
+			command = selectedItemStyle.Render(formatCommandLine(filepath.Base(info.Command), info.Args))
+		} else {
+			command += " " + dimStyle.Render(err.Error())
 		}
 		summaryLines = append(summaryLines,
-			dimStyle.Render("Command:  ")+selectedItemStyle.Render(pm+" run "+script),
+			dimStyle.Render("Command:  ")+command,
 		)
 	}
 	portDisplay := portStyle.Render(":" + port)
 	if m.portFixed {
 		portDisplay += " " + dimStyle.Render("(hardcoded)")
 	}
+	env := dimStyle.Render("inherited")
+	if req, ok := m.launchRequest(); ok && req.CleanEnv {
+		env = selectedItemStyle.Render("clean") + " " + dimStyle.Render("(PATH, HOME, locale, ...)")
+	}
 	summaryLines = append(summaryLines,
 		dimStyle.Render("Port:     ")+portDisplay,
+		dimStyle.Render("Env:      ")+env,
 		dimStyle.Render("Session:  ")+selectedItemStyle.Render(sessionName),
 	)
 
 	summary := lipgloss.JoinVertical(lipgloss.Left, summaryLines...)
 
-	hint := helpKeyStyle.Render("Press Enter to launch")
+	hint := helpKeyStyle.Render("Press Enter to launch") + dimStyle.Render("  (d: dry run, e: clean/inherited env)")
 
 	return lipgloss.JoinVertical(lipgloss.Left,
 		summary,
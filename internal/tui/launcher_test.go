@@ -1,11 +1,15 @@
 package tui
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/kimaguri/simplx-toolkit/internal/config"
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
 	"github.com/kimaguri/simplx-toolkit/internal/discovery"
 )
 
@@ -133,6 +137,63 @@ func TestLauncher_MoveSelection_StepRepo(t *testing.T) {
 	}
 }
 
+func TestLauncher_RefreshWorktrees(t *testing.T) {
+	m := launcherModel{
+		step:      stepRepo,
+		mainRepos: []discovery.Worktree{{Name: "a", Path: "/a"}, {Name: "b", Path: "/b"}},
+		repoIndex: 1,
+	}
+
+	// A new repo listed first keeps b selected
+	fresh := launcherModel{mainRepos: []discovery.Worktree{{Name: "new", Path: "/new"}, {Name: "a", Path: "/a"}, {Name: "b", Path: "/b"}}}
+	m = m.refreshWorktrees(fresh)
+	if len(m.mainRepos) != 3 || m.repoIndex != 2 {
+		t.Errorf("got %d repos with index %d, want 3 with b (2) selected", len(m.mainRepos), m.repoIndex)
+	}
+
+	// The directory step goes back to the repo list when its repo is removed
+	m.step = stepDirectory
+	m.directories = []discovery.Worktree{{Name: "b", Path: "/b"}}
+	m = m.refreshWorktrees(launcherModel{mainRepos: []discovery.Worktree{{Name: "a", Path: "/a"}}})
+	if m.step != stepRepo || m.repoIndex != 0 {
+		t.Errorf("step = %d, repoIndex = %d; want the repo step at 0", m.step, m.repoIndex)
+	}
+
+	// Later steps are left alone
+	m.step = stepScript
+	if got := m.refreshWorktrees(fresh); len(got.mainRepos) != 1 {
+		t.Errorf("script step took the rescan's repos")
+	}
+}
+
+func TestLauncher_RemembersLastProject(t *testing.T) {
+	m := launcherModel{
+		step:        stepDirectory,
+		directories: []discovery.Worktree{{Name: "mono"}},
+		dirProjects: [][]discovery.Project{{{Name: "admin"}, {Name: "web"}, {Name: "api", IsEncore: true}}},
+		portInput:   newLauncherModel(nil, &config.LocalConfig{}).portInput,
+		cfg:         &config.LocalConfig{LastProjects: map[string]string{"mono": "api"}},
+	}
+
+	m, _ = m.advance()
+	if m.step != stepModule || m.projIndex != 2 {
+		t.Fatalf("step = %d, projIndex = %d; want the module step on api (2)", m.step, m.projIndex)
+	}
+
+	// The remembered Encore project still skips the script step
+	m, _ = m.advance()
+	if m.step != stepPort {
+		t.Errorf("step = %d, want the port step for an Encore project without scripts", m.step)
+	}
+
+	// A project no longer listed falls back to the first
+	m.cfg.SetLastProject("mono", "gone")
+	m.step = stepDirectory
+	if m, _ = m.advance(); m.projIndex != 0 {
+		t.Errorf("projIndex = %d, want 0", m.projIndex)
+	}
+}
+
 func TestLauncher_MoveSelection_StepDirectory(t *testing.T) {
 	m := launcherModel{
 		step: stepDirectory,
@@ -454,3 +515,76 @@ func TestWorktreeLocationHint_Sidecar(t *testing.T) {
 		t.Errorf("expected '../' hint for sidecar worktree, got: %q", hint)
 	}
 }
+
+func TestLauncher_PrefillDuplicate(t *testing.T) {
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, "package.json"), []byte(`{"name":"web","scripts":{"dev":"vite","preview":"vite preview"}}`), 0644)
+	os.WriteFile(filepath.Join(root, "pnpm-lock.yaml"), nil, 0644)
+	wts := []discovery.Worktree{{Name: "web", Path: root}}
+	projName := discovery.DetectProjects(wts[0])[0].Name
+
+	m := newLauncherModel(wts, &config.LocalConfig{PortOverrides: map[string]int{}})
+	info := devdash.SessionInfo{Name: "dev-web-web", WtPath: root, Project: projName, Script: "preview", Port: 4000}
+	m, ok := m.prefillDuplicate(info, "dev-web-web-2", 4001)
+	if !ok {
+		t.Fatal("prefillDuplicate failed")
+	}
+	if m.step != stepConfirm {
+		t.Errorf("expected confirm step, got %d", m.step)
+	}
+
+	req, ok := m.launchRequest()
+	if !ok {
+		t.Fatal("launchRequest failed")
+	}
+	if req.Script != "preview" || req.Port != 4001 || req.SessionName != "dev-web-web-2" {
+		t.Errorf("got script %q port %d name %q", req.Script, req.Port, req.SessionName)
+	}
+	if got, _ := buildSessionInfo(devdash.SystemRunner, req); got.Name != "dev-web-web-2" {
+		t.Errorf("session name = %q", got.Name)
+	}
+
+	// Esc returns to the port step so the port can be adjusted
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.step != stepPort {
+		t.Errorf("esc from confirm: expected port step, got %d", m.step)
+	}
+
+	info.WtPath = filepath.Join(root, "gone")
+	if _, ok := newLauncherModel(wts, &config.LocalConfig{}).prefillDuplicate(info, "x", 1); ok {
+		t.Error("expected failure for a missing directory")
+	}
+}
+
+func TestLauncher_CustomCommands(t *testing.T) {
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, "package.json"), []byte(`{"name":"web","scripts":{"dev":"vite"}}`), 0644)
+	os.WriteFile(filepath.Join(root, config.ProjectConfigFile), []byte(`{"commands":[{"name":"make dev","command":"make","args":["dev"]}]}`), 0644)
+	wts := []discovery.Worktree{{Name: "web", Path: root}}
+
+	m := newLauncherModel(wts, &config.LocalConfig{PortOverrides: map[string]int{}})
+	m, _ = m.advance() // repo
+	m, _ = m.advance() // module
+	if m.step != stepScript || m.scriptCount() != 2 {
+		t.Fatalf("expected script step with 2 entries, got step %d count %d", m.step, m.scriptCount())
+	}
+
+	req, _ := m.launchRequest()
+	if req.Custom == nil || req.Script != "make dev" {
+		t.Fatalf("first entry should be the custom command, got %+v", req)
+	}
+	m.moveSelection(1)
+	if req, _ := m.launchRequest(); req.Custom != nil || req.Script != "dev" {
+		t.Errorf("second entry should be the dev script, got %+v", req)
+	}
+
+	// Duplicating a custom-command session selects the same entry
+	info := devdash.SessionInfo{WtPath: root, Project: m.projects[0].Name, Script: "make dev", Custom: true}
+	m, ok := newLauncherModel(wts, &config.LocalConfig{}).prefillDuplicate(info, "copy", 4001)
+	if !ok {
+		t.Fatal("prefillDuplicate failed")
+	}
+	if req, _ := m.launchRequest(); req.Custom == nil || req.Custom.Command != "make" {
+		t.Errorf("duplicate lost the custom command: %+v", req)
+	}
+}
@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+)
+
+// launchErrorClosedMsg closes the launch error overlay
+type launchErrorClosedMsg struct{}
+
+// launchCommandCopiedMsg reports that the failed command was copied
+type launchCommandCopiedMsg struct{}
+
+// launchErrorModel reports a session that failed to start at all — it never
+// got a PID, so unlike a crash there is no row or log to look at. When the
+// command was resolved it is offered for copying, to retry it by hand.
+type launchErrorModel struct {
+	name    string
+	err     string
+	command string // shell line that was attempted, "" if unknown
+	copied  bool
+	runner  devdash.Runner // runs the clipboard helpers
+	width   int
+	height  int
+}
+
+// newLaunchErrorModel creates the overlay for a failed start of name
+func newLaunchErrorModel(name, err, command string, r devdash.Runner) launchErrorModel {
+	return launchErrorModel{name: name, err: err, command: command, runner: r}
+}
+
+// Update copies the command on c and closes the overlay on enter/esc/q
+func (m launchErrorModel) Update(msg tea.KeyMsg) (launchErrorModel, tea.Cmd) {
+	switch msg.String() {
+	case "c":
+		if m.command == "" {
+			return m, nil
+		}
+		r, command := m.runner, m.command
+		return m, func() tea.Msg {
+			if err := copyToClipboard(r, command); err != nil {
+				return ClipboardFeedbackMsg{Message: fmt.Sprintf("[Copy error: %v]", err)}
+			}
+			return launchCommandCopiedMsg{}
+		}
+	case "enter", "esc", "q":
+		return m, func() tea.Msg { return launchErrorClosedMsg{} }
+	}
+	return m, nil
+}
+
+// View renders the overlay
+func (m launchErrorModel) View() string {
+	maxWidth := m.width - 4
+	if maxWidth < 30 {
+		maxWidth = 30
+	}
+	if maxWidth > 64 {
+		maxWidth = 64
+	}
+
+	wrap := lipgloss.NewStyle().Width(maxWidth - 4)
+	parts := []string{
+		statusError.Render("Failed to start " + m.name),
+		"",
+		wrap.Render(m.err),
+		"",
+		dimStyle.Render(wrap.Render("The process never started, so there is no log. Check the command, the working directory, and that the binary is installed.")),
+	}
+	hint := "enter/esc:close"
+	if m.command != "" {
+		parts = append(parts, "", dimStyle.Render("To run it yourself:"), selectedItemStyle.Render(wrap.Render("$ "+m.command)))
+		if m.copied {
+			parts = append(parts, helpKeyStyle.Render("[Command copied]"))
+		}
+		hint = "c:copy command  " + hint
+	}
+	parts = append(parts, "", dimStyle.Render(hint))
+	content := lipgloss.JoinVertical(lipgloss.Left, parts...)
+
+	popup := modalStyle.Width(maxWidth).Render(content)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, popup)
+}
+
+// SetSize updates dimensions for centering
+func (m *launchErrorModel) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+}
@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+)
+
+// localURLScanLines is how much of the log tail is searched for the address
+// the server actually bound
+const localURLScanLines = 500
+
+// listenAddrPattern matches local addresses servers print on startup, e.g.
+// "Local: http://localhost:5174/" or "listening on 0.0.0.0:4000"
+var listenAddrPattern = regexp.MustCompile(`(?:localhost|127\.0\.0\.1|0\.0\.0\.0|\[::1?\]):(\d{2,5})\b`)
+
+// runtimePort returns the port a server reports in its log: requested if it
+// shows up at all, otherwise the most recent one printed (Vite and friends move
+// to the next port when theirs is taken). 0 if the log names none.
+func runtimePort(lines []string, requested int) int {
+	latest := 0
+	for i := len(lines) - 1; i >= 0; i-- {
+		matches := listenAddrPattern.FindAllStringSubmatch(ansi.Strip(lines[i]), -1)
+		for j := len(matches) - 1; j >= 0; j-- {
+			port, err := strconv.Atoi(matches[j][1])
+			if err != nil || port <= 0 || port > 65535 {
+				continue
+			}
+			if port == requested {
+				return requested
+			}
+			if latest == 0 {
+				latest = port
+			}
+		}
+	}
+	return latest
+}
+
+// localPort returns the port to reach a session on: the runtime one from its
+// log when that differs from the launch port. 0 if neither is known.
+func localPort(rp *devdash.RunningProcess) int {
+	if rp.LogBuf != nil {
+		if port := runtimePort(rp.LogBuf.Tail(localURLScanLines), rp.Info.Port); port > 0 {
+			return port
+		}
+	}
+	return rp.Info.Port
+}
+
+// copyLocalURL copies http://localhost:<port> for a session to the clipboard
+func copyLocalURL(r devdash.Runner, rp *devdash.RunningProcess) tea.Cmd {
+	port := localPort(rp)
+	if port == 0 {
+		return feedbackCmd("[No port known for this session]")
+	}
+	url := fmt.Sprintf("http://localhost:%d", port)
+	if rp.Info.Port != 0 && port != rp.Info.Port {
+		return copyCmd(r, url, fmt.Sprintf("[Copied %s (runtime port, launched with %d)]", url, rp.Info.Port))
+	}
+	return copyCmd(r, url, "[Copied "+url+"]")
+}
+
+// curlCommand returns a curl command for a session to edit and run: a GET of
+// the root of its tunnel URL while a tunnel is up, else of its local URL.
+// false if neither is known.
+func curlCommand(rp *devdash.RunningProcess) (string, bool) {
+	base := ""
+	if rp.Tunnel != nil && rp.Tunnel.URL != "" {
+		base = rp.Tunnel.URL
+	} else if port := localPort(rp); port > 0 {
+		base = fmt.Sprintf("http://localhost:%d", port)
+	}
+	if base == "" {
+		return "", false
+	}
+	return fmt.Sprintf("curl -i -X GET '%s/'", strings.TrimSuffix(base, "/")), true
+}
+
+// copyCurlCommand copies a session's curl command to the clipboard
+func copyCurlCommand(r devdash.Runner, rp *devdash.RunningProcess) tea.Cmd {
+	cmd, ok := curlCommand(rp)
+	if !ok {
+		return feedbackCmd("[No port or tunnel known for this session]")
+	}
+	return copyCmd(r, cmd, "[Copied "+cmd+"]")
+}
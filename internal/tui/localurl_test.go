@@ -0,0 +1,43 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+)
+
+func TestRuntimePort(t *testing.T) {
+	tests := []struct {
+		name      string
+		lines     []string
+		requested int
+		want      int
+	}{
+		{"no address", []string{"compiling..."}, 3000, 0},
+		{"requested port confirmed", []string{"ready on http://localhost:3000", "proxy to localhost:4000"}, 3000, 3000},
+		{"vite moved on", []string{"Port 5173 is in use, trying another one...", "\x1b[32m➜\x1b[0m  Local:   http://localhost:\x1b[1m5174\x1b[0m/"}, 5173, 5174},
+		{"latest wins", []string{"listening on 0.0.0.0:8080", "listening on 127.0.0.1:8081"}, 3000, 8081},
+		{"ipv6 loopback", []string{"bound to [::1]:9000"}, 0, 9000},
+	}
+	for _, tt := range tests {
+		if got := runtimePort(tt.lines, tt.requested); got != tt.want {
+			t.Errorf("%s: runtimePort = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCurlCommand(t *testing.T) {
+	rp := &devdash.RunningProcess{Info: devdash.SessionInfo{Name: "api", Port: 4000}}
+	if got, _ := curlCommand(rp); got != "curl -i -X GET 'http://localhost:4000/'" {
+		t.Errorf("local: got %q", got)
+	}
+
+	rp.Tunnel = &devdash.TunnelInfo{Status: devdash.TunnelActive, URL: "https://x.trycloudflare.com"}
+	if got, _ := curlCommand(rp); got != "curl -i -X GET 'https://x.trycloudflare.com/'" {
+		t.Errorf("tunnel: got %q", got)
+	}
+
+	if _, ok := curlCommand(&devdash.RunningProcess{}); ok {
+		t.Error("expected no command without a port or tunnel")
+	}
+}
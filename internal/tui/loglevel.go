@@ -0,0 +1,125 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// logLevel is the severity a log line looks like it has
+type logLevel int
+
+const (
+	levelNone logLevel = iota
+	levelWarn
+	levelError
+)
+
+// Level patterns, matched against ANSI-stripped lines. They cover plain words
+// ("Error:", "FATAL") as well as npm's "ERR!" and Go's "panic:".
+var (
+	errorLinePattern = regexp.MustCompile(`(?i)\b(error|errors|fatal|panic|exception|failed|failure|traceback|uncaught)\b|ERR!`)
+	warnLinePattern  = regexp.MustCompile(`(?i)\b(warn|warning|warnings|deprecated|deprecation)\b|WARN!`)
+)
+
+// classifyLine returns the level a log line looks like it has
+func classifyLine(line string) logLevel {
+	plain := ansi.Strip(line)
+	switch {
+	case errorLinePattern.MatchString(plain):
+		return levelError
+	case warnLinePattern.MatchString(plain):
+		return levelWarn
+	}
+	return levelNone
+}
+
+// levelJump is the outcome of jumpToLevel
+type levelJump struct {
+	line  int // index into the lines jumped within
+	row   int // first visual row of that line
+	nth   int // 1-based position among the error/warning lines
+	total int // error/warning lines in all
+}
+
+// jumpToLevel finds the next (step 1) or previous (step -1) error or warning
+// line. rows[i] is the first visual row of lines[i]. The walk continues from
+// last, the line of the previous jump, while it is still on screen between
+// rows top and top+height; otherwise lines on screen count too, so the first
+// press can land on one already visible.
+func jumpToLevel(lines []string, rows []int, top, height, last, step int) (levelJump, bool) {
+	first, end := len(rows), len(rows) // first line on screen, first line below it
+	for i, r := range rows {
+		if r >= top && first == len(rows) {
+			first = i
+		}
+		if r >= top+height {
+			end = i
+			break
+		}
+	}
+	from := first - 1
+	if step < 0 {
+		from = end
+	}
+	if last >= 0 && last < len(rows) && rows[last] >= top && rows[last] < top+height {
+		from = last
+	}
+
+	var hits []int
+	for i, line := range lines {
+		if classifyLine(line) != levelNone {
+			hits = append(hits, i)
+		}
+	}
+
+	if step > 0 {
+		for n, i := range hits {
+			if i > from {
+				return levelJump{line: i, row: rows[i], nth: n + 1, total: len(hits)}, true
+			}
+		}
+	} else {
+		for n := len(hits) - 1; n >= 0; n-- {
+			if hits[n] < from {
+				return levelJump{line: hits[n], row: rows[hits[n]], nth: n + 1, total: len(hits)}, true
+			}
+		}
+	}
+	return levelJump{total: len(hits)}, false
+}
+
+// lineRows returns the first visual row of each line, with render producing
+// the viewport content of a run of lines
+func lineRows(lines []string, render func([]string) string) []int {
+	rows := make([]int, len(lines))
+	row := 0
+	for i := range lines {
+		rows[i] = row
+		row += strings.Count(render(lines[i:i+1]), "\n") + 1
+	}
+	return rows
+}
+
+// levelJumpCmd scrolls vp to the next or previous error/warning line and
+// reports it. last is updated to the line jumped to; moved is false if there
+// was none to jump to.
+func levelJumpCmd(vp *viewport.Model, lines []string, render func([]string) string, last *int, step int) (cmd tea.Cmd, moved bool) {
+	jump, ok := jumpToLevel(lines, lineRows(lines, render), vp.YOffset, vp.Height, *last, step)
+	switch {
+	case ok:
+		*last = jump.line
+		vp.SetYOffset(jump.row)
+		return feedbackCmd(fmt.Sprintf("[Error/warning %d of %d]", jump.nth, jump.total)), true
+	case jump.total == 0:
+		return feedbackCmd("[No errors or warnings in this log]"), false
+	case step > 0:
+		return feedbackCmd("[No more errors or warnings below]"), false
+	default:
+		return feedbackCmd("[No errors or warnings above]"), false
+	}
+}
@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/viewport"
+)
+
+func TestClassifyLine(t *testing.T) {
+	cases := map[string]logLevel{
+		"\x1b[31mError:\x1b[0m Cannot find module 'x'": levelError,
+		"npm ERR! code ELIFECYCLE":                     levelError,
+		"panic: runtime error: index out of range":     levelError,
+		"(!) Warning: chunk size limit":                levelWarn,
+		"DeprecationWarning: Buffer() is deprecated":   levelWarn,
+		"GET /api/terrors 200":                         levelNone,
+		"ready in 320 ms":                              levelNone,
+	}
+	for line, want := range cases {
+		if got := classifyLine(line); got != want {
+			t.Errorf("classifyLine(%q) = %d, want %d", line, got, want)
+		}
+	}
+}
+
+func TestLevelJumpWalksErrors(t *testing.T) {
+	var lines []string
+	for i := 0; i < 30; i++ {
+		switch i {
+		case 5, 12:
+			lines = append(lines, "Error: boom")
+		case 20:
+			lines = append(lines, "warning: careful")
+		default:
+			lines = append(lines, "ok")
+		}
+	}
+	render := func(ls []string) string { return strings.Join(ls, "\n") }
+	vp := viewport.New(40, 5)
+	vp.SetContent(render(lines))
+	last := -1
+
+	var got []int
+	for i := 0; i < 4; i++ {
+		if _, moved := levelJumpCmd(&vp, lines, render, &last, 1); moved {
+			got = append(got, last)
+		}
+	}
+	if want := []int{5, 12, 20}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("forward jumps = %v, want %v", got, want)
+	}
+	if vp.YOffset != 20 {
+		t.Errorf("viewport should show line 20 at the top, YOffset = %d", vp.YOffset)
+	}
+
+	if _, moved := levelJumpCmd(&vp, lines, render, &last, -1); !moved || last != 12 {
+		t.Errorf("backward jump went to %d, want 12", last)
+	}
+}
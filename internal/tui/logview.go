@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/viewport"
@@ -34,7 +35,18 @@ type logViewModel struct {
 	clipboardMsg  string
 	search        searchModel
 	selection     selectionModel
-	isInteractive bool // interactive mode active (keys → PTY)
+	isInteractive bool           // interactive mode active (keys → PTY)
+	lineNumbers   bool           // show the line-number gutter
+	wrapIndent    bool           // indent the continuation rows of wrapped lines
+	levelLine     int            // log line of the last error jump (-1 = none), see jumpToLevel
+	standalone    bool           // opened by `devdash logs`: q quits, esc reveals the dashboard
+	scrollbar     bool           // show the scrollbar column at the right edge
+	paused        bool           // terminal unfocused: new lines are buffered, not rendered
+	timeFmt       timeFormat     // how the last restart time is shown
+	levels        []logLevel     // level of each line shown, for the scrollbar ticks
+	prettyJSON    bool           // show JSON log lines compactly, see prettyJSONLine
+	clock         devdash.Clock  // the time the last restart is measured at
+	runner        devdash.Runner // runs the clipboard helpers
 }
 
 // newLogViewModel creates a new fullscreen log viewer
@@ -46,6 +58,9 @@ func newLogViewModel(rp *devdash.RunningProcess) logViewModel {
 		logBuf:      rp.LogBuf,
 		autoScroll:  true,
 		search:      newSearchModel(),
+		levelLine:   -1,
+		clock:       devdash.SystemClock,
+		runner:      devdash.SystemRunner,
 	}
 }
 
@@ -95,6 +110,12 @@ func (m logViewModel) Update(msg tea.Msg) (logViewModel, tea.Cmd) {
 		m.clipboardMsg = ""
 		return m, nil
 
+	case searchDebounceMsg:
+		if m.search.settle(msg) {
+			m.applySearchFilter()
+		}
+		return m, nil
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -105,15 +126,11 @@ func (m logViewModel) Update(msg tea.Msg) (logViewModel, tea.Cmd) {
 			vpHeight = 1
 		}
 		if !m.ready {
-			m.viewport = viewport.New(m.width, vpHeight)
-			content := ansi.Wordwrap(m.logBuf.Content(), m.width, "")
-			m.viewport.SetContent(content)
-			if m.autoScroll {
-				m.viewport.GotoBottom()
-			}
+			m.viewport = viewport.New(m.viewportWidth(), vpHeight)
 			m.ready = true
+			m.refreshLogViewport()
 		} else {
-			m.viewport.Width = m.width
+			m.viewport.Width = m.viewportWidth()
 			m.viewport.Height = vpHeight
 		}
 		return m, nil
@@ -122,25 +139,15 @@ func (m logViewModel) Update(msg tea.Msg) (logViewModel, tea.Cmd) {
 		if msg.SessionName != m.sessionName {
 			return m, nil
 		}
-		// Freeze viewport during visual selection — buffer new lines but don't shift content
-		if m.selection.isActive() {
+		// Freeze viewport during visual selection — buffer new lines but don't shift content.
+		// Rendering is skipped the same way while the terminal is unfocused.
+		if m.selection.isActive() || m.paused {
 			if m.subCh != nil {
 				cmds = append(cmds, waitForLogLine(m.sessionName, m.subCh))
 			}
 			return m, tea.Batch(cmds...)
 		}
-		if m.isInteractive {
-			m.refreshInteractiveViewport()
-		} else if m.search.isActive() && m.search.query != "" {
-			m.applySearchFilter()
-		} else {
-			// Update viewport with full content from buffer (word-wrapped)
-			content := ansi.Wordwrap(m.logBuf.Content(), m.viewport.Width, "")
-			m.viewport.SetContent(content)
-			if m.autoScroll {
-				m.viewport.GotoBottom()
-			}
-		}
+		m.showNewLines()
 		// Re-subscribe for the next line
 		if m.subCh != nil {
 			cmds = append(cmds, waitForLogLine(m.sessionName, m.subCh))
@@ -177,6 +184,11 @@ func (m logViewModel) Update(msg tea.Msg) (logViewModel, tea.Cmd) {
 			case "/":
 				cmd := m.search.activate()
 				return m, cmd
+			case "alt+c", "alt+m":
+				m.search.toggleOption(msg.String())
+				m.applySearchFilter()
+				m.search.clampMatch()
+				return m, nil
 			}
 		}
 
@@ -191,12 +203,63 @@ func (m logViewModel) Update(msg tea.Msg) (logViewModel, tea.Cmd) {
 			return m, nil
 		case "c":
 			if m.ready {
-				return m, copyVisibleLines(m.viewport.View())
+				flushLog(m.logBuf)
+				return m, copyVisibleLines(m.runner, m.visibleText())
+			}
+			return m, nil
+		case "C":
+			if m.ready {
+				flushLog(m.logBuf)
+				return m, copyVisibleMarkdown(m.runner, m.visibleText())
+			}
+			return m, nil
+		case "#":
+			m.lineNumbers = !m.lineNumbers
+			if m.search.isActive() && m.search.query != "" {
+				m.applySearchFilter()
+			} else {
+				m.refreshLogViewport()
+			}
+			return m, nil
+		case "J":
+			m.prettyJSON = !m.prettyJSON
+			if m.search.isActive() && m.search.query != "" {
+				m.applySearchFilter()
+			} else {
+				m.refreshLogViewport()
+			}
+			if m.prettyJSON {
+				return m, feedbackCmd("[JSON lines shown compactly]")
+			}
+			return m, feedbackCmd("[JSON lines shown raw]")
+		case "|":
+			m.scrollbar = !m.scrollbar
+			m.viewport.Width = m.viewportWidth()
+			if m.search.isActive() && m.search.query != "" {
+				m.applySearchFilter()
+			} else {
+				m.refreshLogViewport()
+			}
+			return m, nil
+		case "m":
+			if m.logBuf != nil {
+				toggleMark(m.logBuf)
+				m.autoScroll = true
+				if m.search.isActive() && m.search.query != "" {
+					m.applySearchFilter()
+				} else {
+					m.refreshLogViewport()
+				}
 			}
 			return m, nil
 		case "y":
+			flushLog(m.logBuf)
+			// With a search filter active, copy what's shown: the matching lines
+			if m.logBuf != nil && m.search.isActive() && m.search.query != "" {
+				return m, copyFilteredLines(m.runner, m.logBuf.LinesSinceMark(), m.search.matcher())
+			}
 			if m.logBuf != nil {
-				return m, copyAllLines(m.logBuf.Content())
+				return m, copyAllLines(m.runner, m.logBuf.ContentSinceMark())
 			}
 			return m, nil
 		case "/":
@@ -205,8 +268,7 @@ func (m logViewModel) Update(msg tea.Msg) (logViewModel, tea.Cmd) {
 		case "v":
 			if m.logBuf != nil {
 				m.search.deactivate()
-				content := ansi.Wordwrap(m.logBuf.Content(), m.viewport.Width, "")
-				m.selection.activate(m.viewport, content)
+				m.selection.activate(m.viewport, m.logBuf.ContentSinceMark(), m.wrapLog, m.wrapPad)
 				m.selection.applyToViewport(&m.viewport)
 			}
 			return m, nil
@@ -221,10 +283,12 @@ func (m logViewModel) Update(msg tea.Msg) (logViewModel, tea.Cmd) {
 
 		// Pass key to viewport for scrolling
 		prevOffset := m.viewport.YOffset
-		var cmd tea.Cmd
-		m.viewport, cmd = m.viewport.Update(msg)
-		if cmd != nil {
-			cmds = append(cmds, cmd)
+		if !scrollPage(&m.viewport, msg.String()) {
+			var cmd tea.Cmd
+			m.viewport, cmd = m.viewport.Update(msg)
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
 		}
 
 		// If user scrolled up, disable auto-scroll
@@ -254,11 +318,28 @@ func (m logViewModel) updateSearchInput(msg tea.KeyMsg) (logViewModel, tea.Cmd)
 		m.refreshLogViewport()
 		return m, nil
 	case "enter":
+		if m.search.pending {
+			m.search.pending = false
+			m.applySearchFilter()
+		}
 		m.search.enterNavigateMode()
 		return m, nil
+	case "alt+c", "alt+m":
+		m.search.toggleOption(msg.String())
+		m.search.pending = false
+		m.applySearchFilter()
+		return m, nil
 	}
 
+	prevQuery := m.search.query
 	cmd := m.search.update(msg)
+	if m.search.query == prevQuery {
+		return m, cmd
+	}
+	// Large buffers wait for a pause in typing before the rescan
+	if m.logBuf != nil && needsDebounce(m.logBuf.Len()) {
+		return m, tea.Batch(cmd, m.search.debounce())
+	}
 	m.applySearchFilter()
 	return m, cmd
 }
@@ -271,11 +352,16 @@ func (m logViewModel) handleSelectionKey(msg tea.KeyMsg) (logViewModel, tea.Cmd)
 		m.selection.applyToViewport(&m.viewport)
 		return m, nil
 	case selActionCopy:
+		flushLog(m.logBuf)
 		text := m.selection.selectedText()
 		count := m.selection.selectedLineCount()
 		m.selection.deactivate()
 		m.refreshLogViewport()
-		return m, copySelectedLines(text, count)
+		return m, copySelectedLines(m.runner, text, count)
+	case selActionCopyStay:
+		flushLog(m.logBuf)
+		text, count := m.selection.copyStay()
+		return m, copySelectedLines(m.runner, text, count)
 	case selActionCancel:
 		m.selection.deactivate()
 		m.refreshLogViewport()
@@ -284,6 +370,18 @@ func (m logViewModel) handleSelectionKey(msg tea.KeyMsg) (logViewModel, tea.Cmd)
 	return m, nil
 }
 
+// showNewLines re-renders the viewport with the lines that arrived: the live
+// screen in interactive mode, else the log, filtered while searching
+func (m *logViewModel) showNewLines() {
+	if m.isInteractive {
+		m.refreshInteractiveViewport()
+	} else if m.search.isActive() && m.search.query != "" {
+		m.applySearchFilter()
+	} else {
+		m.refreshLogViewport()
+	}
+}
+
 // applySearchFilter filters log content by the current search query
 func (m *logViewModel) applySearchFilter() {
 	if m.logBuf == nil || !m.ready {
@@ -295,13 +393,28 @@ func (m *logViewModel) applySearchFilter() {
 		return
 	}
 
-	lines := m.logBuf.Lines()
-	filtered, matchCount := filterAndHighlight(lines, m.search.query)
+	lines := m.logBuf.LinesSinceMark()
+	if m.prettyJSON {
+		lines = prettyJSONLines(lines)
+	}
+	lm := m.search.matcher()
+	filtered, matchCount := filterAndHighlight(lines, lm)
 	m.search.matchCount = matchCount
+	if m.scrollbar {
+		m.levels = lineLevels(filtered)
+	}
 
-	content := strings.Join(filtered, "\n")
-	wrapped := ansi.Wordwrap(content, m.viewport.Width, "")
-	m.viewport.SetContent(wrapped)
+	if m.lineNumbers {
+		start := m.logBuf.MarkStart()
+		numbers := matchingLines(lines, lm)
+		for i := range numbers {
+			numbers[i] += start + 1
+		}
+		m.setContent(numberLines(filtered, numbers, m.gutterDigits(), m.viewport.Width, m.wrapIndent))
+	} else {
+		content := strings.Join(filtered, "\n")
+		m.setContent(m.wrapLog(content))
+	}
 	m.viewport.GotoBottom()
 }
 
@@ -310,24 +423,157 @@ func (m *logViewModel) refreshLogViewport() {
 	if m.logBuf == nil || !m.ready {
 		return
 	}
-	content := ansi.Wordwrap(m.logBuf.Content(), m.viewport.Width, "")
-	m.viewport.SetContent(content)
+	lines := m.logBuf.LinesSinceMark()
+	m.setContent(m.renderLines(lines, m.logBuf.MarkStart()))
+	if m.scrollbar {
+		if m.prettyJSON {
+			lines = prettyJSONLines(lines)
+		}
+		m.levels = lineLevels(lines)
+	}
 	if m.autoScroll {
 		m.viewport.GotoBottom()
 	}
 }
 
+// setContent shows rendered log content, with rows too wide for the viewport
+// (a long URL, nothing to break it at) ending in the clip marker
+func (m *logViewModel) setContent(content string) {
+	m.viewport.SetContent(markClipped(content, m.viewport.Width))
+}
+
+// renderLines word-wraps lines for the viewport, prefixed with the line-number
+// gutter when it's on, JSON lines compacted in JSON mode. start is the buffer
+// index of lines[0].
+func (m *logViewModel) renderLines(lines []string, start int) string {
+	if m.prettyJSON {
+		lines = prettyJSONLines(lines)
+	}
+	if !m.lineNumbers {
+		return m.wrapLog(strings.Join(lines, "\n"))
+	}
+	numbers := make([]int, len(lines))
+	for i := range numbers {
+		numbers[i] = start + i + 1
+	}
+	return numberLines(lines, numbers, m.gutterDigits(), m.viewport.Width, m.wrapIndent)
+}
+
+// jumpToLevel scrolls to the next (step 1) or previous (step -1) error or
+// warning line, pausing auto-scroll when it moves
+func (m *logViewModel) jumpToLevel(step int) tea.Cmd {
+	if m.logBuf == nil || !m.ready {
+		return nil
+	}
+	lines := m.logBuf.LinesSinceMark()
+	if m.prettyJSON {
+		lines = prettyJSONLines(lines) // numeric JSON levels become words classifyLine knows
+	}
+	render := func(lines []string) string { return m.renderLines(lines, 0) }
+	cmd, moved := levelJumpCmd(&m.viewport, lines, render, &m.levelLine, step)
+	if moved {
+		m.autoScroll = false
+	}
+	return cmd
+}
+
+// wrapLog word-wraps log content to the viewport width
+func (m logViewModel) wrapLog(content string) string {
+	return hangWrap(content, m.viewport.Width, m.wrapIndent, wordwrap)
+}
+
+// wrapPad returns the indent wrapLog adds to a log line's continuation rows
+func (m logViewModel) wrapPad(line string) string {
+	return hangPad(line, m.viewport.Width, m.wrapIndent)
+}
+
+// gutterDigits is the width of the largest line number in the buffer, so the
+// gutter only widens as the buffer grows
+func (m *logViewModel) gutterDigits() int {
+	return len(strconv.Itoa(m.logBuf.Len() + 1))
+}
+
+// visibleText returns the lines currently on screen without the gutter
+func (m *logViewModel) visibleText() string {
+	view := m.viewport.View()
+	if !m.lineNumbers {
+		return view
+	}
+	lines := strings.Split(view, "\n")
+	for i, line := range lines {
+		lines[i] = ansi.TruncateLeft(line, m.gutterDigits()+1, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// numberLines word-wraps each line to fit beside a gutter of digits columns
+// and prefixes its first row with its dimmed, right-aligned number. Wrapped
+// continuation rows get a blank gutter so the number marks the logical line,
+// and a hanging indent when indent is set.
+func numberLines(lines []string, numbers []int, digits, width int, indent bool) string {
+	textWidth := width - digits - 1
+	if textWidth < 1 {
+		textWidth = 1
+	}
+	blank := strings.Repeat(" ", digits+1)
+	var b strings.Builder
+	for i, line := range lines {
+		for j, row := range strings.Split(hangWrap(line, textWidth, indent, wordwrap), "\n") {
+			if i > 0 || j > 0 {
+				b.WriteByte('\n')
+			}
+			if j == 0 {
+				b.WriteString(dimStyle.Render(fmt.Sprintf("%*d", digits, numbers[i])) + " ")
+			} else {
+				b.WriteString(blank)
+			}
+			b.WriteString(row)
+		}
+	}
+	return b.String()
+}
+
+// toggleMark sets a "show only new lines" bookmark at the end of buf, or clears an existing one
+func toggleMark(buf *process.LogBuffer) {
+	if buf.HasMark() {
+		buf.ClearMark()
+	} else {
+		buf.SetMark()
+	}
+}
+
+// jumpToLine scrolls so that buffer line idx is at the top of the viewport
+// and stops auto-scroll. Wrapped rows of earlier lines are accounted for.
+func (m *logViewModel) jumpToLine(idx int) {
+	if m.logBuf == nil || !m.ready {
+		return
+	}
+	// idx refers to the whole buffer, so drop any "since mark" view first
+	if m.logBuf.HasMark() {
+		m.logBuf.ClearMark()
+		m.refreshLogViewport()
+	}
+	lines := m.logBuf.Lines()
+	if idx <= 0 || idx >= len(lines) {
+		return
+	}
+	wrapped := m.renderLines(lines[:idx], 0)
+	m.autoScroll = false
+	m.viewport.SetYOffset(strings.Count(wrapped, "\n") + 1)
+}
+
 // refreshInteractiveViewport renders VTerm or log content into the viewport
 func (m *logViewModel) refreshInteractiveViewport() {
 	if m.rp == nil {
 		return
 	}
+	m.levels = nil
 	if m.rp.VTerm != nil {
-		content := m.rp.VTerm.Content()
+		// Keep blank rows: a live screen must not shrink as the app redraws
+		content := m.rp.VTerm.RawContent()
 		m.viewport.SetContent(content)
 	} else {
-		content := ansi.Wordwrap(m.logBuf.Content(), m.viewport.Width, "")
-		m.viewport.SetContent(content)
+		m.setContent(m.wrapLog(m.logBuf.ContentSinceMark()))
 	}
 	m.viewport.GotoBottom()
 }
@@ -340,8 +586,22 @@ func (m logViewModel) View() string {
 
 	// Title bar
 	titleText := fmt.Sprintf(" %s (:%d)", m.sessionName, m.port)
+	if m.logBuf != nil && m.logBuf.HasMark() {
+		titleText += " [since mark]"
+	}
+	if m.rp != nil {
+		if label := restartLabel(m.rp, m.timeFmt, m.clock.Now()); label != "" {
+			titleText += " · " + label
+		}
+		if m.rp.Info.NoLog {
+			titleText += " · no persisted log"
+		}
+	}
 	scrollInfo := fmt.Sprintf("scroll: %d/%d ", m.viewport.YOffset+m.viewport.Height, m.viewport.TotalLineCount())
-	helpText := " q:back  G:bottom  g:top  c:copy  y:copy all  v:select  /:search  i:interactive "
+	helpText := " q:back  G:bottom  g:top  ^d/^u:half page  ^f/^b:page  #:numbers  |:scrollbar  J:json  L:curl  c:copy  C:copy md  y:copy all  m:mark  v:select  /:search  i:interactive "
+	if m.standalone {
+		helpText = " q:quit  esc:dashboard" + strings.TrimPrefix(helpText, " q:back")
+	}
 	if m.isInteractive {
 		helpText = " INTERACTIVE  esc esc:exit "
 	}
@@ -373,7 +633,12 @@ func (m logViewModel) View() string {
 	}
 
 	// Build view parts
-	parts := []string{header, m.viewport.View()}
+	body := m.viewport.View()
+	if m.scrollbar {
+		bar := renderScrollbar(m.viewport.Height, m.viewport.TotalLineCount(), m.viewport.YOffset, m.levels)
+		body = lipgloss.JoinHorizontal(lipgloss.Top, body, bar)
+	}
+	parts := []string{header, body}
 
 	// Add selection or search bar at the bottom when active
 	if m.selection.isActive() {
@@ -398,6 +663,33 @@ func (m *logViewModel) SetSize(w, h int) {
 	if vpHeight < 1 {
 		vpHeight = 1
 	}
-	m.viewport.Width = w
+	m.viewport.Width = m.viewportWidth()
 	m.viewport.Height = vpHeight
 }
+
+// viewportWidth is the width left for log text, one column less with the
+// scrollbar on
+func (m *logViewModel) viewportWidth() int {
+	if m.scrollbar {
+		return max(m.width-1, 1)
+	}
+	return m.width
+}
+
+// scrollPage handles the vim-style paging keys: ctrl+d/ctrl+u move half a page,
+// ctrl+f/ctrl+b a full page. Returns false for any other key.
+func scrollPage(vp *viewport.Model, key string) bool {
+	switch key {
+	case "ctrl+d":
+		vp.HalfPageDown()
+	case "ctrl+u":
+		vp.HalfPageUp()
+	case "ctrl+f":
+		vp.PageDown()
+	case "ctrl+b":
+		vp.PageUp()
+	default:
+		return false
+	}
+	return true
+}
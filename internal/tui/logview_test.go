@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestNumberLines(t *testing.T) {
+	got := ansi.Strip(numberLines([]string{"short", "a much longer line here"}, []int{9, 10}, 2, 13, false))
+	want := " 9 short\n10 a much\n   longer\n   line here"
+	if got != want {
+		t.Errorf("numberLines =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestLogViewLineNumbers(t *testing.T) {
+	rp := newTestProcess("api", "one", "two", "ERROR three")
+	m := newLogViewModel(rp)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 40, Height: 10})
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("#")})
+	view := ansi.Strip(m.viewport.View())
+	if !strings.Contains(view, "1 one") || !strings.Contains(view, "3 ERROR three") {
+		t.Errorf("gutter missing:\n%s", view)
+	}
+	if lines := strings.Split(ansi.Strip(m.visibleText()), "\n"); !strings.HasPrefix(lines[0], "one") || !strings.HasPrefix(lines[2], "ERROR three") {
+		t.Errorf("copied text kept the gutter:\n%q", lines)
+	}
+
+	// Filtered lines keep their buffer numbers
+	m.search.query = "error"
+	m.applySearchFilter()
+	if view := ansi.Strip(m.viewport.View()); !strings.HasPrefix(view, "3 ERROR three") {
+		t.Errorf("filtered view:\n%s", view)
+	}
+}
+
+func TestJumpToLineBounds(t *testing.T) {
+	rp := newTestProcess("api", "one", "two", "three")
+	m := newLogViewModel(rp)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 40, Height: 10})
+
+	m.jumpToLine(len(rp.LogBuf.Lines()))
+	if !m.autoScroll {
+		t.Error("a line past the end shouldn't stop auto-scroll")
+	}
+	m.jumpToLine(1)
+	if m.autoScroll {
+		t.Error("jumping to a line should stop auto-scroll")
+	}
+}
+
+func TestLogViewPausedWhileUnfocused(t *testing.T) {
+	rp := newTestProcess("api", "one")
+	a := App{view: viewLogFull, logView: newLogViewModel(rp)}
+	a.logView, _ = a.logView.Update(tea.WindowSizeMsg{Width: 40, Height: 10})
+	cmd := a.logView.Subscribe()
+	defer a.logView.Unsubscribe()
+
+	model, _ := a.Update(tea.BlurMsg{})
+	a = model.(App)
+	rp.LogBuf.Write([]byte("two\n"))
+	model, next := a.Update(cmd())
+	a = model.(App)
+	if next == nil {
+		t.Error("the subscription wasn't renewed while paused")
+	}
+	if strings.Contains(a.logView.viewport.View(), "two") {
+		t.Error("rendered a new line while unfocused")
+	}
+
+	model, _ = a.Update(tea.FocusMsg{})
+	a = model.(App)
+	if !strings.Contains(a.logView.viewport.View(), "two") {
+		t.Errorf("focus didn't catch up:\n%s", a.logView.viewport.View())
+	}
+}
@@ -0,0 +1,307 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+)
+
+// usageSampledMsg delivers a CPU/memory sample of the running sessions, by PID
+type usageSampledMsg struct {
+	usage map[int]devdash.Usage
+}
+
+// Overview table column widths; the name column takes what the others leave,
+// and the tunnel column gets the rest of the row. The time columns depend on
+// the time format, see timeColumnWidths.
+const (
+	overviewPortW   = 6
+	overviewCPUW    = 6
+	overviewMemW    = 7
+	overviewNameMin = 12
+	overviewNameMax = 32
+)
+
+// timeColumnWidths returns the widths of the uptime and last-log columns that
+// fit the longest values of the time format (a two-digit day for timeClock)
+func timeColumnWidths(f timeFormat) (uptime, last int) {
+	switch f {
+	case timeLong:
+		return len("59 minutes"), len("59 seconds ago")
+	case timeClock:
+		return len("since Jan 02 15:04"), len("Jan 02 15:04")
+	}
+	return len("23h59m"), len("59m ago")
+}
+
+// overviewModel is the all-sessions summary screen: one dense table row per
+// session with its status and resource use, and no log panel
+type overviewModel struct {
+	processes []*devdash.RunningProcess
+	selected  int
+	sampler   *devdash.UsageSampler
+	usage     map[int]devdash.Usage // by PID, from the latest sample
+	timeFmt   timeFormat            // how uptime and the last log line are shown
+	clock     devdash.Clock         // the time uptime and samples are taken at
+	width     int
+	height    int
+}
+
+// newOverviewModel creates the overview with an empty usage history,
+// sampling through r at clock's time
+func newOverviewModel(timeFmt timeFormat, clock devdash.Clock, r devdash.Runner) overviewModel {
+	return overviewModel{sampler: devdash.NewUsageSampler(r), timeFmt: timeFmt, clock: clock}
+}
+
+// SetProcesses updates the listed sessions, keeping the selected one selected
+func (m *overviewModel) SetProcesses(procs []*devdash.RunningProcess) {
+	name := ""
+	if sel := m.SelectedProcess(); sel != nil {
+		name = sel.Info.Name
+	}
+	m.processes = procs
+	m.selectByName(name)
+}
+
+// selectByName moves the selection to the session called name, if listed,
+// and clamps it otherwise
+func (m *overviewModel) selectByName(name string) {
+	for i, rp := range m.processes {
+		if rp.Info.Name == name {
+			m.selected = i
+			return
+		}
+	}
+	m.selected = max(min(m.selected, len(m.processes)-1), 0)
+}
+
+// SelectedProcess returns the selected session, or nil if there are none
+func (m overviewModel) SelectedProcess() *devdash.RunningProcess {
+	if m.selected >= 0 && m.selected < len(m.processes) {
+		return m.processes[m.selected]
+	}
+	return nil
+}
+
+// SetSize updates dimensions
+func (m *overviewModel) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+}
+
+// Update handles table navigation
+func (m overviewModel) Update(msg tea.KeyMsg) overviewModel {
+	switch msg.String() {
+	case "up", "k":
+		if m.selected > 0 {
+			m.selected--
+		}
+	case "down", "j":
+		if m.selected < len(m.processes)-1 {
+			m.selected++
+		}
+	case "g", "home":
+		m.selected = 0
+	case "G", "end":
+		m.selected = max(len(m.processes)-1, 0)
+	}
+	return m
+}
+
+// sampleCmd measures CPU and memory of the running sessions off the UI goroutine
+func (m overviewModel) sampleCmd() tea.Cmd {
+	var pids []int
+	for _, rp := range m.processes {
+		if rp.Status == devdash.StatusRunning && rp.Info.PID > 0 {
+			pids = append(pids, rp.Info.PID)
+		}
+	}
+	sampler, clock := m.sampler, m.clock
+	return func() tea.Msg {
+		return usageSampledMsg{usage: sampler.Sample(pids, clock.Now())}
+	}
+}
+
+// View renders the table and a help bar ending in feedback, if any
+func (m overviewModel) View(feedback string) string {
+	innerW := max(m.width-2, 1)
+	innerH := max(m.height-3, 1) // borders and help bar
+
+	nameW := overviewNameMin
+	for _, rp := range m.processes {
+		nameW = max(nameW, len([]rune(rp.Info.Name)))
+	}
+	nameW = min(nameW, overviewNameMax)
+
+	var lines []string
+	if len(m.processes) == 0 {
+		lines = append(lines, dimStyle.Render("No active sessions"))
+	} else {
+		lines = append(lines, dimStyle.Render(m.headerRow(nameW, innerW)))
+		now := m.clock.Now()
+		items := make([][]string, len(m.processes))
+		for i, rp := range m.processes {
+			items[i] = []string{m.renderRow(i, rp, nameW, innerW, now)}
+		}
+		lines = append(lines, windowItems(items, m.selected, innerH-1)...)
+	}
+	for len(lines) < innerH {
+		lines = append(lines, "")
+	}
+
+	var b strings.Builder
+	b.WriteString(buildTopBorder(fmt.Sprintf(" Overview (%d) ", len(m.processes)), innerW, true))
+	b.WriteByte('\n')
+	for _, line := range lines[:innerH] {
+		b.WriteString(buildBodyLine(line, innerW, true))
+		b.WriteByte('\n')
+	}
+	b.WriteString(buildBottomBorder(innerW, true))
+	b.WriteByte('\n')
+	b.WriteString(m.renderHelpBar(feedback))
+	return b.String()
+}
+
+// headerRow renders the column titles, aligned with renderRow
+func (m overviewModel) headerRow(nameW, width int) string {
+	uptimeW, lastW := timeColumnWidths(m.timeFmt)
+	row := fmt.Sprintf("    %-*s %-*s %-*s %*s %*s %-*s %s",
+		nameW, "NAME", overviewPortW, "PORT", uptimeW, "UPTIME",
+		overviewCPUW, "CPU", overviewMemW, "MEM", lastW, "LAST LOG", "TUNNEL")
+	return ansi.Truncate(row, width, "")
+}
+
+// renderRow renders one session: cursor, status, name, port, uptime, CPU,
+// memory, time since the last log line, and tunnel URL
+func (m overviewModel) renderRow(idx int, rp *devdash.RunningProcess, nameW, width int, now time.Time) string {
+	cursor, nameStyle := "  ", normalItemStyle
+	if idx == m.selected {
+		cursor, nameStyle = "> ", selectedItemStyle
+	}
+
+	name := fmt.Sprintf("%-*s", nameW, elideMiddle(rp.Info.Name, nameW))
+	port := "-"
+	if rp.Info.Port > 0 {
+		port = fmt.Sprintf(":%d", rp.Info.Port)
+	}
+	uptime := "-"
+	if rp.Status == devdash.StatusRunning {
+		uptime = m.timeFmt.age(rp.StartedAt, now)
+	}
+
+	cpu, mem := "-", "-"
+	if u, ok := m.usage[rp.Info.PID]; ok && rp.Status == devdash.StatusRunning {
+		if u.HasCPU {
+			cpu = fmt.Sprintf("%.0f%%", u.CPU)
+		}
+		mem = formatBytes(u.RSS)
+	}
+
+	last := "-"
+	if rp.LogBuf != nil {
+		if at := rp.LogBuf.LastLineAt(); !at.IsZero() {
+			last = m.timeFmt.ago(at, now)
+		}
+	}
+
+	uptimeW, lastW := timeColumnWidths(m.timeFmt)
+	row := cursor + sessionStatusIcon(rp) + " " + nameStyle.Render(name) + " " +
+		portStyle.Render(fmt.Sprintf("%-*s", overviewPortW, port)) + " " +
+		ageStyle.Render(fmt.Sprintf("%-*s", uptimeW, uptime)) + " " +
+		fmt.Sprintf("%*s %*s", overviewCPUW, cpu, overviewMemW, mem) + " " +
+		ageStyle.Render(fmt.Sprintf("%-*s", lastW, last)) + " " +
+		overviewTunnel(rp.Tunnel)
+	return ansi.Truncate(row, width, "…")
+}
+
+// overviewTunnel renders the tunnel column: the public URL once known, else
+// the tunnel's state, "-" without one
+func overviewTunnel(t *devdash.TunnelInfo) string {
+	if t == nil {
+		return dimStyle.Render("-")
+	}
+	switch {
+	case t.URL != "":
+		return tunnelURLStyle.Render(t.URL)
+	case t.Status == devdash.TunnelStarting:
+		return dimStyle.Render("starting")
+	case t.Status == devdash.TunnelError:
+		return statusError.Render("error")
+	}
+	return dimStyle.Render("-")
+}
+
+// renderHelpBar renders the overview's key hints
+func (m overviewModel) renderHelpBar(feedback string) string {
+	keys := []struct{ key, desc string }{
+		{"j/k", "move"},
+		{"enter", "open log"},
+		{"O", "dashboard"},
+		{"q", "quit"},
+	}
+	var parts []string
+	for _, k := range keys {
+		parts = append(parts, helpKeyStyle.Render(k.key)+":"+helpDescStyle.Render(k.desc))
+	}
+	if feedback != "" {
+		parts = append(parts, helpKeyStyle.Render(feedback))
+	}
+	return helpStyle.Width(m.width).Render(strings.Join(parts, "  "))
+}
+
+// formatBytes formats a memory size compactly: 812K, 96M, 1.2G
+func formatBytes(n uint64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1fG", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%dM", n>>20)
+	default:
+		return fmt.Sprintf("%dK", n>>10)
+	}
+}
+
+// showOverview switches from the dashboard to the overview, starting at the
+// dashboard's selected session
+func (a App) showOverview() (App, tea.Cmd) {
+	a.dashboard.unsubscribeLogs()
+	a.overview.processes = a.dashboard.processes
+	a.overview.selected = a.dashboard.selected
+	a.view = viewOverview
+	return a, a.overview.sampleCmd()
+}
+
+// leaveOverview returns to the dashboard with the overview's selected session
+// selected there
+func (a App) leaveOverview() App {
+	if sel := a.overview.SelectedProcess(); sel != nil {
+		a.dashboard.selectByName(sel.Info.Name)
+	}
+	a.view = viewDashboard
+	return a
+}
+
+// updateOverviewKeys handles key events on the overview
+func (a App) updateOverviewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return a, tea.Quit
+	case "O", "esc":
+		a = a.leaveOverview()
+		return a, a.dashboard.SubscribeToSelected()
+	case "enter":
+		sel := a.overview.SelectedProcess()
+		if sel == nil {
+			return a, nil
+		}
+		a = a.leaveOverview()
+		return a.openLogView(sel)
+	}
+	a.overview = a.overview.Update(msg)
+	return a, nil
+}
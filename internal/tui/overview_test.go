@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+	"github.com/kimaguri/simplx-toolkit/internal/process"
+)
+
+func TestOverviewRows(t *testing.T) {
+	m := newOverviewModel(timeShort, devdash.SystemClock, devdash.SystemRunner)
+	m.SetSize(120, 12)
+	m.SetProcesses([]*devdash.RunningProcess{
+		{Info: devdash.SessionInfo{Name: "api", Port: 4000, PID: 10}, Status: devdash.StatusRunning,
+			Tunnel: &devdash.TunnelInfo{Status: devdash.TunnelActive, URL: "https://x.trycloudflare.com"}},
+		{Info: devdash.SessionInfo{Name: "web", Port: 5173, PID: 11}, Status: devdash.StatusError},
+	})
+	m.usage = map[int]devdash.Usage{10: {CPU: 12.4, HasCPU: true, RSS: 96 << 20}}
+
+	lines := strings.Split(ansi.Strip(m.View("")), "\n")
+	if len(lines) != 12 {
+		t.Fatalf("view is %d lines, want the terminal height 12", len(lines))
+	}
+	header, api, web := lines[1], lines[2], lines[3]
+	for _, col := range []string{"NAME", "PORT", "UPTIME", "CPU", "MEM", "LAST LOG", "TUNNEL"} {
+		if !strings.Contains(header, col) {
+			t.Errorf("header lacks %s: %q", col, header)
+		}
+	}
+	for _, want := range []string{"> *", "api", ":4000", "12%", "96M", "https://x.trycloudflare.com"} {
+		if !strings.Contains(api, want) {
+			t.Errorf("api row lacks %q: %q", want, api)
+		}
+	}
+	if !strings.Contains(web, "! web") || strings.Contains(web, "%") {
+		t.Errorf("errored session should show no usage: %q", web)
+	}
+}
+
+func TestOverviewNavigation(t *testing.T) {
+	procs := []*devdash.RunningProcess{
+		{Info: devdash.SessionInfo{Name: "a"}, Status: devdash.StatusRunning, LogBuf: process.NewLogBuffer(10)},
+		{Info: devdash.SessionInfo{Name: "b"}, Status: devdash.StatusRunning, LogBuf: process.NewLogBuffer(10)},
+		{Info: devdash.SessionInfo{Name: "c"}, Status: devdash.StatusRunning, LogBuf: process.NewLogBuffer(10)},
+	}
+	a := App{dashboard: newDashboardModel(), overview: newOverviewModel(timeShort, devdash.SystemClock, devdash.SystemRunner), width: 100, height: 30}
+	a.dashboard.SetProcesses(procs)
+	a.dashboard.selected = 1
+
+	model, _ := a.updateDashboardKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'O'}})
+	a = model.(App)
+	if a.view != viewOverview || a.overview.SelectedProcess().Info.Name != "b" {
+		t.Fatalf("O should open the overview at the dashboard's selection")
+	}
+
+	model, _ = a.updateOverviewKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	model, _ = model.(App).updateOverviewKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'O'}})
+	a = model.(App)
+	if a.view != viewDashboard || a.dashboard.SelectedProcess().Info.Name != "c" {
+		t.Errorf("leaving the overview should keep its selection, got view %d", a.view)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	cases := map[uint64]string{812 << 10: "812K", 96 << 20: "96M", 3 << 29: "1.5G"}
+	for n, want := range cases {
+		if got := formatBytes(n); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
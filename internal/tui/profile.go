@@ -0,0 +1,163 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kimaguri/simplx-toolkit/internal/config"
+	"github.com/kimaguri/simplx-toolkit/internal/discovery"
+)
+
+// launchNextMsg pops the next queued profile launch, if any
+type launchNextMsg struct{}
+
+// launchNext asks the app to start the next queued launch. Harmless when the
+// queue is empty, so it is sent after every launch attempt.
+func launchNext() tea.Msg { return launchNextMsg{} }
+
+// launchRetryDelay is how often a queued launch checks whether the overlay
+// it is waiting on was closed
+const launchRetryDelay = 500 * time.Millisecond
+
+// launchNextLater asks for the next queued launch after launchRetryDelay
+func launchNextLater() tea.Cmd {
+	return tea.Tick(launchRetryDelay, func(time.Time) tea.Msg { return launchNextMsg{} })
+}
+
+// resolveProfile turns the entries of a named profile into launch requests.
+// Entries whose worktree or project can't be found are skipped with a warning
+// so one stale entry doesn't block the rest.
+func resolveProfile(cfg *config.LocalConfig, wts []discovery.Worktree, name string) ([]LaunchRequestMsg, []string, error) {
+	entries, ok := cfg.Profiles[name]
+	if !ok {
+		if names := cfg.ProfileNames(); len(names) > 0 {
+			return nil, nil, fmt.Errorf("unknown profile %q (available: %s)", name, strings.Join(names, ", "))
+		}
+		return nil, nil, fmt.Errorf("unknown profile %q: no profiles in config.json", name)
+	}
+
+	filter := discoveryFilter(cfg)
+
+	var reqs []LaunchRequestMsg
+	var warnings []string
+	for _, e := range entries {
+		label := e.Worktree + "/" + e.Project
+
+		wt, found := findWorktree(wts, e.Worktree)
+		if !found {
+			warnings = append(warnings, fmt.Sprintf("%s: worktree %q not found", label, e.Worktree))
+			continue
+		}
+		proj, found := findProject(discovery.DetectProjectsFiltered(wt, filter), e.Project)
+		if !found {
+			warnings = append(warnings, fmt.Sprintf("%s: project %q not found in %s", label, e.Project, wt.Path))
+			continue
+		}
+
+		// Same order as the launcher's script step: custom commands first
+		script := e.Script
+		if script == "" {
+			if len(proj.Commands) > 0 {
+				script = proj.Commands[0]
+			} else if len(proj.Scripts) > 0 {
+				script = proj.Scripts[0]
+			}
+		}
+		var custom *config.CustomCommand
+		if c, ok := config.LoadProjectConfig(proj.Path).LaunchCommand(script); ok {
+			custom = &c
+		}
+		if custom == nil && !proj.IsEncore && script != "" && !containsString(proj.Scripts, script) {
+			warnings = append(warnings, fmt.Sprintf("%s: no %q script in package.json or .devdash.json", label, script))
+			continue
+		}
+
+		// Same precedence as the launcher's port step
+		port := e.Port
+		if port <= 0 {
+			port = cfg.GetPort(config.PortKey(wt.Name, proj.Name))
+		}
+		if port <= 0 {
+			port = proj.DetectedPort
+		}
+		if port <= 0 {
+			port = 3000
+		}
+
+		var encoreArgs []string
+		if proj.IsEncore {
+			encoreArgs = cfg.EncoreArgsFor(proj.Path)
+		}
+
+		reqs = append(reqs, LaunchRequestMsg{
+			Worktree:       wt,
+			Project:        proj,
+			Port:           port,
+			Script:         script,
+			Custom:         custom,
+			PackageManager: proj.PackageManager,
+			EncoreArgs:     encoreArgs,
+			StopTimeout:    cfg.StopTimeoutFor(proj.Path),
+			StopSignal:     cfg.StopSignalFor(proj.Path),
+			OpenFiles:      cfg.OpenFilesFor(proj.Path),
+			VersionManager: cfg.VersionManager,
+			CleanEnv:       cfg.CleanEnvFor(proj.Path),
+		})
+	}
+	return reqs, warnings, nil
+}
+
+// findWorktree returns the worktree with the given display name
+func findWorktree(wts []discovery.Worktree, name string) (discovery.Worktree, bool) {
+	for _, wt := range wts {
+		if wt.Name == name {
+			return wt, true
+		}
+	}
+	return discovery.Worktree{}, false
+}
+
+// findProject returns the project with the given directory or package name
+func findProject(projects []discovery.Project, name string) (discovery.Project, bool) {
+	for _, p := range projects {
+		if p.Name == name || (p.PkgName != "" && p.PkgName == name) {
+			return p, true
+		}
+	}
+	return discovery.Project{}, false
+}
+
+// containsString reports whether list contains s
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// StartProfile queues every service in the named profile for launch once the
+// program starts. Services that are already running are skipped. Returns
+// warnings for skipped entries; an error means the profile doesn't exist.
+func (a App) StartProfile(name string) (App, []string, error) {
+	reqs, warnings, err := resolveProfile(a.cfg, a.worktrees, name)
+	if err != nil {
+		return a, nil, err
+	}
+	for _, req := range reqs {
+		sessionName := config.SessionName(req.Worktree.Name, req.Project.Name)
+		if a.pm.Get(sessionName) != nil {
+			warnings = append(warnings, fmt.Sprintf("%s: already running", sessionName))
+			continue
+		}
+		req.fromProfile = true
+		a.launchQueue = append(a.launchQueue, req)
+	}
+	a.profileName = name
+	a.profileSkipped = len(warnings)
+	return a, warnings, nil
+}
@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kimaguri/simplx-toolkit/internal/config"
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+	"github.com/kimaguri/simplx-toolkit/internal/discovery"
+)
+
+func TestResolveProfile(t *testing.T) {
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, "package.json"), []byte(`{"name":"web","scripts":{"dev":"vite","build":"vite build"}}`), 0644)
+	os.WriteFile(filepath.Join(root, "pnpm-lock.yaml"), nil, 0644)
+	wts := []discovery.Worktree{{Name: "web", Path: root}}
+
+	projects := discovery.DetectProjects(wts[0])
+	if len(projects) != 1 {
+		t.Fatalf("expected 1 project, got %d", len(projects))
+	}
+	projName := projects[0].Name
+
+	cfg := &config.LocalConfig{
+		PortOverrides: map[string]int{config.PortKey("web", projName): 4100},
+		Profiles: map[string][]config.ProfileEntry{
+			"morning": {
+				{Worktree: "web", Project: projName},
+				{Worktree: "web", Project: projName, Script: "build", Port: 5000},
+				{Worktree: "gone", Project: "api"},
+				{Worktree: "web", Project: projName, Script: "missing"},
+			},
+		},
+	}
+
+	reqs, warnings, err := resolveProfile(cfg, wts, "morning")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reqs) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(reqs))
+	}
+	if reqs[0].Script != "dev" || reqs[0].Port != 4100 {
+		t.Errorf("defaults: got script %q port %d, want dev/4100", reqs[0].Script, reqs[0].Port)
+	}
+	if reqs[1].Script != "build" || reqs[1].Port != 5000 {
+		t.Errorf("explicit: got script %q port %d, want build/5000", reqs[1].Script, reqs[1].Port)
+	}
+	if len(warnings) != 2 || !strings.Contains(warnings[0], `worktree "gone"`) || !strings.Contains(warnings[1], `"missing"`) {
+		t.Errorf("unexpected warnings %q", warnings)
+	}
+}
+
+func TestResolveProfile_Unknown(t *testing.T) {
+	cfg := &config.LocalConfig{Profiles: map[string][]config.ProfileEntry{"b": nil, "a": nil}}
+	_, _, err := resolveProfile(cfg, nil, "nope")
+	if err == nil || !strings.Contains(err.Error(), "available: a, b") {
+		t.Errorf("expected error listing profiles, got %v", err)
+	}
+}
+
+func TestProfileLaunchWaitsForOverlay(t *testing.T) {
+	t.Setenv(config.ConfigDirEnv, t.TempDir())
+	req := LaunchRequestMsg{
+		Worktree:    discovery.Worktree{Name: "platform", Path: t.TempDir()},
+		Project:     discovery.Project{Name: "web", IsEncore: true},
+		Port:        4100,
+		fromProfile: true,
+	}
+	a := App{
+		pm:          devdash.NewProcessManager(t.TempDir(), t.TempDir()),
+		cfg:         &config.LocalConfig{PortOverrides: map[string]int{}},
+		dashboard:   newDashboardModel(),
+		overlay:     overlaySettings,
+		launchQueue: []LaunchRequestMsg{req},
+	}
+
+	// Not dequeued while the settings overlay is open
+	model, cmd := a.Update(launchNextMsg{})
+	a = model.(App)
+	if len(a.launchQueue) != 1 || a.overlay != overlaySettings || cmd == nil {
+		t.Fatalf("queue %d, overlay %v, retry %v; want the launch held and retried", len(a.launchQueue), a.overlay, cmd != nil)
+	}
+
+	// A launch back from its checks goes back to the front of the queue
+	a.launchQueue = nil
+	back := req
+	back.portChecked, back.elsewhereChecked = true, true
+	model, _ = a.Update(back)
+	a = model.(App)
+	if len(a.launchQueue) != 1 || !a.launchQueue[0].portChecked || a.overlay != overlaySettings {
+		t.Fatalf("queue %+v, overlay %v; want the launch requeued with its checks kept", a.launchQueue, a.overlay)
+	}
+
+	// Once the overlay is closed it launches, without saving its port
+	a.overlay = overlayNone
+	a.launchQueue = nil
+	model, _ = a.Update(back)
+	a = model.(App)
+	if port := a.cfg.GetPort(config.PortKey("platform", "web")); port != 0 {
+		t.Errorf("profile port was saved as %d", port)
+	}
+}
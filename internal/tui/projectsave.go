@@ -0,0 +1,120 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kimaguri/simplx-toolkit/internal/config"
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+	"github.com/kimaguri/simplx-toolkit/internal/discovery"
+)
+
+// projectLaunch is what saving a session's launch config writes to its
+// project's .devdash.json
+type projectLaunch struct {
+	dir  string   // project directory
+	port int      // session port, the project's default port
+	env  []string // session env besides PORT, for script launches
+}
+
+// sessionProjectLaunch returns the launch config of the session info to save
+// into its project's .devdash.json. A custom command's env lives in its own
+// entry, so for those the file's env is kept as is. So is a script launch's
+// whose file env expands to the session's: its ${VAR} templates stay in the
+// file rather than this machine's values.
+func sessionProjectLaunch(cfg *config.LocalConfig, wts []discovery.Worktree, info devdash.SessionInfo) (projectLaunch, bool) {
+	_, proj, found := sessionProject(cfg, wts, info)
+	if !found {
+		return projectLaunch{}, false
+	}
+	pl := projectLaunch{dir: proj.Path, port: info.Port}
+	pc := config.LoadProjectConfig(proj.Path)
+	if info.Custom {
+		pl.env = pc.Env
+		return pl, true
+	}
+	for _, kv := range info.ExtraEnv {
+		if !strings.HasPrefix(kv, "PORT=") {
+			pl.env = append(pl.env, kv)
+		}
+	}
+	portEnv := fmt.Sprintf("PORT=%d", info.Port)
+	if env, err := pc.ExpandEnv(append(devdash.BaseEnv(info.CleanEnv), portEnv)); err == nil && slices.Equal(env, pl.env) {
+		pl.env = pc.Env
+	}
+	return pl, true
+}
+
+// diff lists what saving pl changes in pc, as "-"/"+" line pairs like
+// launchDiff; empty when the file already has these values
+func (pl projectLaunch) diff(pc *config.ProjectConfig) []string {
+	var lines []string
+	add := func(label, before, after string) {
+		if before == after {
+			return
+		}
+		if before == "" {
+			before = "(unset)"
+		}
+		if after == "" {
+			after = "(unset)"
+		}
+		lines = append(lines, "- "+label+": "+before, "+ "+label+": "+after)
+	}
+	port := func(p int) string {
+		if p <= 0 {
+			return ""
+		}
+		return strconv.Itoa(p)
+	}
+	add("port", port(pc.Port), port(pl.port))
+	if !slices.Equal(pc.Env, pl.env) {
+		add("env", strings.Join(pc.Env, " "), strings.Join(pl.env, " "))
+	}
+	return lines
+}
+
+// confirmSaveProjectLaunch asks to write the selected session's port and env
+// to its project's .devdash.json, showing what would change
+func (a App) confirmSaveProjectLaunch() (App, tea.Cmd) {
+	sel := a.dashboard.SelectedProcess()
+	if sel == nil {
+		return a, nil
+	}
+	pl, ok := sessionProjectLaunch(a.cfg, a.worktrees, sel.Info)
+	if !ok {
+		return a, feedbackCmd("[No project to save the launch config to]")
+	}
+	path := filepath.Join(pl.dir, config.ProjectConfigFile)
+	diff := pl.diff(config.LoadProjectConfig(pl.dir))
+	if len(diff) == 0 {
+		return a, feedbackCmd(fmt.Sprintf("[%s already has this launch config]", path))
+	}
+	text := fmt.Sprintf("Save the launch config of %q to %s?\n\n%s", sel.Info.Name, path, strings.Join(diff, "\n"))
+	a.confirm = newConfirmModel(text, "save-project-launch", sel.Info.Name)
+	a.confirm.SetSize(a.width, a.height)
+	a.overlay = overlayConfirm
+	return a, nil
+}
+
+// saveProjectLaunch writes the session's launch config to its project's
+// .devdash.json, merged with what the file already has
+func (a App) saveProjectLaunch(name string) tea.Cmd {
+	rp := a.pm.Get(name)
+	if rp == nil {
+		return nil
+	}
+	pl, ok := sessionProjectLaunch(a.cfg, a.worktrees, rp.Info)
+	if !ok {
+		return feedbackCmd("[No project to save the launch config to]")
+	}
+	if err := config.SaveProjectLaunch(pl.dir, pl.port, pl.env); err != nil {
+		return feedbackCmd(fmt.Sprintf("[Save failed: %v]", err))
+	}
+	return feedbackCmd(fmt.Sprintf("[Saved to %s]", filepath.Join(pl.dir, config.ProjectConfigFile)))
+}
@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+)
+
+// readyFlashDuration is how long a session's row stays green after its log
+// says the server is up
+const readyFlashDuration = 2 * time.Second
+
+// serverReadyMsg reports that a session's server came up
+type serverReadyMsg struct {
+	name string
+}
+
+// readyFlashDoneMsg re-renders the list once a ready flash is over
+type readyFlashDoneMsg struct{}
+
+// waitServerReady waits for the next session whose server comes up
+func waitServerReady(pm *devdash.ProcessManager) tea.Cmd {
+	return func() tea.Msg {
+		return serverReadyMsg{name: <-pm.Ready()}
+	}
+}
+
+// readyFlashTimeout ends the ready flash after readyFlashDuration
+func readyFlashTimeout() tea.Cmd {
+	return tea.Tick(readyFlashDuration, func(time.Time) tea.Msg {
+		return readyFlashDoneMsg{}
+	})
+}
+
+// readyFlashing reports whether rp's server came up less than
+// readyFlashDuration before now
+func readyFlashing(rp *devdash.RunningProcess, now time.Time) bool {
+	at := rp.Ready.ReadyAt()
+	return !at.IsZero() && now.Sub(at) < readyFlashDuration
+}
+
+// ringBell rings the terminal bell, on stderr like the OSC52 clipboard
+// sequence so it doesn't interfere with rendering
+func ringBell() {
+	_, _ = fmt.Fprint(os.Stderr, "\a")
+}
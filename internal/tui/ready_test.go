@@ -0,0 +1,26 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+)
+
+func TestReadyFlashing(t *testing.T) {
+	rp := &devdash.RunningProcess{Ready: devdash.NewReadyDetector(devdash.SessionInfo{})}
+	at := time.Date(2026, 10, 15, 14, 30, 0, 0, time.UTC)
+	if readyFlashing(rp, at) {
+		t.Error("a session that isn't ready yet should not flash")
+	}
+	rp.Ready.Observe("Listening on :3000", at)
+	if !readyFlashing(rp, at.Add(time.Second)) {
+		t.Error("a session that just came up should flash")
+	}
+	if readyFlashing(rp, at.Add(readyFlashDuration)) {
+		t.Error("the flash should be over after readyFlashDuration")
+	}
+	if readyFlashing(&devdash.RunningProcess{}, at) {
+		t.Error("a session without a ready detector should not flash")
+	}
+}
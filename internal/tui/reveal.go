@@ -0,0 +1,57 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+)
+
+// fileManagerCommand returns the command that opens dir in the file manager
+// of goos: Finder on macOS, Explorer on Windows, the desktop's default
+// through xdg-open elsewhere
+func fileManagerCommand(goos, dir string) []string {
+	switch goos {
+	case "darwin":
+		return []string{"open", dir}
+	case "windows":
+		return []string{"explorer", dir}
+	default:
+		return []string{"xdg-open", dir}
+	}
+}
+
+// sessionDir returns the directory a session runs in, falling back to its
+// worktree; "" for attached processes that have neither
+func sessionDir(info devdash.SessionInfo) string {
+	if info.WorkDir != "" {
+		return info.WorkDir
+	}
+	return info.WtPath
+}
+
+// revealInFileManager opens the session's directory in the OS file manager,
+// run through r. The file manager runs detached; the TUI keeps the terminal.
+func revealInFileManager(r devdash.Runner, info devdash.SessionInfo) tea.Cmd {
+	dir := sessionDir(info)
+	if dir == "" {
+		return feedbackCmd("[No directory known for this session]")
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return feedbackCmd(fmt.Sprintf("[Not found: %s]", dir))
+	}
+	argv := fileManagerCommand(runtime.GOOS, dir)
+	if _, err := r.LookPath(argv[0]); err != nil {
+		return feedbackCmd(fmt.Sprintf("[No file manager: %s not found]", argv[0]))
+	}
+	cmd := r.Command(context.Background(), argv[0], argv[1:]...)
+	if err := cmd.Start(); err != nil {
+		return feedbackCmd(fmt.Sprintf("[Open error: %v]", err))
+	}
+	go func() { _ = cmd.Wait() }()
+	return feedbackCmd(fmt.Sprintf("[Opened %s]", dir))
+}
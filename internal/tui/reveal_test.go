@@ -0,0 +1,31 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+)
+
+func TestFileManagerCommand(t *testing.T) {
+	cases := map[string][]string{
+		"darwin":  {"open", "/src/app"},
+		"windows": {"explorer", "/src/app"},
+		"linux":   {"xdg-open", "/src/app"},
+		"freebsd": {"xdg-open", "/src/app"},
+	}
+	for goos, want := range cases {
+		if got := fileManagerCommand(goos, "/src/app"); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %v, want %v", goos, got, want)
+		}
+	}
+}
+
+func TestSessionDir(t *testing.T) {
+	if got := sessionDir(devdash.SessionInfo{WorkDir: "/ws", WtPath: "/wt"}); got != "/ws" {
+		t.Errorf("work dir should win, got %q", got)
+	}
+	if got := sessionDir(devdash.SessionInfo{WtPath: "/wt"}); got != "/wt" {
+		t.Errorf("worktree fallback, got %q", got)
+	}
+}
@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kimaguri/simplx-toolkit/internal/config"
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+	"github.com/kimaguri/simplx-toolkit/internal/discovery"
+)
+
+// scanPurpose says what to open once a background discovery finishes
+type scanPurpose int
+
+const (
+	scanLauncher  scanPurpose = iota // open the launcher
+	scanDuplicate                    // open the launcher prefilled with a copy of a session
+	scanSettings                     // open settings with fresh worktree counts
+	scanRescan                       // refresh the counts in the open settings overlay
+	scanRefresh                      // only update the worktree list, no overlay
+)
+
+// scanRequest describes one background discovery run
+type scanRequest struct {
+	purpose  scanPurpose
+	seq      int // scanningModel.seq for overlay scans
+	gen      int // App.scanGen when started; orders results of overlapping scans
+	scanDirs []string
+	// scanDuplicate only: the session to copy and the copy's name and port
+	dup     devdash.SessionInfo
+	dupName string
+	dupPort int
+}
+
+// discoveryDoneMsg delivers the results of a scanRequest
+type discoveryDoneMsg struct {
+	req       scanRequest
+	worktrees []discovery.Worktree
+	launcher  launcherModel // scanLauncher/scanDuplicate/scanRefresh: wizard with projects detected
+	ok        bool          // scanDuplicate: the session's directory and project were found
+}
+
+// discoverCmd scans worktrees and, for the launcher, detects their projects —
+// the slow part on big trees — off the UI goroutine
+func discoverCmd(req scanRequest, cfg *config.LocalConfig) tea.Cmd {
+	return func() tea.Msg {
+		msg := discoveryDoneMsg{req: req, worktrees: discovery.ScanWorktrees(req.scanDirs)}
+		switch req.purpose {
+		case scanLauncher, scanRefresh:
+			msg.launcher, msg.ok = newLauncherModel(msg.worktrees, cfg), true
+		case scanDuplicate:
+			msg.launcher, msg.ok = newLauncherModel(msg.worktrees, cfg).prefillDuplicate(req.dup, req.dupName, req.dupPort)
+		}
+		return msg
+	}
+}
+
+// scanningModel is the "Scanning..." overlay shown while discovery runs
+type scanningModel struct {
+	spinner spinner.Model
+	seq     int          // id of the scan being waited for; results of cancelled scans are not opened
+	back    overlayState // overlay restored when the scan is cancelled
+	width   int
+	height  int
+}
+
+// newScanningModel creates the overlay's spinner
+func newScanningModel() scanningModel {
+	return scanningModel{spinner: spinner.New(spinner.WithSpinner(spinner.Dot))}
+}
+
+// start begins waiting for a new scan and returns its id
+func (m *scanningModel) start(back overlayState) int {
+	m.seq++
+	m.back = back
+	return m.seq
+}
+
+// View renders the overlay
+func (m scanningModel) View() string {
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		modalTitleStyle.Render("Discovery"),
+		"",
+		m.spinner.View()+" Scanning worktrees and projects...",
+		"",
+		dimStyle.Render("esc:cancel"),
+	)
+	popup := modalStyle.Width(44).Render(content)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, popup)
+}
+
+// SetSize updates dimensions for centering
+func (m *scanningModel) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+}
@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kimaguri/simplx-toolkit/internal/config"
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+	"github.com/kimaguri/simplx-toolkit/internal/discovery"
+)
+
+func TestScanOpensLauncherWhenDone(t *testing.T) {
+	cfg := &config.LocalConfig{}
+	pm := devdash.NewProcessManager(t.TempDir(), t.TempDir())
+	a := App{pm: pm, cfg: cfg, scanning: newScanningModel(), width: 100, height: 40}
+
+	a, cmd := a.scan(scanRequest{purpose: scanLauncher})
+	if a.overlay != overlayScanning || cmd == nil {
+		t.Fatalf("expected scanning overlay, got %d", a.overlay)
+	}
+	done := discoverCmd(scanRequest{purpose: scanLauncher, seq: a.scanning.seq}, cfg)().(discoveryDoneMsg)
+
+	m, _ := a.handleDiscoveryDone(done)
+	if got := m.(App).overlay; got != overlayLauncher {
+		t.Errorf("overlay = %d, want launcher", got)
+	}
+}
+
+func TestScanCancelDropsResult(t *testing.T) {
+	cfg := &config.LocalConfig{}
+	a := App{cfg: cfg, scanning: newScanningModel(), overlay: overlaySettings}
+
+	a, _ = a.scan(scanRequest{purpose: scanRescan})
+	m, _ := a.updateOverlay(tea.KeyMsg{Type: tea.KeyEsc})
+	a = m.(App)
+	if a.overlay != overlaySettings {
+		t.Fatalf("esc should return to settings, got %d", a.overlay)
+	}
+
+	// A newer scan supersedes an older one still in flight
+	a, _ = a.scan(scanRequest{purpose: scanLauncher})
+	stale := discoveryDoneMsg{req: scanRequest{purpose: scanLauncher, seq: a.scanning.seq - 1}, ok: true}
+	m, _ = a.handleDiscoveryDone(stale)
+	if got := m.(App).overlay; got != overlayScanning {
+		t.Errorf("stale result opened overlay %d", got)
+	}
+}
+
+func TestStaleRefreshKeepsNewerWorktrees(t *testing.T) {
+	a := App{cfg: &config.LocalConfig{}, scanning: newScanningModel()}
+
+	a.discover(scanRequest{purpose: scanRefresh})
+	a.discover(scanRequest{purpose: scanRefresh})
+	older := discoveryDoneMsg{req: scanRequest{purpose: scanRefresh, gen: 1}, worktrees: []discovery.Worktree{{Name: "old"}}}
+	newer := discoveryDoneMsg{req: scanRequest{purpose: scanRefresh, gen: 2}, worktrees: []discovery.Worktree{{Name: "new"}}}
+
+	m, _ := a.handleDiscoveryDone(newer)
+	m, _ = m.(App).handleDiscoveryDone(older)
+	if got := m.(App).worktrees; len(got) != 1 || got[0].Name != "new" {
+		t.Errorf("worktrees = %v, want the newer scan's", got)
+	}
+}
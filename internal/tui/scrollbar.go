@@ -0,0 +1,75 @@
+package tui
+
+import "strings"
+
+// Scrollbar glyphs. Ticks mark error and warning lines; their shape differs
+// from the track so they still show without color.
+const (
+	scrollTrack     = "│"
+	scrollThumb     = "┃"
+	scrollTick      = "━"
+	scrollThumbTick = "╋"
+)
+
+// thumbSpan returns the first cell and the length of the scrollbar thumb for
+// a viewport of height rows showing rows offset.. of total. The whole track is
+// the thumb when everything fits.
+func thumbSpan(height, total, offset int) (top, size int) {
+	if total <= height {
+		return 0, height
+	}
+	size = max(height*height/total, 1)
+	top = offset * (height - size) / (total - height)
+	return max(min(top, height-size), 0), size
+}
+
+// lineLevels classifies each line, for the scrollbar ticks
+func lineLevels(lines []string) []logLevel {
+	levels := make([]logLevel, len(lines))
+	for i, line := range lines {
+		levels[i] = classifyLine(line)
+	}
+	return levels
+}
+
+// levelCells maps line levels onto cells scrollbar cells, keeping the most
+// severe level of the lines each cell covers. Lines are spread evenly, so
+// with wrapped lines a tick can be a cell off.
+func levelCells(levels []logLevel, cells int) []logLevel {
+	out := make([]logLevel, cells)
+	for i, lv := range levels {
+		c := i * cells / len(levels)
+		out[c] = max(out[c], lv)
+	}
+	return out
+}
+
+// renderScrollbar renders a one-column scrollbar of height rows for a
+// viewport at offset into total rows, with ticks where levels has errors and
+// warnings
+func renderScrollbar(height, total, offset int, levels []logLevel) string {
+	top, size := thumbSpan(height, total, offset)
+	ticks := levelCells(levels, height)
+	rows := make([]string, height)
+	for i := range rows {
+		thumb := i >= top && i < top+size
+		glyph, style := scrollTrack, dimStyle
+		if thumb {
+			glyph, style = scrollThumb, helpKeyStyle
+		}
+		switch ticks[i] {
+		case levelError:
+			style = statusError
+		case levelWarn:
+			style = statusStopped
+		}
+		if ticks[i] != levelNone {
+			glyph = scrollTick
+			if thumb {
+				glyph = scrollThumbTick
+			}
+		}
+		rows[i] = style.Render(glyph)
+	}
+	return strings.Join(rows, "\n")
+}
@@ -0,0 +1,66 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestThumbSpan(t *testing.T) {
+	tests := []struct {
+		height, total, offset int
+		top, size             int
+	}{
+		{10, 5, 0, 0, 10},    // everything fits
+		{10, 100, 0, 0, 1},   // top
+		{10, 100, 90, 9, 1},  // bottom
+		{10, 20, 5, 2, 5},    // half way
+		{10, 1000, 45, 0, 1}, // tiny thumb rounds down
+	}
+	for _, tt := range tests {
+		top, size := thumbSpan(tt.height, tt.total, tt.offset)
+		if top != tt.top || size != tt.size {
+			t.Errorf("thumbSpan(%d, %d, %d) = %d, %d; want %d, %d",
+				tt.height, tt.total, tt.offset, top, size, tt.top, tt.size)
+		}
+	}
+}
+
+func TestLevelCells(t *testing.T) {
+	levels := []logLevel{levelNone, levelWarn, levelError, levelNone, levelNone, levelNone}
+	got := levelCells(levels, 3)
+	want := []logLevel{levelWarn, levelError, levelNone}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("levelCells = %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestLogViewScrollbar(t *testing.T) {
+	rp := newTestProcess("api", "one", "two", "ERROR three")
+	m := newLogViewModel(rp)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 40, Height: 5})
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("|")})
+	if m.viewport.Width != 39 {
+		t.Errorf("viewport width = %d, want 39 with the scrollbar", m.viewport.Width)
+	}
+	rows := strings.Split(ansi.Strip(m.View()), "\n")[1:4]
+	for i, row := range rows {
+		if ansi.StringWidth(row) != 40 {
+			t.Errorf("row %d is %d wide, want 40: %q", i, ansi.StringWidth(row), row)
+		}
+	}
+	if !strings.HasSuffix(rows[2], scrollThumbTick) {
+		t.Errorf("want an error tick beside the error line, got %q", rows[2])
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("|")})
+	if m.viewport.Width != 40 {
+		t.Errorf("viewport width = %d, want 40 without the scrollbar", m.viewport.Width)
+	}
+}
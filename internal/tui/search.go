@@ -2,7 +2,11 @@ package tui
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
@@ -25,6 +29,23 @@ type searchModel struct {
 	query        string
 	matchCount   int
 	currentMatch int
+	seq          int  // bumped per debounced keystroke; stale debounce msgs are ignored
+	pending      bool // query changed but the filter hasn't been re-run yet
+
+	caseSensitive bool      // match case exactly; toggled with alt+c, kept across searches
+	kind          matchKind // substring, whole-word or fuzzy; cycled with alt+m
+}
+
+// searchDebounce is how long typing must pause before a large buffer is re-filtered
+const searchDebounce = 120 * time.Millisecond
+
+// searchDebounceLines is the buffer size from which filtering waits for a
+// pause in typing; smaller buffers are filtered on every keystroke
+const searchDebounceLines = 5000
+
+// searchDebounceMsg fires searchDebounce after a keystroke
+type searchDebounceMsg struct {
+	seq int
 }
 
 // newSearchModel creates a new search model with a configured text input
@@ -55,6 +76,7 @@ func (s *searchModel) deactivate() {
 	s.query = ""
 	s.matchCount = 0
 	s.currentMatch = 0
+	s.pending = false
 	s.input.SetValue("")
 	s.input.Blur()
 }
@@ -77,56 +99,222 @@ func (s *searchModel) isActive() bool {
 	return s.mode != searchOff
 }
 
-// filterAndHighlight filters lines that contain the query (case-insensitive)
-// and highlights matching text. Returns filtered lines and total match count.
-func filterAndHighlight(lines []string, query string) ([]string, int) {
-	if query == "" {
+// matchKind selects how the search query is matched against log lines
+type matchKind int
+
+const (
+	matchSubstring matchKind = iota // query appears anywhere in the line
+	matchWholeWord                  // query appears between word boundaries
+	matchFuzzy                      // query's characters appear in order
+	matchKindCount
+)
+
+// label is the search bar indicator for the kind; empty for the default
+func (k matchKind) label() string {
+	switch k {
+	case matchWholeWord:
+		return "word"
+	case matchFuzzy:
+		return "fuzzy"
+	}
+	return ""
+}
+
+// lineMatcher finds query matches in a line according to the search options
+type lineMatcher struct {
+	query         string
+	caseSensitive bool
+	kind          matchKind
+	word          *regexp.Regexp // compiled query for matchWholeWord
+}
+
+// newLineMatcher prepares a matcher; the word-boundary regexp is compiled once
+// here rather than per line
+func newLineMatcher(query string, caseSensitive bool, kind matchKind) lineMatcher {
+	lm := lineMatcher{query: query, caseSensitive: caseSensitive, kind: kind}
+	if kind == matchWholeWord && query != "" {
+		expr := `\b` + regexp.QuoteMeta(query) + `\b`
+		if !caseSensitive {
+			expr = "(?i)" + expr
+		}
+		lm.word = regexp.MustCompile(expr)
+	}
+	return lm
+}
+
+// ranges returns the byte ranges of line that matched, or nil for no match.
+// Substring and whole-word give one range per occurrence; fuzzy gives the
+// runs of matched characters. Only the visible text is matched, so escape
+// sequences neither match nor make word boundaries.
+func (lm lineMatcher) ranges(line string) [][2]int {
+	if lm.query == "" {
+		return nil
+	}
+	if lm.kind == matchFuzzy {
+		return fuzzyRanges(line, lm.query, lm.caseSensitive)
+	}
+
+	plain, offsets := visibleText(line)
+	var out [][2]int
+	if lm.kind == matchWholeWord {
+		for _, loc := range lm.word.FindAllStringIndex(plain, -1) {
+			out = append(out, [2]int{offsets[loc[0]], offsets[loc[1]-1] + 1})
+		}
+		return out
+	}
+	folded := foldCase(plain, lm.caseSensitive)
+	query := foldCase(lm.query, lm.caseSensitive)
+	for start := 0; ; {
+		idx := strings.Index(folded[start:], query)
+		s, e := start+idx, start+idx+len(query)
+		if idx == -1 || e > len(offsets) {
+			return out
+		}
+		out = append(out, [2]int{offsets[s], offsets[e-1] + 1})
+		start = e
+	}
+}
+
+// visibleText returns line without its escape sequences, and for each byte
+// of that text its index in line
+func visibleText(line string) (string, []int) {
+	if !strings.Contains(line, "\x1b") {
+		offsets := make([]int, len(line))
+		for i := range offsets {
+			offsets[i] = i
+		}
+		return line, offsets
+	}
+	var b strings.Builder
+	offsets := make([]int, 0, len(line))
+	for i := 0; i < len(line); {
+		if line[i] == 0x1b {
+			i = skipEscape(line, i)
+			continue
+		}
+		b.WriteByte(line[i])
+		offsets = append(offsets, i)
+		i++
+	}
+	return b.String(), offsets
+}
+
+// count returns the number of matches in a line: occurrences for substring
+// and whole-word, 1 for a fuzzy hit
+func (lm lineMatcher) count(ranges [][2]int) int {
+	if lm.kind == matchFuzzy && len(ranges) > 0 {
+		return 1
+	}
+	return len(ranges)
+}
+
+// fuzzyRanges matches query as a subsequence of line's visible characters,
+// taking the earliest position for each one. ANSI escape sequences are
+// skipped so highlighting never lands inside one.
+func fuzzyRanges(line, query string, caseSensitive bool) [][2]int {
+	want := []rune(foldCase(query, caseSensitive))
+	var out [][2]int
+	for i := 0; i < len(line) && len(want) > 0; {
+		if line[i] == 0x1b {
+			i = skipEscape(line, i)
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(line[i:])
+		if !caseSensitive {
+			r = unicode.ToLower(r)
+		}
+		if r == want[0] {
+			want = want[1:]
+			if n := len(out); n > 0 && out[n-1][1] == i {
+				out[n-1][1] = i + size
+			} else {
+				out = append(out, [2]int{i, i + size})
+			}
+		}
+		i += size
+	}
+	if len(want) > 0 {
+		return nil
+	}
+	return out
+}
+
+// skipEscape returns the index just past the escape sequence starting at i
+func skipEscape(s string, i int) int {
+	if i+1 >= len(s) {
+		return len(s)
+	}
+	switch s[i+1] {
+	case '[': // CSI: parameters, then a final byte in 0x40–0x7e
+		for j := i + 2; j < len(s); j++ {
+			if s[j] >= 0x40 && s[j] <= 0x7e {
+				return j + 1
+			}
+		}
+		return len(s)
+	case ']': // OSC: terminated by BEL or ESC \
+		for j := i + 2; j < len(s); j++ {
+			if s[j] == 0x07 {
+				return j + 1
+			}
+			if s[j] == 0x1b && j+1 < len(s) && s[j+1] == '\\' {
+				return j + 2
+			}
+		}
+		return len(s)
+	}
+	return i + 2
+}
+
+// filterAndHighlight filters lines that match the query and highlights the
+// matched text. Returns filtered lines and total match count.
+func filterAndHighlight(lines []string, lm lineMatcher) ([]string, int) {
+	if lm.query == "" {
 		return lines, 0
 	}
 
-	lowerQuery := strings.ToLower(query)
 	var filtered []string
 	matchCount := 0
 
 	for _, line := range lines {
-		if strings.Contains(strings.ToLower(line), lowerQuery) {
-			highlighted := highlightMatches(line, query)
-			filtered = append(filtered, highlighted)
-			matchCount += strings.Count(strings.ToLower(line), lowerQuery)
+		ranges := lm.ranges(line)
+		if len(ranges) == 0 {
+			continue
 		}
+		filtered = append(filtered, highlightRanges(line, ranges))
+		matchCount += lm.count(ranges)
 	}
 
 	return filtered, matchCount
 }
 
-// highlightMatches wraps each occurrence of query in the line with a highlight style.
-// Uses case-insensitive matching but preserves the original case in output.
-func highlightMatches(line string, query string) string {
-	if query == "" {
-		return line
+// foldCase lowercases s unless the search is case-sensitive
+func foldCase(s string, caseSensitive bool) string {
+	if caseSensitive {
+		return s
 	}
+	return strings.ToLower(s)
+}
 
-	lowerLine := strings.ToLower(line)
-	lowerQuery := strings.ToLower(query)
-	queryLen := len(lowerQuery)
+// highlightMatches wraps each match in the line with a highlight style.
+// Case-insensitive matching still preserves the original case in output.
+func highlightMatches(line string, lm lineMatcher) string {
+	return highlightRanges(line, lm.ranges(line))
+}
 
+// highlightRanges wraps the given byte ranges of line in the highlight style
+func highlightRanges(line string, ranges [][2]int) string {
+	if len(ranges) == 0 {
+		return line
+	}
 	var result strings.Builder
-	lastIdx := 0
-
-	for {
-		idx := strings.Index(lowerLine[lastIdx:], lowerQuery)
-		if idx == -1 {
-			result.WriteString(line[lastIdx:])
-			break
-		}
-
-		absIdx := lastIdx + idx
-		result.WriteString(line[lastIdx:absIdx])
-		matchText := line[absIdx : absIdx+queryLen]
-		result.WriteString(searchHighlightStyle.Render(matchText))
-		lastIdx = absIdx + queryLen
+	last := 0
+	for _, r := range ranges {
+		result.WriteString(line[last:r[0]])
+		result.WriteString(searchHighlightStyle.Render(line[r[0]:r[1]]))
+		last = r[1]
 	}
-
+	result.WriteString(line[last:])
 	return result.String()
 }
 
@@ -142,13 +330,15 @@ func (s *searchModel) renderSearchBar(width int) string {
 		}
 		inputView := s.input.View()
 		countText := ""
-		if s.query != "" {
+		if s.pending {
+			countText = searchCountStyle.Render(" searching...")
+		} else if s.query != "" {
 			countText = searchCountStyle.Render(fmt.Sprintf(" %d matches", s.matchCount))
 		}
 		bar = inputView + countText
 
 	case searchNavigate:
-		queryDisplay := searchPromptStyle.Render("/") +
+		queryDisplay := searchPromptStyle.Render(s.prompt()) +
 			lipgloss.NewStyle().Foreground(colorWhite).Render(s.query)
 		countText := ""
 		if s.matchCount > 0 {
@@ -157,7 +347,7 @@ func (s *searchModel) renderSearchBar(width int) string {
 		} else {
 			countText = searchCountStyle.Render(" [no matches]")
 		}
-		navHint := searchCountStyle.Render("  n:next N:prev esc:close")
+		navHint := searchCountStyle.Render("  n:next N:prev alt+c:case alt+m:mode esc:close")
 		bar = queryDisplay + countText + navHint
 	}
 
@@ -177,3 +367,76 @@ func (s *searchModel) update(msg tea.KeyMsg) tea.Cmd {
 
 	return cmd
 }
+
+// debounce marks the filter as stale and schedules a searchDebounceMsg. Only
+// the msg from the latest keystroke settles the search.
+func (s *searchModel) debounce() tea.Cmd {
+	s.seq++
+	s.pending = true
+	seq := s.seq
+	return tea.Tick(searchDebounce, func(time.Time) tea.Msg {
+		return searchDebounceMsg{seq: seq}
+	})
+}
+
+// settle reports whether msg is the latest debounce and the filter should run now
+func (s *searchModel) settle(msg searchDebounceMsg) bool {
+	if !s.pending || msg.seq != s.seq {
+		return false
+	}
+	s.pending = false
+	return true
+}
+
+// needsDebounce reports whether a buffer of n lines is large enough that
+// filtering should wait for a pause in typing
+func needsDebounce(n int) bool {
+	return n >= searchDebounceLines
+}
+
+// toggleOption handles the match option keys: alt+c switches case
+// sensitivity, alt+m cycles substring → word → fuzzy. The caller re-applies
+// the filter.
+func (s *searchModel) toggleOption(key string) {
+	switch key {
+	case "alt+c":
+		s.caseSensitive = !s.caseSensitive
+	case "alt+m":
+		s.kind = (s.kind + 1) % matchKindCount
+	}
+	s.input.Prompt = s.prompt()
+}
+
+// matcher returns a lineMatcher for the current query and options
+func (s *searchModel) matcher() lineMatcher {
+	return newLineMatcher(s.query, s.caseSensitive, s.kind)
+}
+
+// prompt is the search bar prompt, tagged with the non-default options:
+// "/" by default, "[word Aa]/" for case-sensitive whole-word matching
+func (s *searchModel) prompt() string {
+	var tags []string
+	if label := s.kind.label(); label != "" {
+		tags = append(tags, label)
+	}
+	if s.caseSensitive {
+		tags = append(tags, "Aa")
+	}
+	if len(tags) == 0 {
+		return "/"
+	}
+	return "[" + strings.Join(tags, " ") + "]/"
+}
+
+// clampMatch keeps currentMatch within the match count after a re-filter
+func (s *searchModel) clampMatch() {
+	if s.mode != searchNavigate {
+		return
+	}
+	if s.currentMatch > s.matchCount {
+		s.currentMatch = s.matchCount
+	}
+	if s.currentMatch == 0 && s.matchCount > 0 {
+		s.currentMatch = 1
+	}
+}
@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestFilterAndHighlight_CaseSensitivity(t *testing.T) {
+	lines := []string{"FooBar ready", "foobar retry", "no match", "FooBar FooBar"}
+
+	filtered, count := filterAndHighlight(lines, newLineMatcher("FooBar", false, matchSubstring))
+	if len(filtered) != 3 || count != 4 {
+		t.Errorf("insensitive: %d lines, %d matches, want 3/4", len(filtered), count)
+	}
+
+	filtered, count = filterAndHighlight(lines, newLineMatcher("FooBar", true, matchSubstring))
+	if len(filtered) != 2 || count != 3 {
+		t.Errorf("sensitive: %d lines, %d matches, want 2/3", len(filtered), count)
+	}
+	if got := ansi.Strip(filtered[0]); got != "FooBar ready" {
+		t.Errorf("highlighting changed the text: %q", got)
+	}
+}
+
+func TestSearchToggleCase(t *testing.T) {
+	s := newSearchModel()
+	s.activate()
+	if s.input.Prompt != "/" {
+		t.Errorf("default prompt = %q", s.input.Prompt)
+	}
+	s.toggleOption("alt+c")
+	if !s.caseSensitive || s.input.Prompt != "[Aa]/" {
+		t.Errorf("after toggle: caseSensitive=%v prompt=%q", s.caseSensitive, s.input.Prompt)
+	}
+	s.deactivate()
+	if !s.caseSensitive {
+		t.Error("case sensitivity should survive closing the search")
+	}
+}
+
+func TestLineMatcher_Kinds(t *testing.T) {
+	tests := []struct {
+		name  string
+		kind  matchKind
+		query string
+		line  string
+		want  string // matched text, ranges joined by "|"
+	}{
+		{"substring inside word", matchSubstring, "port", "import port", "port|port"},
+		{"whole word skips import", matchWholeWord, "port", "import port=3000", "port"},
+		{"whole word no match", matchWholeWord, "port", "import reports", ""},
+		{"whole word quotes regexp", matchWholeWord, "a.b", "axb a.b", "a.b"},
+		{"fuzzy subsequence", matchFuzzy, "ecr", "ECONNREFUSED", "EC|R"},
+		{"fuzzy merges runs", matchFuzzy, "conn", "ECONNREFUSED", "CONN"},
+		{"fuzzy out of order", matchFuzzy, "rc", "crash", ""},
+		{"whole word in colored line", matchWholeWord, "error", "\x1b[31mERROR\x1b[0m error", "ERROR|error"},
+		{"whole word no boundary from escape", matchWholeWord, "m", "\x1b[31mmodule\x1b[0m", ""},
+		{"whole word ignores escape params", matchWholeWord, "31m", "\x1b[31mred\x1b[0m", ""},
+		{"substring ignores escape params", matchSubstring, "0m", "\x1b[31mred\x1b[0m", ""},
+		{"fuzzy skips escapes", matchFuzzy, "mo", "\x1b[31mmodule\x1b[0m", "mo"},
+		{"fuzzy missing char", matchFuzzy, "xyz", "xylophone", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lm := newLineMatcher(tt.query, false, tt.kind)
+			var parts []string
+			for _, r := range lm.ranges(tt.line) {
+				parts = append(parts, tt.line[r[0]:r[1]])
+			}
+			if got := strings.Join(parts, "|"); got != tt.want {
+				t.Errorf("ranges(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterAndHighlight_FuzzyCountsLines(t *testing.T) {
+	lines := []string{"connection refused", "cr", "nothing"}
+	filtered, count := filterAndHighlight(lines, newLineMatcher("cr", false, matchFuzzy))
+	if len(filtered) != 2 || count != 2 {
+		t.Errorf("got %d lines, %d matches, want 2/2", len(filtered), count)
+	}
+}
+
+func TestSearchCycleKindPrompt(t *testing.T) {
+	s := newSearchModel()
+	want := []string{"[word]/", "[fuzzy]/", "/"}
+	for _, w := range want {
+		s.toggleOption("alt+m")
+		if s.input.Prompt != w {
+			t.Errorf("prompt = %q, want %q", s.input.Prompt, w)
+		}
+	}
+	s.toggleOption("alt+m")
+	s.toggleOption("alt+c")
+	if s.input.Prompt != "[word Aa]/" {
+		t.Errorf("combined prompt = %q", s.input.Prompt)
+	}
+}
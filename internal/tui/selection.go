@@ -2,10 +2,12 @@ package tui
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/viewport"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 // selectionMode tracks the visual selection state
@@ -23,6 +25,7 @@ const (
 	selActionNone   selectionAction = iota
 	selActionMoved                          // cursor moved — re-apply viewport
 	selActionCopy                           // y pressed — copy and exit
+	selActionCopyStay                       // Y pressed — copy and keep selecting
 	selActionCancel                         // esc pressed — exit
 )
 
@@ -32,13 +35,32 @@ type selectionModel struct {
 	anchor      int      // line where selection started
 	cursor      int      // current cursor position
 	totalLines  int
-	frozenLines []string // snapshot of wrapped content at activation
+	frozenLines []string // snapshot of wrapped content at activation (one entry per visual row)
+	sourceLines []string // logical (unwrapped) lines the rows were wrapped from
+	sourcePads  []string // indent wrap added to each source line's continuation rows
+	rowSource   []int    // index into sourceLines for each visual row
+	copied      int      // lines copied by the last Y, shown until the next key
 }
 
-// activate freezes the current viewport content and starts selection at the current offset
-func (s *selectionModel) activate(vp viewport.Model, wrappedContent string) {
+// activate freezes the content and starts selection at the current offset.
+// Each logical line is wrapped separately with wrap so that visual rows can be
+// mapped back to the line they came from when copying; pad returns the indent
+// wrap puts before a line's continuation rows (nil for none).
+func (s *selectionModel) activate(vp viewport.Model, content string, wrap, pad func(string) string) {
 	s.mode = selectionActive
-	s.frozenLines = strings.Split(wrappedContent, "\n")
+	s.sourceLines = strings.Split(content, "\n")
+	s.sourcePads = make([]string, len(s.sourceLines))
+	s.frozenLines = s.frozenLines[:0]
+	s.rowSource = s.rowSource[:0]
+	for i, line := range s.sourceLines {
+		if pad != nil {
+			s.sourcePads[i] = pad(line)
+		}
+		for _, row := range strings.Split(wrap(line), "\n") {
+			s.frozenLines = append(s.frozenLines, row)
+			s.rowSource = append(s.rowSource, i)
+		}
+	}
 	s.totalLines = len(s.frozenLines)
 	s.anchor = vp.YOffset
 	s.cursor = vp.YOffset
@@ -51,6 +73,10 @@ func (s *selectionModel) deactivate() {
 	s.cursor = 0
 	s.totalLines = 0
 	s.frozenLines = nil
+	s.sourceLines = nil
+	s.sourcePads = nil
+	s.rowSource = nil
+	s.copied = 0
 }
 
 // isActive returns true when visual selection is in progress
@@ -61,6 +87,7 @@ func (s *selectionModel) isActive() bool {
 // handleKey processes a key press during selection and returns the action to take.
 // vpHeight is the visible viewport height for page-scroll calculations.
 func (s *selectionModel) handleKey(key string, vpHeight int) selectionAction {
+	s.copied = 0
 	switch key {
 	case "j", "down":
 		if s.cursor < s.totalLines-1 {
@@ -92,6 +119,8 @@ func (s *selectionModel) handleKey(key string, vpHeight int) selectionAction {
 		return selActionMoved
 	case "y":
 		return selActionCopy
+	case "Y":
+		return selActionCopyStay
 	case "esc":
 		return selActionCancel
 	}
@@ -130,25 +159,112 @@ func (s *selectionModel) applyToViewport(vp *viewport.Model) {
 	}
 }
 
-// selectedText returns the raw (un-highlighted) text of the selected lines
+// selectedText returns the raw (un-highlighted) text of the selected lines.
+// Rows wrapped from the same logical line are joined back without newlines;
+// a fully selected logical line is copied as-is, a partially selected one as
+// the plain-text span covered by the selected rows.
 func (s *selectionModel) selectedText() string {
 	if s.frozenLines == nil {
 		return ""
 	}
 	minL, maxL := s.selRange()
-	return strings.Join(s.frozenLines[minL:maxL+1], "\n")
+	if len(s.rowSource) != len(s.frozenLines) {
+		return strings.Join(s.frozenLines[minL:maxL+1], "\n")
+	}
+
+	var out []string
+	for row := minL; row <= maxL; {
+		src := s.rowSource[row]
+		first, last := s.sourceRows(src)
+		end := last
+		if end > maxL {
+			end = maxL
+		}
+		if row == first && end == last {
+			out = append(out, s.sourceLines[src])
+		} else {
+			out = append(out, fragmentSpan(s.sourceLines[src], s.sourcePads[src], s.frozenLines[first:last+1], row-first, end-first))
+		}
+		row = end + 1
+	}
+	return strings.Join(out, "\n")
+}
+
+// copyStay returns the selected text and its line count for copying without
+// leaving selection mode, and has the status bar confirm the copy
+func (s *selectionModel) copyStay() (string, int) {
+	s.copied = s.selectedLineCount()
+	return s.selectedText(), s.copied
+}
+
+// cursorLine returns the logical line under the selection cursor
+func (s *selectionModel) cursorLine() string {
+	if s.cursor < 0 || s.cursor >= len(s.frozenLines) {
+		return ""
+	}
+	if len(s.rowSource) == len(s.frozenLines) {
+		return s.sourceLines[s.rowSource[s.cursor]]
+	}
+	return s.frozenLines[s.cursor]
 }
 
-// selectedLineCount returns the number of selected lines
+// selectedLineCount returns the number of logical lines touched by the selection
 func (s *selectionModel) selectedLineCount() int {
 	minL, maxL := s.selRange()
-	return maxL - minL + 1
+	if len(s.rowSource) != len(s.frozenLines) || maxL < minL {
+		return maxL - minL + 1
+	}
+	return s.rowSource[maxL] - s.rowSource[minL] + 1
+}
+
+// sourceRows returns the first and last visual rows wrapped from logical line src
+func (s *selectionModel) sourceRows(src int) (int, int) {
+	first := sort.SearchInts(s.rowSource, src)
+	last := sort.SearchInts(s.rowSource, src+1) - 1
+	return first, last
+}
+
+// fragmentSpan returns the plain text of line covered by rows[from..to], where
+// rows are the wrapped fragments of line. Fragments are located in the stripped
+// line so that spaces dropped at wrap points are restored; pad, the hanging
+// indent added to continuation rows, is not part of the line and is skipped.
+func fragmentSpan(line, pad string, rows []string, from, to int) string {
+	plain := ansi.Strip(line)
+	pos := 0
+	start, end := -1, -1
+	for i, row := range rows {
+		frag := ansi.Strip(row)
+		if i > 0 {
+			frag = strings.TrimPrefix(frag, pad)
+		}
+		idx := strings.Index(plain[pos:], frag)
+		if idx < 0 {
+			// Wrapping altered the text in a way we can't map; fall back to the fragments
+			var parts []string
+			for _, r := range rows[from : to+1] {
+				parts = append(parts, ansi.Strip(r))
+			}
+			return strings.Join(parts, "")
+		}
+		if i == from {
+			start = pos + idx
+		}
+		pos += idx + len(frag)
+		if i == to {
+			end = pos
+			break
+		}
+	}
+	return plain[start:end]
 }
 
 // renderStatusBar renders the selection status bar
 func (s *selectionModel) renderStatusBar(width int) string {
-	count := s.selectedLineCount()
-	text := fmt.Sprintf(" VISUAL: %d lines | j/k:move G/g:top/bottom y:copy Esc:cancel", count)
+	count := fmt.Sprintf("%d lines", s.selectedLineCount())
+	if s.copied > 0 {
+		count += fmt.Sprintf(", %d copied", s.copied)
+	}
+	text := fmt.Sprintf(" VISUAL: %s | j/k:move G/g:top/bottom y:copy Y:copy and stay o:open file Esc:cancel", count)
 	return selectionBarStyle.Width(width).Render(text)
 }
 
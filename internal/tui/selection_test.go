@@ -0,0 +1,120 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// activateAt starts a selection with the given wrap width and selects rows [from, to]
+func activateAt(content string, width, from, to int) selectionModel {
+	var s selectionModel
+	vp := viewport.New(width, 10)
+	s.activate(vp, content, func(line string) string {
+		return wrapLogContent(line, width)
+	}, nil)
+	s.anchor = from
+	s.cursor = to
+	return s
+}
+
+func TestSelection_LongLineFullySelected(t *testing.T) {
+	long := strings.Repeat("abcdefghij", 5000) // 50KB minified-style line
+	content := "before\n" + long + "\nafter"
+	s := activateAt(content, 80, 0, 0)
+
+	rows := s.totalLines
+	if rows < 600 {
+		t.Fatalf("expected the long line to wrap to many rows, got %d", rows)
+	}
+
+	s.cursor = rows - 1
+	if got := s.selectedText(); got != content {
+		t.Errorf("full selection should round-trip the content (got %d bytes, want %d)", len(got), len(content))
+	}
+	if n := s.selectedLineCount(); n != 3 {
+		t.Errorf("selectedLineCount = %d, want 3", n)
+	}
+}
+
+func TestSelection_PartOfLongLineHasNoSpuriousNewlines(t *testing.T) {
+	long := strings.Repeat("abcdefghij", 100)
+	s := activateAt("head\n"+long, 40, 2, 5) // rows 1..25 belong to the long line
+
+	got := s.selectedText()
+	if strings.Contains(got, "\n") {
+		t.Errorf("fragments of one line should not be joined with newlines: %q", got)
+	}
+	if got != long[40:200] {
+		t.Errorf("selectedText = %q, want %q", got, long[40:200])
+	}
+	if n := s.selectedLineCount(); n != 1 {
+		t.Errorf("selectedLineCount = %d, want 1", n)
+	}
+}
+
+func TestSelection_PartialWordWrappedLineKeepsSpaces(t *testing.T) {
+	line := strings.TrimSpace(strings.Repeat("lorem ipsum dolor ", 20))
+	s := activateAt(line+"\nnext", 30, 1, 3)
+
+	got := s.selectedText()
+	if !strings.Contains(line, got) {
+		t.Errorf("partial selection %q is not a substring of the source line", got)
+	}
+	if strings.Contains(got, "ipsumdolor") || strings.Contains(got, "loremipsum") || strings.Contains(got, "dolorlorem") {
+		t.Errorf("spaces at wrap points were lost: %q", got)
+	}
+}
+
+func TestSelection_SpanAcrossLinesEndsMidLine(t *testing.T) {
+	long := "\x1b[31m" + strings.Repeat("x", 100) + "\x1b[0m"
+	s := activateAt("first\n"+long, 40, 0, 1)
+
+	got := s.selectedText()
+	want := "first\n" + strings.Repeat("x", 40)
+	if ansi.Strip(got) != want {
+		t.Errorf("selectedText = %q, want %q", got, want)
+	}
+}
+
+func TestSelection_CopyStayKeepsSelection(t *testing.T) {
+	s := activateAt("one\ntwo\nthree", 80, 0, 1)
+
+	if action := s.handleKey("Y", 10); action != selActionCopyStay {
+		t.Fatalf("Y = %v, want selActionCopyStay", action)
+	}
+	text, count := s.copyStay()
+	if text != "one\ntwo" || count != 2 {
+		t.Errorf("copyStay = %q, %d; want \"one\\ntwo\", 2", text, count)
+	}
+	if !s.isActive() {
+		t.Fatal("selection ended after copyStay")
+	}
+	if bar := ansi.Strip(s.renderStatusBar(120)); !strings.Contains(bar, "2 lines, 2 copied") {
+		t.Errorf("status bar doesn't confirm the copy: %q", bar)
+	}
+
+	// Extending the selection drops the confirmation; the anchor stays
+	s.handleKey("j", 10)
+	if bar := ansi.Strip(s.renderStatusBar(120)); strings.Contains(bar, "copied") {
+		t.Errorf("confirmation kept after moving: %q", bar)
+	}
+	if text, _ := s.copyStay(); text != "one\ntwo\nthree" {
+		t.Errorf("copy after extending = %q", text)
+	}
+}
+
+func TestFragmentSpan_TrimsOnlyTheAddedIndent(t *testing.T) {
+	line := "abcde   fgh"
+
+	// No indent added: the continuation row's own spaces are part of the line
+	if got := fragmentSpan(line, "", []string{"abcde", "   fgh"}, 1, 1); got != "   fgh" {
+		t.Errorf("without indent: got %q, want %q", got, "   fgh")
+	}
+	// A two-space hanging indent goes, the line's spaces after it stay
+	if got := fragmentSpan(line, "  ", []string{"abcde", "     fgh"}, 1, 1); got != "   fgh" {
+		t.Errorf("with indent: got %q, want %q", got, "   fgh")
+	}
+}
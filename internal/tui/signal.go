@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+)
+
+// signalChosenMsg asks the app to send devdash.Signals[index] to session
+type signalChosenMsg struct {
+	session string
+	index   int
+}
+
+// signalCancelledMsg closes the signal menu without sending anything
+type signalCancelledMsg struct{}
+
+// signalMenuModel is the "send signal" overlay: a pick list of devdash.Signals
+type signalMenuModel struct {
+	session string
+	cursor  int
+	width   int
+	height  int
+}
+
+// newSignalMenuModel creates the menu for session with the first signal selected
+func newSignalMenuModel(session string) signalMenuModel {
+	return signalMenuModel{session: session}
+}
+
+// Update handles menu navigation
+func (m signalMenuModel) Update(msg tea.KeyMsg) (signalMenuModel, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(devdash.Signals)-1 {
+			m.cursor++
+		}
+	case "enter":
+		chosen := signalChosenMsg{session: m.session, index: m.cursor}
+		return m, func() tea.Msg { return chosen }
+	case "esc", "q":
+		return m, func() tea.Msg { return signalCancelledMsg{} }
+	}
+	return m, nil
+}
+
+// View renders the menu
+func (m signalMenuModel) View() string {
+	var rows []string
+	for i, s := range devdash.Signals {
+		cursor, style := "  ", normalItemStyle
+		if i == m.cursor {
+			cursor, style = "> ", selectedItemStyle
+		}
+		rows = append(rows, cursor+style.Render(fmt.Sprintf("%-8s", s.Name))+"  "+dimStyle.Render(s.Hint))
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		modalTitleStyle.Render("Send signal to "+m.session),
+		"",
+		strings.Join(rows, "\n"),
+		"",
+		dimStyle.Render("j/k:move  enter:send  esc:cancel"),
+	)
+
+	popup := modalStyle.Width(64).Render(content)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, popup)
+}
+
+// SetSize updates dimensions for centering
+func (m *signalMenuModel) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+}
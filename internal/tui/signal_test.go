@@ -0,0 +1,30 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+)
+
+func TestSignalMenu(t *testing.T) {
+	m := newSignalMenuModel("api")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp}) // already at the top
+	for i := 0; i < len(devdash.Signals)+2; i++ {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	msg, ok := cmd().(signalChosenMsg)
+	if want := len(devdash.Signals) - 2; !ok || msg.session != "api" || msg.index != want {
+		t.Errorf("chose %+v, want index %d", msg, want)
+	}
+
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc}); cmd == nil {
+		t.Fatal("esc should cancel")
+	} else if _, ok := cmd().(signalCancelledMsg); !ok {
+		t.Error("esc should cancel")
+	}
+}
@@ -0,0 +1,259 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+)
+
+// splitModel shows the logs of two sessions side by side, e.g. to follow a
+// request through two services. Keys scroll the focused pane; with sync on,
+// the other pane follows it to the same row offset.
+type splitModel struct {
+	panes  [2]logViewModel
+	focus  int  // index of the pane keys go to
+	sync   bool // scroll both panes together
+	width  int
+	height int
+}
+
+// newSplitModel creates the split view of left and right
+func newSplitModel(left, right *devdash.RunningProcess, wrapIndent bool) splitModel {
+	var m splitModel
+	for i, rp := range []*devdash.RunningProcess{left, right} {
+		m.panes[i] = newLogViewModel(rp)
+		m.panes[i].wrapIndent = wrapIndent
+	}
+	return m
+}
+
+// paneWidths splits the width between the panes, less the divider column
+func (m splitModel) paneWidths() (left, right int) {
+	inner := max(m.width-1, 2)
+	return inner / 2, inner - inner/2
+}
+
+// SetSize lays the panes out side by side; each gets a title row above its
+// log and the shared help bar below
+func (m *splitModel) SetSize(w, h int) {
+	m.width, m.height = w, h
+	lw, rw := m.paneWidths()
+	for i, pw := range []int{lw, rw} {
+		if m.panes[i].ready {
+			m.panes[i].SetSize(pw, h)
+		} else {
+			m.panes[i], _ = m.panes[i].Update(tea.WindowSizeMsg{Width: pw, Height: h})
+		}
+		m.panes[i].refreshLogViewport()
+	}
+}
+
+// Subscribe starts following both panes' logs
+func (m *splitModel) Subscribe() tea.Cmd {
+	return tea.Batch(m.panes[0].Subscribe(), m.panes[1].Subscribe())
+}
+
+// Unsubscribe stops following both panes' logs
+func (m *splitModel) Unsubscribe() {
+	m.panes[0].Unsubscribe()
+	m.panes[1].Unsubscribe()
+}
+
+// Update routes log lines to the pane showing their session
+func (m splitModel) Update(msg tea.Msg) (splitModel, tea.Cmd) {
+	var cmds []tea.Cmd
+	for i := range m.panes {
+		var cmd tea.Cmd
+		m.panes[i], cmd = m.panes[i].Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	if _, ok := msg.(LogLineMsg); ok && m.sync {
+		m.follow()
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// scroll passes a scrolling key to the focused pane and, with sync on, moves
+// the other pane along. Returns false for keys that don't scroll.
+func (m *splitModel) scroll(msg tea.KeyMsg) bool {
+	switch msg.String() {
+	case "up", "down", "k", "j", "pgup", "pgdown", "home", "end",
+		"ctrl+d", "ctrl+u", "ctrl+f", "ctrl+b", "g", "G":
+	default:
+		return false
+	}
+	m.panes[m.focus], _ = m.panes[m.focus].Update(msg)
+	if m.sync {
+		m.follow()
+	}
+	return true
+}
+
+// follow brings the unfocused pane to the focused one's row offset, or to
+// its bottom while the focused pane follows new output. Panes that wrap
+// differently drift apart; matching rows is as close as it gets.
+func (m *splitModel) follow() {
+	lead, other := &m.panes[m.focus], &m.panes[1-m.focus]
+	other.autoScroll = lead.autoScroll
+	if lead.autoScroll {
+		other.viewport.GotoBottom()
+		return
+	}
+	other.viewport.SetYOffset(lead.viewport.YOffset)
+}
+
+// toggleSync turns synced scrolling on, lining the panes up, or off
+func (m *splitModel) toggleSync() {
+	m.sync = !m.sync
+	if m.sync {
+		m.follow()
+	}
+}
+
+// showSession switches the focused pane to rp, keeping its layout
+func (m *splitModel) showSession(rp *devdash.RunningProcess) tea.Cmd {
+	pane := &m.panes[m.focus]
+	pane.Unsubscribe()
+	next := newLogViewModel(rp)
+	next.wrapIndent = pane.wrapIndent
+	next.clock, next.runner = pane.clock, pane.runner
+	*pane = next
+	m.SetSize(m.width, m.height)
+	if m.sync {
+		m.follow()
+	}
+	return pane.Subscribe()
+}
+
+// View renders the panes side by side with a divider between them
+func (m splitModel) View(feedback string) string {
+	lw, rw := m.paneWidths()
+	left := m.renderPane(0, lw)
+	right := m.renderPane(1, rw)
+	rows := lipgloss.Height(left)
+	divider := dimStyle.Render(strings.TrimSuffix(strings.Repeat("│\n", rows), "\n"))
+	body := lipgloss.JoinHorizontal(lipgloss.Top, left, divider, right)
+	return lipgloss.JoinVertical(lipgloss.Left, body, m.renderHelpBar(feedback))
+}
+
+// renderPane renders pane i's title row over its log, the focused pane's
+// title highlighted
+func (m splitModel) renderPane(i, width int) string {
+	p := m.panes[i]
+	title := fmt.Sprintf(" %s (:%d)", p.sessionName, p.port)
+	if p.logBuf != nil && p.logBuf.HasMark() {
+		title += " [since mark]"
+	}
+	title = ansi.Truncate(title, width, "…")
+	if i == m.focus {
+		title = titleStyle.Render(title)
+	} else {
+		title = dimStyle.Render(title)
+	}
+	body := lipgloss.NewStyle().Width(width).Render(p.viewport.View())
+	return lipgloss.JoinVertical(lipgloss.Left, title, body)
+}
+
+// renderHelpBar renders the split view's key hints
+func (m splitModel) renderHelpBar(feedback string) string {
+	sync := "sync scroll"
+	if m.sync {
+		sync = "unsync scroll"
+	}
+	keys := []struct{ key, desc string }{
+		{"tab", "other pane"},
+		{"[/]", "session"},
+		{"s", sync},
+		{"G/g", "bottom/top"},
+		{"^d/^u", "half page"},
+		{"q", "back"},
+	}
+	var parts []string
+	for _, k := range keys {
+		parts = append(parts, helpKeyStyle.Render(k.key)+":"+helpDescStyle.Render(k.desc))
+	}
+	if feedback != "" {
+		parts = append(parts, helpKeyStyle.Render(feedback))
+	}
+	return helpStyle.Width(m.width).MaxHeight(1).Render(strings.Join(parts, "  "))
+}
+
+// showSplit switches from the dashboard to the split view: the selected
+// session on the left, the next one in the list on the right
+func (a App) showSplit() (App, tea.Cmd) {
+	procs := a.dashboard.processes
+	if len(procs) < 2 {
+		return a, feedbackCmd("[Split view needs two sessions]")
+	}
+	sel := min(max(a.dashboard.selected, 0), len(procs)-1)
+	a.dashboard.unsubscribeLogs()
+	a.split = newSplitModel(procs[sel], procs[(sel+1)%len(procs)], a.dashboard.wrapIndent)
+	for i := range a.split.panes {
+		a.split.panes[i].clock, a.split.panes[i].runner = a.dashboard.clock, a.dashboard.runner
+	}
+	a.split.SetSize(a.width, a.height)
+	a.view = viewSplit
+	return a, a.split.Subscribe()
+}
+
+// leaveSplit returns to the dashboard with the focused pane's session selected
+func (a App) leaveSplit() (App, tea.Cmd) {
+	a.split.Unsubscribe()
+	a.dashboard.selectByName(a.split.panes[a.split.focus].sessionName)
+	a.view = viewDashboard
+	return a, a.dashboard.SubscribeToSelected()
+}
+
+// cycleSplitSession switches the focused pane to the next (step 1) or
+// previous (step -1) session in the dashboard list, skipping the one the
+// other pane shows
+func (a App) cycleSplitSession(step int) (App, tea.Cmd) {
+	procs := a.dashboard.processes
+	if len(procs) < 2 {
+		return a, nil
+	}
+	cur, other := a.split.panes[a.split.focus].sessionName, a.split.panes[1-a.split.focus].sessionName
+	idx := 0
+	for i, rp := range procs {
+		if rp.Info.Name == cur {
+			idx = i
+		}
+	}
+	for range procs {
+		idx = (idx + step + len(procs)) % len(procs)
+		if name := procs[idx].Info.Name; name != other && name != cur {
+			return a, a.split.showSession(procs[idx])
+		}
+	}
+	return a, nil
+}
+
+// updateSplitKeys handles key events on the split view
+func (a App) updateSplitKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc", "V":
+		return a.leaveSplit()
+	case "ctrl+c":
+		return a, tea.Quit
+	case "tab", "shift+tab":
+		a.split.focus = 1 - a.split.focus
+		return a, nil
+	case "]":
+		return a.cycleSplitSession(1)
+	case "[":
+		return a.cycleSplitSession(-1)
+	case "s":
+		a.split.toggleSync()
+		if a.split.sync {
+			return a, feedbackCmd("[Panes scroll together]")
+		}
+		return a, feedbackCmd("[Panes scroll separately]")
+	}
+	a.split.scroll(msg)
+	return a, nil
+}
@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+	"github.com/kimaguri/simplx-toolkit/internal/process"
+)
+
+func TestSplitView(t *testing.T) {
+	var procs []*devdash.RunningProcess
+	for _, name := range []string{"a", "b", "c"} {
+		buf := process.NewLogBuffer(500)
+		for i := 0; i < 100; i++ {
+			fmt.Fprintf(buf, "%s line %d\n", name, i)
+		}
+		procs = append(procs, &devdash.RunningProcess{Info: devdash.SessionInfo{Name: name}, Status: devdash.StatusRunning, LogBuf: buf})
+	}
+	a := App{dashboard: newDashboardModel(), width: 100, height: 30}
+	a.dashboard.SetProcesses(procs)
+
+	key := func(k string) {
+		t.Helper()
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		if k == "tab" {
+			msg = tea.KeyMsg{Type: tea.KeyTab}
+		}
+		var model tea.Model
+		if a.view == viewSplit {
+			model, _ = a.updateSplitKeys(msg)
+		} else {
+			model, _ = a.updateDashboardKeys(msg)
+		}
+		a = model.(App)
+	}
+
+	key("V")
+	if a.view != viewSplit || a.split.panes[0].sessionName != "a" || a.split.panes[1].sessionName != "b" {
+		t.Fatalf("V should split the selected session and the next, got view %d", a.view)
+	}
+	if rows := strings.Count(a.View(), "\n") + 1; rows != a.height {
+		t.Errorf("split view is %d rows, want the terminal height %d", rows, a.height)
+	}
+
+	// ] picks the focused pane's session, skipping the other pane's
+	key("tab")
+	key("]")
+	if got := a.split.panes[1].sessionName; got != "c" {
+		t.Errorf("right pane shows %q after ], want c", got)
+	}
+	key("]")
+	if got := a.split.panes[1].sessionName; got != "b" {
+		t.Errorf("right pane shows %q after ] again, want b (a is on the left)", got)
+	}
+
+	// With sync on, scrolling one pane moves the other
+	key("s")
+	key("g")
+	if off := a.split.panes[0].viewport.YOffset; off != 0 || a.split.panes[0].autoScroll {
+		t.Errorf("left pane at offset %d, want 0 with auto-scroll off", off)
+	}
+	key("j")
+	if l, r := a.split.panes[0].viewport.YOffset, a.split.panes[1].viewport.YOffset; l != 1 || r != 1 {
+		t.Errorf("offsets = %d, %d after j; want both 1", l, r)
+	}
+	key("s")
+	key("j")
+	if l, r := a.split.panes[0].viewport.YOffset, a.split.panes[1].viewport.YOffset; l != 1 || r != 2 {
+		t.Errorf("offsets = %d, %d without sync; want 1, 2", l, r)
+	}
+
+	key("q")
+	if a.view != viewDashboard || a.dashboard.SelectedProcess().Info.Name != "b" {
+		t.Errorf("leaving should select the focused pane's session, got view %d", a.view)
+	}
+}
+
+func TestSplitView_NeedsTwoSessions(t *testing.T) {
+	a := App{dashboard: newDashboardModel(), width: 100, height: 30}
+	a.dashboard.SetProcesses([]*devdash.RunningProcess{{Info: devdash.SessionInfo{Name: "a"}, LogBuf: process.NewLogBuffer(10)}})
+	model, _ := a.updateDashboardKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'V'}})
+	if model.(App).view != viewDashboard {
+		t.Error("V with one session should stay on the dashboard")
+	}
+}
@@ -0,0 +1,69 @@
+package tui
+
+import "github.com/kimaguri/simplx-toolkit/internal/devdash"
+
+// statusFilter narrows the session list by process state. It only affects
+// what is listed: hidden sessions keep running (or stay stopped) as before.
+type statusFilter int
+
+const (
+	showAll        statusFilter = iota // every session
+	showRunning                        // running sessions only
+	showNotRunning                     // stopped and errored sessions only
+)
+
+// next returns the filter after f in the h cycle: all, running, not running
+func (f statusFilter) next() statusFilter {
+	return (f + 1) % 3
+}
+
+// label names the filter in the list title; "" for all sessions
+func (f statusFilter) label() string {
+	switch f {
+	case showRunning:
+		return "running"
+	case showNotRunning:
+		return "not running"
+	}
+	return ""
+}
+
+// emptyText is shown in place of the list when the filter hides every session
+func (f statusFilter) emptyText() string {
+	if f == showNotRunning {
+		return "No stopped or errored sessions"
+	}
+	return "No running sessions"
+}
+
+// allows reports whether rp is listed under the filter
+func (f statusFilter) allows(rp *devdash.RunningProcess) bool {
+	switch f {
+	case showRunning:
+		return rp.Status == devdash.StatusRunning
+	case showNotRunning:
+		return rp.Status != devdash.StatusRunning
+	}
+	return true
+}
+
+// filterByStatus returns the sessions the filter allows
+func filterByStatus(procs []*devdash.RunningProcess, f statusFilter) []*devdash.RunningProcess {
+	if f == showAll {
+		return procs
+	}
+	var out []*devdash.RunningProcess
+	for _, rp := range procs {
+		if f.allows(rp) {
+			out = append(out, rp)
+		}
+	}
+	return out
+}
+
+// cycleStatusFilter moves to the next status filter. The selected session
+// stays selected if it is still listed.
+func (m *dashboardModel) cycleStatusFilter() {
+	m.statusFilter = m.statusFilter.next()
+	m.SetProcesses(m.all)
+}
@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+)
+
+func TestDashboardStatusFilter(t *testing.T) {
+	newProc := func(name string, status devdash.ProcessStatus) *devdash.RunningProcess {
+		return &devdash.RunningProcess{Info: devdash.SessionInfo{Name: name}, Status: status}
+	}
+	api := newProc("api", devdash.StatusRunning)
+	docs := newProc("docs", devdash.StatusStopped)
+	web := newProc("web", devdash.StatusRunning)
+	worker := newProc("worker", devdash.StatusError)
+	procs := []*devdash.RunningProcess{web, worker, api, docs}
+
+	m := newDashboardModel()
+	m.SetProcesses(procs)
+	m.selected = 2 // web
+
+	m.cycleStatusFilter()
+	if m.statusFilter != showRunning || len(m.processes) != 2 {
+		t.Fatalf("running filter lists %d sessions", len(m.processes))
+	}
+	if sel := m.SelectedProcess(); sel == nil || sel.Info.Name != "web" {
+		t.Errorf("selection should stay on web, got %v", sel)
+	}
+	if view := m.renderSessionList(40, 10); !strings.Contains(view, "(running)") {
+		t.Errorf("title should name the filter:\n%s", view)
+	}
+
+	// api exits: web stays selected although it moves up a row
+	api.Status = devdash.StatusStopped
+	m.SetProcesses(procs)
+	if len(m.processes) != 1 || m.selected != 0 || m.SelectedProcess() != web {
+		t.Errorf("after api stopped: %d listed, selected %d", len(m.processes), m.selected)
+	}
+
+	m.cycleStatusFilter()
+	if m.statusFilter != showNotRunning || len(m.processes) != 3 {
+		t.Fatalf("not-running filter lists %d sessions", len(m.processes))
+	}
+	for _, rp := range m.processes {
+		if rp.Status == devdash.StatusRunning {
+			t.Errorf("%s is running but listed", rp.Info.Name)
+		}
+	}
+
+	m.cycleStatusFilter()
+	if m.statusFilter != showAll || len(m.processes) != 4 {
+		t.Errorf("filter should wrap to all, got %d with %d sessions", m.statusFilter, len(m.processes))
+	}
+}
+
+func TestStatusFilterEmptyList(t *testing.T) {
+	m := newDashboardModel()
+	m.SetProcesses([]*devdash.RunningProcess{{Info: devdash.SessionInfo{Name: "web"}, Status: devdash.StatusRunning}})
+	m.cycleStatusFilter()
+	m.cycleStatusFilter()
+	if m.SelectedProcess() != nil {
+		t.Fatal("nothing should be selected")
+	}
+	if view := m.renderSessionList(40, 10); !strings.Contains(view, "No stopped or errored sessions") {
+		t.Errorf("empty filtered list should say so:\n%s", view)
+	}
+}
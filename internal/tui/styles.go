@@ -1,6 +1,17 @@
 package tui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// monochrome is set by SetMonochrome; panel chrome that normally relies on
+// color to show focus uses reverse video instead
+var monochrome bool
 
 // Color palette
 var (
@@ -96,6 +107,11 @@ var (
 
 	normalItemStyle = lipgloss.NewStyle().
 			Foreground(colorDimWhite)
+
+	// Name of a session whose server just came up, briefly
+	readyFlashStyle = lipgloss.NewStyle().
+			Foreground(colorGreen).
+			Bold(true)
 )
 
 // Dim text
@@ -110,6 +126,14 @@ var portStyle = lipgloss.NewStyle().
 var ageStyle = lipgloss.NewStyle().
 	Foreground(colorGray)
 
+// Activity sparkline style
+var sparklineStyle = lipgloss.NewStyle().
+	Foreground(colorBlue)
+
+// Idle session marker style
+var idleStyle = lipgloss.NewStyle().
+	Foreground(colorYellow)
+
 // Section header style
 var sectionStyle = lipgloss.NewStyle().
 	Foreground(colorBlue).
@@ -151,3 +175,111 @@ var searchPromptStyle = lipgloss.NewStyle().
 // Tunnel URL style — cyan for active tunnel URLs
 var tunnelURLStyle = lipgloss.NewStyle().
 	Foreground(lipgloss.Color("#00CCCC"))
+
+// Pinned tunnel banner — bold on a blue band, padded to tunnelDockH rows
+var tunnelDockStyle = lipgloss.NewStyle().
+	Bold(true).
+	Foreground(colorWhite).
+	Background(lipgloss.Color("#1D4ED8")).
+	Align(lipgloss.Center).
+	Padding(1, 0)
+
+// Tag chip backgrounds; a tag always hashes to the same one
+var tagChipColors = []lipgloss.Color{"#5599FF", "#FF77AA", "#33CC99", "#FFAA00", "#AA88FF", "#00CCCC"}
+
+// tagChipStyle returns the chip style for a tag — dark text on its palette color
+func tagChipStyle(tag string) lipgloss.Style {
+	h := fnv.New32a()
+	h.Write([]byte(tag))
+	style := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#000000")).
+		Background(tagChipColors[h.Sum32()%uint32(len(tagChipColors))])
+	if monochrome {
+		style = style.Reverse(true)
+	}
+	return style
+}
+
+// SetMonochrome switches the TUI to plain output for NO_COLOR / --no-color.
+// Styles render with the basic ANSI profile, which unlike the ascii one keeps
+// text attributes, and App.View strips the colors from each frame. Styles
+// that only stood out by color fall back to bold, underline, and reverse
+// video. Status icons (*, -, !) already carry the meaning.
+func SetMonochrome() {
+	monochrome = true
+	lipgloss.SetColorProfile(termenv.ANSI)
+
+	statusError = statusError.Underline(true)
+	titleStyle = titleStyle.UnsetBackground()
+	helpKeyStyle = helpKeyStyle.Underline(true)
+	activeButtonStyle = activeButtonStyle.Reverse(true)
+	selectedItemStyle = selectedItemStyle.Underline(true)
+	readyFlashStyle = readyFlashStyle.Reverse(true)
+	tunnelDockStyle = tunnelDockStyle.Reverse(true)
+	sectionStyle = sectionStyle.Underline(true)
+	selectionHighlightStyle = selectionHighlightStyle.Reverse(true)
+	selectionCursorStyle = selectionCursorStyle.Reverse(true).Underline(true)
+	selectionBarStyle = selectionBarStyle.Reverse(true)
+	searchHighlightStyle = searchHighlightStyle.Reverse(true)
+}
+
+// stripColors removes the color parameters from the SGR sequences in s,
+// keeping attributes such as bold (1), underline (4) and reverse (7). A
+// sequence left with nothing to set is dropped; resets are kept.
+func stripColors(s string) string {
+	if !strings.Contains(s, "\x1b[") {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	for {
+		i := strings.Index(s, "\x1b[")
+		if i < 0 {
+			b.WriteString(s)
+			return b.String()
+		}
+		b.WriteString(s[:i])
+		s = s[i+2:]
+		// Parameters run until the final byte; only SGR ('m') is rewritten
+		j := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '?' })
+		if j < 0 || s[j] != 'm' {
+			b.WriteString("\x1b[")
+			continue
+		}
+		if params, ok := sgrWithoutColors(s[:j]); ok {
+			b.WriteString("\x1b[" + params + "m")
+		}
+		s = s[j+1:]
+	}
+}
+
+// sgrWithoutColors drops the color parameters from an SGR parameter list,
+// including the extended 38/48;5;n and 38/48;2;r;g;b forms. ok is false when
+// only colors were set.
+func sgrWithoutColors(params string) (string, bool) {
+	if params == "" {
+		return "", true // bare reset
+	}
+	fields := strings.Split(params, ";")
+	var kept []string
+	for i := 0; i < len(fields); i++ {
+		n, err := strconv.Atoi(fields[i])
+		switch {
+		case err != nil:
+			kept = append(kept, fields[i])
+		case n == 38 || n == 48 || n == 58:
+			if i+1 < len(fields) && fields[i+1] == "5" {
+				i += 2
+			} else if i+1 < len(fields) && fields[i+1] == "2" {
+				i += 4
+			}
+		case n >= 30 && n <= 39, n >= 40 && n <= 49, n >= 90 && n <= 97, n >= 100 && n <= 107:
+		default:
+			kept = append(kept, fields[i])
+		}
+	}
+	if len(kept) == 0 {
+		return "", false
+	}
+	return strings.Join(kept, ";"), true
+}
@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// setMonochrome turns monochrome on for a test, restoring the color styles after
+func setMonochrome(t *testing.T) {
+	t.Helper()
+	profile := lipgloss.ColorProfile()
+	styles := []*lipgloss.Style{
+		&statusError, &titleStyle, &helpKeyStyle, &activeButtonStyle, &selectedItemStyle,
+		&readyFlashStyle, &tunnelDockStyle, &sectionStyle, &selectionHighlightStyle,
+		&selectionCursorStyle, &selectionBarStyle, &searchHighlightStyle,
+	}
+	saved := make([]lipgloss.Style, len(styles))
+	for i, s := range styles {
+		saved[i] = *s
+	}
+	t.Cleanup(func() {
+		monochrome = false
+		lipgloss.SetColorProfile(profile)
+		for i, s := range styles {
+			*s = saved[i]
+		}
+	})
+	SetMonochrome()
+}
+
+// sgrParams returns the parameters of every SGR sequence in s
+func sgrParams(s string) [][]string {
+	var out [][]string
+	for _, m := range regexp.MustCompile(`\x1b\[([0-9;]*)m`).FindAllStringSubmatch(s, -1) {
+		out = append(out, strings.Split(m[1], ";"))
+	}
+	return out
+}
+
+func TestMonochromeSelectionKeepsAttributes(t *testing.T) {
+	setMonochrome(t)
+
+	var s selectionModel
+	vp := viewport.New(20, 3)
+	s.activate(vp, "first\nsecond\nthird", func(l string) string { return l }, nil)
+	s.anchor, s.cursor = 0, 1
+	s.applyToViewport(&vp)
+	out := stripColors(vp.View())
+
+	var reverse, underline bool
+	for _, params := range sgrParams(out) {
+		reverse = reverse || slices.Contains(params, "7")
+		underline = underline || slices.Contains(params, "4")
+		for _, p := range params {
+			if n, _ := strconv.Atoi(p); n >= 30 && n <= 49 || n >= 90 && n <= 107 {
+				t.Errorf("color parameter %s left in %q", p, out)
+			}
+		}
+	}
+	if !reverse || !underline {
+		t.Errorf("selected rows lost reverse (%v) or underline (%v): %q", reverse, underline, out)
+	}
+}
+
+func TestStripColors(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"plain", "plain"},
+		{"\x1b[31mred\x1b[0m", "red\x1b[0m"},
+		{"\x1b[1;38;2;255;0;0;48;5;17;7mx\x1b[m", "\x1b[1;7mx\x1b[m"},
+		{"\x1b[4;91;100mx", "\x1b[4mx"},
+		{"\x1b[2Kline", "\x1b[2Kline"}, // not SGR
+	}
+	for _, tt := range tests {
+		if got := stripColors(tt.in); got != tt.want {
+			t.Errorf("stripColors(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
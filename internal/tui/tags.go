@@ -0,0 +1,138 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kimaguri/simplx-toolkit/internal/config"
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+)
+
+// tagsSavedMsg carries the edited tags of a session
+type tagsSavedMsg struct {
+	session string
+	tags    []string
+}
+
+// tagsCancelledMsg closes the tags overlay without saving
+type tagsCancelledMsg struct{}
+
+// tagsModel is the small overlay for editing a session's tags
+type tagsModel struct {
+	session string
+	input   textinput.Model
+	inUse   []string // tags already assigned anywhere, shown as a hint
+	width   int
+	height  int
+}
+
+// newTagsModel creates the editor prefilled with the session's current tags
+func newTagsModel(session string, current, inUse []string) tagsModel {
+	ti := textinput.New()
+	ti.Placeholder = "frontend, backend, infra"
+	ti.Width = 40
+	ti.CharLimit = 200
+	ti.SetValue(strings.Join(current, ", "))
+	ti.CursorEnd()
+	ti.Focus()
+	return tagsModel{session: session, input: ti, inUse: inUse}
+}
+
+// Update handles editor input
+func (m tagsModel) Update(msg tea.Msg) (tagsModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "esc":
+		return m, func() tea.Msg { return tagsCancelledMsg{} }
+	case "enter":
+		saved := tagsSavedMsg{session: m.session, tags: config.ParseTags(m.input.Value())}
+		return m, func() tea.Msg { return saved }
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(keyMsg)
+	return m, cmd
+}
+
+// View renders the tags overlay
+func (m tagsModel) View() string {
+	hint := "Comma or space separated; leave empty to clear."
+	if len(m.inUse) > 0 {
+		hint += "\nIn use: " + strings.Join(m.inUse, ", ")
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		modalTitleStyle.Render("Tags for "+m.session),
+		"",
+		m.input.View(),
+		"",
+		dimStyle.Render(hint),
+		"",
+		dimStyle.Render("enter:save  esc:cancel"),
+	)
+
+	popup := modalStyle.Width(56).Render(content)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, popup)
+}
+
+// SetSize updates dimensions for centering
+func (m *tagsModel) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+}
+
+// renderTagChips renders tags as small colored chips separated by spaces
+func renderTagChips(tags []string) string {
+	chips := make([]string, len(tags))
+	for i, tag := range tags {
+		chips[i] = tagChipStyle(tag).Render(tag)
+	}
+	return strings.Join(chips, " ")
+}
+
+// filterByTag returns the sessions tagged with tag; all of them when tag is ""
+func filterByTag(procs []*devdash.RunningProcess, tags map[string][]string, tag string) []*devdash.RunningProcess {
+	if tag == "" {
+		return procs
+	}
+	var out []*devdash.RunningProcess
+	for _, rp := range procs {
+		for _, t := range tags[rp.Info.Name] {
+			if t == tag {
+				out = append(out, rp)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// cycleTagFilter moves the filter to the tag after the current one in inUse
+// (sorted), wrapping back to no filter. The selected session stays selected
+// if it is still listed.
+func (m *dashboardModel) cycleTagFilter(inUse []string) {
+	var selName string
+	if sel := m.SelectedProcess(); sel != nil {
+		selName = sel.Info.Name
+	}
+
+	next := ""
+	for _, tag := range inUse {
+		if tag > m.tagFilter {
+			next = tag
+			break
+		}
+	}
+	m.tagFilter = next
+	m.SetProcesses(m.all)
+
+	m.selected = 0
+	m.selectByName(selName)
+}
@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+)
+
+func TestTagsEditorSavesParsedTags(t *testing.T) {
+	m := newTagsModel("web", []string{"frontend"}, nil)
+	if got := m.input.Value(); got != "frontend" {
+		t.Fatalf("prefilled %q", got)
+	}
+	for _, r := range " UI,ui" {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	msg, ok := cmd().(tagsSavedMsg)
+	if !ok || msg.session != "web" || !reflect.DeepEqual(msg.tags, []string{"frontend", "ui"}) {
+		t.Errorf("saved %+v", msg)
+	}
+}
+
+func TestDashboardTagFilter(t *testing.T) {
+	newProc := func(name string) *devdash.RunningProcess {
+		return &devdash.RunningProcess{Info: devdash.SessionInfo{Name: name}}
+	}
+	m := newDashboardModel()
+	m.width, m.height = 80, 20
+	m.tags = map[string][]string{"api": {"backend"}, "web": {"frontend"}, "worker": {"backend"}}
+	m.SetProcesses([]*devdash.RunningProcess{newProc("web"), newProc("worker"), newProc("api"), newProc("docs")})
+	m.selected = 3 // worker
+
+	inUse := []string{"backend", "frontend"}
+	m.cycleTagFilter(inUse)
+	if m.tagFilter != "backend" || len(m.processes) != 2 {
+		t.Fatalf("filter %q lists %d sessions", m.tagFilter, len(m.processes))
+	}
+	if sel := m.SelectedProcess(); sel == nil || sel.Info.Name != "worker" {
+		t.Errorf("selection should stay on worker, got %v", sel)
+	}
+	if view := m.renderSessionList(40, 10); !strings.Contains(view, "#backend") {
+		t.Errorf("title should name the filter:\n%s", view)
+	}
+
+	m.cycleTagFilter(inUse)
+	if m.tagFilter != "frontend" || len(m.processes) != 1 || m.SelectedProcess().Info.Name != "web" {
+		t.Errorf("filter %q lists %d sessions", m.tagFilter, len(m.processes))
+	}
+	m.cycleTagFilter(inUse)
+	if m.tagFilter != "" || len(m.processes) != 4 {
+		t.Errorf("filter should wrap off, got %q with %d sessions", m.tagFilter, len(m.processes))
+	}
+}
@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+)
+
+// timeFormat selects how session times (uptime, last log line, idle) are
+// shown, set by the time_format config key
+type timeFormat int
+
+const (
+	timeShort timeFormat = iota // "short": 3h12m, 45s ago
+	timeLong                    // "long": 3 hours, 45 seconds ago, just now
+	timeClock                   // "clock": since 14:02:33, 14:02:33
+)
+
+// justNow is how recent an event is shown as "just now" in the long format
+const justNow = 5 * time.Second
+
+// parseTimeFormat reads a time_format value; unknown values are "short"
+func parseTimeFormat(s string) timeFormat {
+	switch s {
+	case "long":
+		return timeLong
+	case "clock":
+		return timeClock
+	}
+	return timeShort
+}
+
+// age formats how long something has been going since t, for uptime and
+// idle time. "" for the zero time.
+func (f timeFormat) age(t, now time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	switch f {
+	case timeLong:
+		return formatDurationLong(now.Sub(t))
+	case timeClock:
+		return "since " + formatClock(t, now)
+	}
+	return formatDuration(now.Sub(t))
+}
+
+// ago formats when an event at t happened, for the last log line.
+// "" for the zero time.
+func (f timeFormat) ago(t, now time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	d := now.Sub(t)
+	switch f {
+	case timeLong:
+		if d < justNow {
+			return "just now"
+		}
+		return formatDurationLong(d) + " ago"
+	case timeClock:
+		return formatClock(t, now)
+	}
+	return formatDuration(d) + " ago"
+}
+
+// formatDurationLong spells a duration out in its largest unit: 12 seconds,
+// 1 minute, 3 hours, 2 days
+func formatDurationLong(d time.Duration) string {
+	n, unit := int(d.Seconds()), "second"
+	switch {
+	case d >= 24*time.Hour:
+		n, unit = int(d.Hours()/24), "day"
+	case d >= time.Hour:
+		n, unit = int(d.Hours()), "hour"
+	case d >= time.Minute:
+		n, unit = int(d.Minutes()), "minute"
+	}
+	if n != 1 {
+		unit += "s"
+	}
+	return fmt.Sprintf("%d %s", n, unit)
+}
+
+// formatClock formats t as a wall-clock time, with the date when it isn't today
+func formatClock(t, now time.Time) string {
+	if y, m, d := t.Date(); y != now.Year() || m != now.Month() || d != now.Day() {
+		return t.Format("Jan 2 15:04")
+	}
+	return t.Format("15:04:05")
+}
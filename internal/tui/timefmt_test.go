@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeFormats(t *testing.T) {
+	now := time.Date(2026, 10, 15, 14, 30, 0, 0, time.Local)
+	cases := []struct {
+		f        timeFormat
+		t        time.Time
+		age, ago string
+	}{
+		{timeShort, now.Add(-3*time.Hour - 12*time.Minute), "3h12m", "3h12m ago"},
+		{timeShort, now.Add(-45 * time.Second), "45s", "45s ago"},
+		{timeLong, now.Add(-2 * time.Second), "2 seconds", "just now"},
+		{timeLong, now.Add(-time.Minute), "1 minute", "1 minute ago"},
+		{timeLong, now.Add(-3*time.Hour - 12*time.Minute), "3 hours", "3 hours ago"},
+		{timeClock, now.Add(-90 * time.Minute), "since 13:00:00", "13:00:00"},
+		{timeClock, now.Add(-36 * time.Hour), "since Oct 14 02:30", "Oct 14 02:30"},
+	}
+	for _, c := range cases {
+		if got := c.f.age(c.t, now); got != c.age {
+			t.Errorf("format %d age(%v) = %q, want %q", c.f, now.Sub(c.t), got, c.age)
+		}
+		if got := c.f.ago(c.t, now); got != c.ago {
+			t.Errorf("format %d ago(%v) = %q, want %q", c.f, now.Sub(c.t), got, c.ago)
+		}
+	}
+	if got := timeLong.age(time.Time{}, now); got != "" {
+		t.Errorf("zero time should format as empty, got %q", got)
+	}
+}
+
+func TestParseTimeFormat(t *testing.T) {
+	for s, want := range map[string]timeFormat{"": timeShort, "short": timeShort, "long": timeLong, "clock": timeClock, "bogus": timeShort} {
+		if got := parseTimeFormat(s); got != want {
+			t.Errorf("parseTimeFormat(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func TestTimeColumnWidthsFitClock(t *testing.T) {
+	now := time.Date(2026, 10, 15, 14, 30, 0, 0, time.Local)
+	then := time.Date(2026, 9, 28, 23, 59, 0, 0, time.Local) // two-digit day
+	uptime, last := timeColumnWidths(timeClock)
+	if got := timeClock.age(then, now); len(got) > uptime {
+		t.Errorf("uptime %q is wider than its column (%d)", got, uptime)
+	}
+	if got := timeClock.ago(then, now); len(got) > last {
+		t.Errorf("last log %q is wider than its column (%d)", got, last)
+	}
+}
@@ -1,14 +1,16 @@
 package tui
 
 import (
+	"context"
 	"fmt"
-	"os/exec"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/kimaguri/simplx-toolkit/internal/config"
 	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+	"github.com/kimaguri/simplx-toolkit/internal/discovery"
 )
 
 // --- Tunnel messages ---
@@ -63,15 +65,17 @@ type tunnelOverlayModel struct {
 	errMsg      string
 	focusCopy   bool // true = Copy focused, false = OK focused
 	copied      bool
+	runner      devdash.Runner // runs the clipboard helpers
 	width       int
 	height      int
 }
 
-func newTunnelOverlay(processName string) tunnelOverlayModel {
+func newTunnelOverlay(processName string, r devdash.Runner) tunnelOverlayModel {
 	return tunnelOverlayModel{
 		phase:       tunnelPhaseStarting,
 		processName: processName,
 		focusCopy:   true,
+		runner:      r,
 	}
 }
 
@@ -89,12 +93,15 @@ func (m tunnelOverlayModel) Update(msg tea.KeyMsg) (tunnelOverlayModel, tea.Cmd)
 		case "enter":
 			if m.focusCopy {
 				m.copied = true
-				return m, copyTunnelURL(m.url)
+				return m, copyTunnelURL(m.runner, m.url)
 			}
 			return m, func() tea.Msg { return tunnelOverlayClosedMsg{} }
 		case "c":
 			m.copied = true
-			return m, copyTunnelURL(m.url)
+			return m, copyTunnelURL(m.runner, m.url)
+		case "p":
+			name := m.processName
+			return m, func() tea.Msg { return tunnelDockMsg{name: name} }
 		case "esc", "q":
 			return m, func() tea.Msg { return tunnelOverlayClosedMsg{} }
 		}
@@ -162,7 +169,7 @@ func (m tunnelOverlayModel) viewActive(maxWidth int) string {
 		feedback = helpKeyStyle.Render("[URL copied]")
 	}
 
-	hint := dimStyle.Render("c:copy  tab:switch  enter:select  esc:close")
+	hint := dimStyle.Render("c:copy  p:pin  tab:switch  enter:select  esc:close")
 
 	parts := []string{title, "", urlBox, ""}
 	if feedback != "" {
@@ -192,30 +199,55 @@ func (m *tunnelOverlayModel) SetSize(w, h int) {
 
 // --- Tunnel commands ---
 
-// startTunnelCmd checks for cloudflared and starts a tunnel
-func startTunnelCmd(pm *devdash.ProcessManager, name string) tea.Cmd {
+// sessionTunnelOrigin returns where a session's tunnel reaches it, from its
+// project's .devdash.json; the defaults for sessions without a project
+func sessionTunnelOrigin(cfg *config.LocalConfig, wts []discovery.Worktree, info devdash.SessionInfo) devdash.TunnelOrigin {
+	_, proj, found := sessionProject(cfg, wts, info)
+	if !found {
+		return devdash.TunnelOrigin{}
+	}
+	pc := config.LoadProjectConfig(proj.Path)
+	return devdash.TunnelOrigin{Scheme: pc.TunnelScheme, Host: pc.TunnelHost, HostHeader: pc.TunnelHostHeader}
+}
+
+// openTunnel shows the tunnel overlay for the session called name and starts
+// its tunnel
+func (a App) openTunnel(name string) (App, tea.Cmd) {
+	a.tunnelOvl = newTunnelOverlay(name, a.pm.Runner())
+	a.tunnelOvl.SetSize(a.width, a.height)
+	a.overlay = overlayTunnel
+	return a, startTunnelCmd(a.pm, a.cfg, a.worktrees, name)
+}
+
+// startTunnelCmd checks for cloudflared and starts a tunnel to the session's
+// origin, read from its project config
+func startTunnelCmd(pm *devdash.ProcessManager, cfg *config.LocalConfig, wts []discovery.Worktree, name string) tea.Cmd {
 	return func() tea.Msg {
-		if !devdash.CloudflaredAvailable() {
+		if !pm.CloudflaredAvailable() {
 			return cloudflaredMissingMsg{name: name}
 		}
 
-		ti, err := pm.StartTunnel(name)
+		origin, port := devdash.TunnelOrigin{}, 0
+		if info, ok := pm.Info(name); ok {
+			origin, port = sessionTunnelOrigin(cfg, wts, info), info.Port
+		}
+
+		ti, err := pm.StartTunnel(name, origin)
 		if err != nil {
 			return tunnelErrorMsg{name: name, err: err}
 		}
 
 		select {
 		case url := <-ti.URLCh:
-			ti.Status = devdash.TunnelActive
-			ti.URL = url
+			pm.TunnelReady(name, ti, url)
 			return tunnelStartedMsg{name: name, url: url}
 		case <-ti.Done:
 			return tunnelErrorMsg{
 				name: name,
-				err:  fmt.Errorf("cloudflared exited before providing URL"),
+				err:  fmt.Errorf("cloudflared exited before providing URL (origin %s)", origin.URL(port)),
 			}
-		case <-time.After(30 * time.Second):
-			devdash.StopTunnel(ti)
+		case <-pm.Clock().After(30 * time.Second):
+			devdash.StopTunnel(ti, pm.Clock())
 			return tunnelErrorMsg{
 				name: name,
 				err:  fmt.Errorf("tunnel URL timeout (30s)"),
@@ -236,24 +268,14 @@ func stopTunnelCmd(pm *devdash.ProcessManager, name string) tea.Cmd {
 }
 
 // copyTunnelURL copies the tunnel URL to clipboard
-func copyTunnelURL(url string) tea.Cmd {
-	if err := copyToClipboard(url); err != nil {
-		return func() tea.Msg {
-			return ClipboardFeedbackMsg{Message: fmt.Sprintf("[Copy error: %v]", err)}
-		}
-	}
-	return tea.Batch(
-		func() tea.Msg {
-			return ClipboardFeedbackMsg{Message: "[Tunnel URL copied]"}
-		},
-		clipboardFeedbackTimeout(),
-	)
+func copyTunnelURL(r devdash.Runner, url string) tea.Cmd {
+	return copyCmd(r, url, "[Tunnel URL copied]")
 }
 
-// installCloudflaredCmd runs brew install cloudflared
-func installCloudflaredCmd() tea.Cmd {
+// installCloudflaredCmd runs brew install cloudflared through r
+func installCloudflaredCmd(r devdash.Runner) tea.Cmd {
 	return func() tea.Msg {
-		cmd := exec.Command("brew", "install", "cloudflared")
+		cmd := r.Command(context.Background(), "brew", "install", "cloudflared")
 		output, err := cmd.CombinedOutput()
 		if err != nil {
 			return cloudflaredInstalledMsg{
@@ -0,0 +1,85 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+)
+
+// tunnelDockH is the height of the pinned tunnel banner: the URL with a blank
+// row above and below, so it stands out from across the room
+const tunnelDockH = 3
+
+// tunnelDockMsg pins the tunnel of the session called name above the dashboard
+type tunnelDockMsg struct {
+	name string
+}
+
+// dockedTunnel returns the session whose tunnel is pinned, nil if none is or
+// it no longer has a tunnel
+func (m dashboardModel) dockedTunnel() *devdash.RunningProcess {
+	if m.dockName == "" {
+		return nil
+	}
+	for _, rp := range m.all {
+		if rp.Info.Name == m.dockName && rp.Tunnel != nil {
+			return rp
+		}
+	}
+	return nil
+}
+
+// dockHeight returns the rows the pinned tunnel banner takes, 0 without one
+func (m dashboardModel) dockHeight() int {
+	if m.dockName == "" {
+		return 0
+	}
+	return tunnelDockH
+}
+
+// dockTunnel pins the tunnel of the session called name; "" unpins
+func (m *dashboardModel) dockTunnel(name string) {
+	if m.dockName == name {
+		return
+	}
+	m.dockName = name
+	m.initViewport()
+	m.refreshLogViewport()
+}
+
+// renderTunnelDock renders the pinned tunnel banner across the full width. It
+// reads the session's tunnel as it is now, so a restarted tunnel's new URL
+// shows up without pinning it again.
+func (m dashboardModel) renderTunnelDock() string {
+	rp := m.dockedTunnel()
+	if rp == nil {
+		return ""
+	}
+	text := rp.Info.Name + "  →  "
+	switch {
+	case rp.Tunnel.URL != "":
+		text += rp.Tunnel.URL
+	case rp.Tunnel.Status == devdash.TunnelError:
+		text += "tunnel error"
+	default:
+		text += "tunnel starting..."
+	}
+	text = ansi.Truncate(text, max(m.width-2, 1), "…")
+	return tunnelDockStyle.Width(m.width).Render(text)
+}
+
+// toggleTunnelDock pins the selected session's tunnel above the dashboard, or
+// unpins it when it is the one already pinned
+func (a App) toggleTunnelDock() (App, tea.Cmd) {
+	sel := a.dashboard.SelectedProcess()
+	switch {
+	case sel != nil && a.dashboard.dockName == sel.Info.Name:
+		a.dashboard.dockTunnel("")
+		return a, feedbackCmd("[Tunnel URL unpinned]")
+	case sel == nil || sel.Tunnel == nil:
+		return a, feedbackCmd("[No tunnel to pin — press t to open one]")
+	}
+	a.dashboard.dockTunnel(sel.Info.Name)
+	return a, feedbackCmd("[Tunnel URL pinned]")
+}
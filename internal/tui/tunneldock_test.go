@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/kimaguri/simplx-toolkit/internal/devdash"
+)
+
+func TestTunnelDock(t *testing.T) {
+	rp := &devdash.RunningProcess{
+		Info:   devdash.SessionInfo{Name: "web", Port: 4000},
+		Status: devdash.StatusRunning,
+		Tunnel: &devdash.TunnelInfo{Status: devdash.TunnelActive, URL: "https://a.trycloudflare.com"},
+	}
+	m := newDashboardModel()
+	m.width, m.height = 100, 30
+	m.initViewport()
+	m.SetProcesses([]*devdash.RunningProcess{rp})
+	vpH := m.logViewport.Height
+	undocked := strings.Count(m.View(), "\n")
+
+	m.dockTunnel("web")
+	if m.logViewport.Height != vpH-tunnelDockH {
+		t.Errorf("viewport height = %d, want %d", m.logViewport.Height, vpH-tunnelDockH)
+	}
+	view := m.View()
+	if rows := strings.Count(view, "\n"); rows != undocked {
+		t.Errorf("view is %d rows with the banner, %d without; want the same", rows, undocked)
+	}
+	if !strings.Contains(ansi.Strip(view), "web  →  https://a.trycloudflare.com") {
+		t.Errorf("banner missing from view:\n%s", ansi.Strip(view))
+	}
+
+	// A restarted tunnel's new URL shows without pinning again
+	rp.Tunnel = &devdash.TunnelInfo{Status: devdash.TunnelActive, URL: "https://b.trycloudflare.com"}
+	if got := ansi.Strip(m.renderTunnelDock()); !strings.Contains(got, "https://b.trycloudflare.com") {
+		t.Errorf("banner = %q, want the new URL", got)
+	}
+
+	// Closing the tunnel unpins it and gives the rows back
+	rp.Tunnel = nil
+	m.SetProcesses([]*devdash.RunningProcess{rp})
+	if m.dockName != "" || m.logViewport.Height != vpH {
+		t.Errorf("dock = %q, viewport height = %d; want unpinned at %d", m.dockName, m.logViewport.Height, vpH)
+	}
+}
@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kimaguri/simplx-toolkit/internal/config"
+	"github.com/kimaguri/simplx-toolkit/internal/discovery"
+)
+
+// worktreesChangedMsg signals that the scan dirs changed on disk and the
+// worktree list may be out of date
+type worktreesChangedMsg struct{}
+
+// watcherStartedMsg delivers the worktree watcher once its first walk of
+// the scan dirs is done
+type watcherStartedMsg struct {
+	watcher  *discovery.Watcher
+	scanDirs []string // the dirs it watches
+}
+
+// startWorktreeWatcher watches the scan dirs for worktrees being added or
+// removed, off the UI goroutine since it walks them. Nothing is started when
+// the no_watch config key turns it off or watching fails.
+func startWorktreeWatcher(cfg *config.LocalConfig) tea.Cmd {
+	if cfg.NoWatch {
+		return nil
+	}
+	dirs := slices.Clone(cfg.ScanDirs)
+	return func() tea.Msg {
+		w, err := discovery.NewWatcher(dirs, discovery.DefaultWatchDebounce)
+		if err != nil {
+			return nil
+		}
+		return watcherStartedMsg{watcher: w, scanDirs: dirs}
+	}
+}
+
+// handleWatcherStarted keeps the started watcher and waits for its changes,
+// repointing it if the scan dirs changed while it walked the old ones
+func (a App) handleWatcherStarted(msg watcherStartedMsg) (App, tea.Cmd) {
+	a.watcher = msg.watcher
+	cmds := []tea.Cmd{waitWorktreesChanged(a.watcher)}
+	if !slices.Equal(msg.scanDirs, a.cfg.ScanDirs) {
+		cmds = append(cmds, a.watchScanDirs())
+	}
+	return a, tea.Batch(cmds...)
+}
+
+// Close stops the worktree watcher. Call it once the program has exited.
+func (a App) Close() {
+	if a.watcher != nil {
+		_ = a.watcher.Close()
+	}
+}
+
+// waitWorktreesChanged waits for the watcher's next settled burst of changes
+func waitWorktreesChanged(w *discovery.Watcher) tea.Cmd {
+	if w == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		<-w.Changes()
+		return worktreesChangedMsg{}
+	}
+}
+
+// watchScanDirs points the watcher at the scan dirs in the config, off the UI
+// goroutine since it walks them
+func (a App) watchScanDirs() tea.Cmd {
+	if a.watcher == nil {
+		return nil
+	}
+	w, dirs := a.watcher, a.cfg.ScanDirs
+	return func() tea.Msg {
+		w.SetScanDirs(dirs)
+		return nil
+	}
+}
+
+// refreshWorktrees rescans in the background after a change on disk; an open
+// launcher picks up the new list when the scan is done
+func (a App) refreshWorktrees() (App, tea.Cmd) {
+	refresh := a.discover(scanRequest{purpose: scanRefresh, scanDirs: a.cfg.ScanDirs})
+	return a, tea.Batch(refresh, waitWorktreesChanged(a.watcher))
+}
@@ -0,0 +1,28 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/kimaguri/simplx-toolkit/internal/config"
+)
+
+func TestWorktreeWatcherStartsFromCmd(t *testing.T) {
+	if cmd := startWorktreeWatcher(&config.LocalConfig{NoWatch: true}); cmd != nil {
+		t.Error("no_watch should start no watcher")
+	}
+
+	cfg := &config.LocalConfig{ScanDirs: []string{t.TempDir()}}
+	a := App{cfg: cfg}
+	msg, ok := startWorktreeWatcher(cfg)().(watcherStartedMsg)
+	if !ok || msg.watcher == nil {
+		t.Fatalf("start cmd returned %#v, want a started watcher", msg)
+	}
+
+	// Scan dirs changed during the first walk: the watcher is repointed
+	cfg.ScanDirs = []string{t.TempDir()}
+	a, cmd := a.handleWatcherStarted(msg)
+	defer a.Close()
+	if a.watcher != msg.watcher || cmd == nil {
+		t.Errorf("watcher kept: %v, cmd: %v", a.watcher == msg.watcher, cmd != nil)
+	}
+}
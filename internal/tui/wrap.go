@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/kimaguri/simplx-toolkit/internal/config"
+)
+
+// clipMark ends a row cut off at the right edge of a log panel
+const clipMark = "›"
+
+// hangingIndent is how much further than their line's own indentation
+// wrapped continuation rows are indented when wrap indent is on
+const hangingIndent = 2
+
+// wordwrap is ansi.Wordwrap without breakpoints, in the shape hangWrap takes
+func wordwrap(s string, width int) string {
+	return ansi.Wordwrap(s, width, "")
+}
+
+// hangWrap wraps each line of content to width with wrap. With indent set, the
+// continuation rows of a line that needs wrapping are wrapped narrower and
+// prefixed with its hanging indent so they read as one block; its first row
+// keeps the full width.
+func hangWrap(content string, width int, indent bool, wrap func(string, int) string) string {
+	if !indent || width <= 0 {
+		return wrap(content, width)
+	}
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if ansi.StringWidth(line) <= width {
+			continue
+		}
+		pad := continuationIndent(line, width)
+		first, _, _ := strings.Cut(wrap(line, width), "\n")
+		rest := ansi.TruncateLeft(line, ansi.StringWidth(first), "")
+		// Drop the spaces wrap broke the line at, keeping any styles before them
+		plain := ansi.Strip(rest)
+		if strings.TrimLeft(plain, " ") == "" {
+			continue // wrap couldn't break it
+		}
+		rest = ansi.TruncateLeft(rest, len(plain)-len(strings.TrimLeft(plain, " ")), "")
+		rows := strings.Split(wrap(rest, width-len(pad)), "\n")
+		for j := range rows {
+			rows[j] = pad + rows[j]
+		}
+		lines[i] = first + "\n" + strings.Join(rows, "\n")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// hangPad returns the indent hangWrap puts before line's continuation rows;
+// "" when indent is off or line doesn't wrap
+func hangPad(line string, width int, indent bool) string {
+	if !indent || width <= 0 || ansi.StringWidth(line) <= width {
+		return ""
+	}
+	return continuationIndent(line, width)
+}
+
+// continuationIndent returns the spaces continuation rows of line start with:
+// its leading whitespace plus hangingIndent, at most half of width so deeply
+// indented lines keep room for text
+func continuationIndent(line string, width int) string {
+	plain := ansi.Strip(line)
+	lead := len(plain) - len(strings.TrimLeft(plain, " \t"))
+	return strings.Repeat(" ", min(lead+hangingIndent, width/2))
+}
+
+// markClipped cuts each row of content wider than width to end in clipMark,
+// so clipped content doesn't go unnoticed
+func markClipped(content string, width int) string {
+	if width <= 1 {
+		return content
+	}
+	rows := strings.Split(content, "\n")
+	for i, row := range rows {
+		if ansi.StringWidth(row) > width {
+			rows[i] = clipRow(row, width)
+		}
+	}
+	return strings.Join(rows, "\n")
+}
+
+// clipRow cuts row to width, its last column the dimmed clipMark
+func clipRow(row string, width int) string {
+	return ansi.Truncate(row, width-1, "") + dimStyle.Render(clipMark)
+}
+
+// toggleWrapIndent switches the hanging indent of wrapped log lines in both
+// log views and saves the choice
+func (a App) toggleWrapIndent() (App, tea.Cmd) {
+	a.cfg.WrapIndent = !a.cfg.WrapIndent
+	_ = config.SaveConfig(a.cfg)
+	a.dashboard.wrapIndent = a.cfg.WrapIndent
+	a.logView.wrapIndent = a.cfg.WrapIndent
+	if a.view == viewLogFull {
+		a.logView.refreshLogViewport()
+	} else {
+		a.dashboard.refreshLogViewport()
+	}
+	if a.cfg.WrapIndent {
+		return a, feedbackCmd("[Wrapped log lines indented]")
+	}
+	return a, feedbackCmd("[Wrapped log lines flush]")
+}
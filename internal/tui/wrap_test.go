@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestHangWrap(t *testing.T) {
+	content := "short\n  error: something went wrong in the handler"
+
+	if got, want := hangWrap(content, 20, false, wordwrap), wordwrap(content, 20); got != want {
+		t.Errorf("indent off should wrap as before:\n%s\nwant:\n%s", got, want)
+	}
+
+	rows := strings.Split(hangWrap(content, 20, true, wordwrap), "\n")
+	if rows[0] != "short" {
+		t.Errorf("a line that fits should be unchanged, got %q", rows[0])
+	}
+	if rows[1] != "  error: something" {
+		t.Errorf("first row keeps its own indent and the full width, got %q", rows[1])
+	}
+	for _, row := range rows[2:] {
+		if !strings.HasPrefix(row, "    ") || strings.HasPrefix(row, "     ") {
+			t.Errorf("continuation %q should be indented by the line's 2 spaces plus %d", row, hangingIndent)
+		}
+		if len(row) > 20 {
+			t.Errorf("row %q is wider than 20", row)
+		}
+	}
+}
+
+func TestHangWrapKeepsStyles(t *testing.T) {
+	line := "\x1b[31mred text that wraps over\x1b[0m"
+	rows := strings.Split(hangWrap(line, 10, true, wordwrap), "\n")
+	if ansi.Strip(rows[0]) != "red text" || !strings.HasPrefix(rows[1], "  \x1b[31m") {
+		t.Errorf("rows = %q, want a full first row and styled continuations", rows)
+	}
+	if got := ansi.Strip(hangWrap("unbreakable_long_word", 10, true, wordwrap)); got != "unbreakable_long_word" {
+		t.Errorf("a line wrap can't break = %q", got)
+	}
+}
+
+func TestSelectionCopiesIndentedRowsWithoutIndent(t *testing.T) {
+	line := "GET /api/users returned 500 after retrying twice"
+	var s selectionModel
+	s.activate(viewport.New(16, 5), line, func(l string) string { return hangWrap(l, 16, true, wordwrap) },
+		func(l string) string { return hangPad(l, 16, true) })
+	if len(s.frozenLines) < 3 {
+		t.Fatalf("expected the line to wrap, got %q", s.frozenLines)
+	}
+
+	// Select only the second and third rows
+	s.anchor, s.cursor = 1, 2
+	got := s.selectedText()
+	if strings.HasPrefix(got, " ") || !strings.Contains(line, got) {
+		t.Errorf("partial copy %q should be a span of the line without the indent", got)
+	}
+}
+
+func TestMarkClipped(t *testing.T) {
+	content := "short\nhttps://example.com/a/very/long/url\n\x1b[31mred and long\x1b[0m"
+	got := strings.Split(markClipped(content, 10), "\n")
+	want := []string{"short", "https://e" + clipMark, "red and l" + clipMark}
+	for i, row := range got {
+		if ansi.Strip(row) != want[i] {
+			t.Errorf("row %d = %q, want %q", i, ansi.Strip(row), want[i])
+		}
+		if w := ansi.StringWidth(row); w > 10 {
+			t.Errorf("row %d is %d wide", i, w)
+		}
+	}
+}